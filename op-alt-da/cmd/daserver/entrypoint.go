@@ -6,6 +6,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 )
@@ -27,18 +28,45 @@ func StartDAServer(cliCtx *cli.Context) error {
 
 	l.Info("Initializing AltDA server...")
 
-	var store altda.KVStore
+	var stores []NamedStore
 
 	if cfg.FileStoreEnabled() {
 		l.Info("Using file storage", "path", cfg.FileStoreDirPath)
-		store = NewFileStore(cfg.FileStoreDirPath)
-	} else if cfg.S3Enabled() {
+		stores = append(stores, NamedStore{Name: "file", Store: NewFileStore(cfg.FileStoreDirPath)})
+	}
+	if cfg.S3Enabled() {
 		l.Info("Using S3 storage", "bucket", cfg.S3Config().Bucket)
 		s3, err := NewS3Store(cfg.S3Config())
 		if err != nil {
 			return fmt.Errorf("failed to create S3 store: %w", err)
 		}
-		store = s3
+		stores = append(stores, NamedStore{Name: "s3", Store: s3})
+	}
+	if cfg.PebbleEnabled() {
+		pebbleCfg, err := cfg.PebbleConfig()
+		if err != nil {
+			return err
+		}
+		l.Info("Using pebble storage", "path", cfg.PebblePath, "retention", pebbleCfg.Retention)
+		pebble, err := NewPebbleStore(l, pebbleCfg, clock.SystemClock)
+		if err != nil {
+			return fmt.Errorf("failed to create pebble store: %w", err)
+		}
+		pebble.Start()
+		defer func() {
+			if err := pebble.Close(); err != nil {
+				l.Error("failed to close pebble store", "err", err)
+			}
+		}()
+		stores = append(stores, NamedStore{Name: "pebble", Store: pebble})
+	}
+
+	var store altda.KVStore
+	if cfg.Replicated {
+		l.Info("Replicating inputs to all storage backends", "count", len(stores))
+		store = NewReplicatedStore(l, stores)
+	} else {
+		store = stores[0].Store
 	}
 
 	server := altda.NewDAServer(cliCtx.String(ListenAddrFlagName), cliCtx.Int(PortFlagName), store, l, cfg.UseGenericComm)
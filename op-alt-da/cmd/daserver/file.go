@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"encoding/hex"
+	"fmt"
 	"os"
 	"path"
 
@@ -34,6 +35,17 @@ func (s *FileStore) Put(ctx context.Context, key []byte, value []byte) error {
 	return os.WriteFile(s.fileName(key), value, 0600)
 }
 
+func (s *FileStore) HealthCheck(ctx context.Context) error {
+	info, err := os.Stat(s.directory)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", s.directory)
+	}
+	return nil
+}
+
 func (s *FileStore) fileName(key []byte) string {
 	return path.Join(s.directory, hex.EncodeToString(key))
 }
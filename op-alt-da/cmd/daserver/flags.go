@@ -3,10 +3,13 @@ package main
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 )
 
@@ -18,7 +21,14 @@ const (
 	S3AccessKeyIDFlagName     = "s3.access-key-id"
 	S3AccessKeySecretFlagName = "s3.access-key-secret"
 	FileStorePathFlagName     = "file.path"
+	PebblePathFlagName        = "pebble.path"
+	ReplicatedFlagName        = "replicated"
 	GenericCommFlagName       = "generic-commitment"
+
+	RetentionRollupConfigFlagName  = "retention.rollup-config"
+	RetentionL1BlockTimeFlagName   = "retention.l1-block-time"
+	RetentionMarginFlagName        = "retention.margin"
+	RetentionPruneIntervalFlagName = "retention.prune-interval"
 )
 
 const EnvVarPrefix = "OP_ALTDA_SERVER"
@@ -45,6 +55,40 @@ var (
 		Usage:   "path to directory for file storage",
 		EnvVars: prefixEnvVars("FILESTORE_PATH"),
 	}
+	PebblePathFlag = &cli.StringFlag{
+		Name:    PebblePathFlagName,
+		Usage:   "path to directory for pebble storage",
+		EnvVars: prefixEnvVars("PEBBLE_PATH"),
+	}
+	ReplicatedFlag = &cli.BoolFlag{
+		Name:    ReplicatedFlagName,
+		Usage:   "store inputs in all enabled storage backends, and read them from any backend with an input matching the commitment",
+		EnvVars: prefixEnvVars("REPLICATED"),
+		Value:   false,
+	}
+	RetentionRollupConfigFlag = &cli.StringFlag{
+		Name:    RetentionRollupConfigFlagName,
+		Usage:   "path to the rollup config of the chain, to read the challenge and resolve windows from. Inputs in pebble storage are pruned once they can no longer be challenged or resolved. Inputs are kept forever if not set.",
+		EnvVars: prefixEnvVars("RETENTION_ROLLUP_CONFIG"),
+	}
+	RetentionL1BlockTimeFlag = &cli.DurationFlag{
+		Name:    RetentionL1BlockTimeFlagName,
+		Usage:   "L1 block time, to convert the challenge and resolve windows to a retention period",
+		Value:   12 * time.Second,
+		EnvVars: prefixEnvVars("RETENTION_L1_BLOCK_TIME"),
+	}
+	RetentionMarginFlag = &cli.DurationFlag{
+		Name:    RetentionMarginFlagName,
+		Usage:   "margin added to the retention period, for the delay between storing an input and the L1 inclusion of its commitment, which the challenge window starts at",
+		Value:   24 * time.Hour,
+		EnvVars: prefixEnvVars("RETENTION_MARGIN"),
+	}
+	RetentionPruneIntervalFlag = &cli.DurationFlag{
+		Name:    RetentionPruneIntervalFlagName,
+		Usage:   "interval at which inputs past the retention period are pruned",
+		Value:   time.Hour,
+		EnvVars: prefixEnvVars("RETENTION_PRUNE_INTERVAL"),
+	}
 	GenericCommFlag = &cli.BoolFlag{
 		Name:    GenericCommFlagName,
 		Usage:   "enable generic commitments for testing. Not for production use.",
@@ -87,6 +131,12 @@ var optionalFlags = []cli.Flag{
 	S3EndpointFlag,
 	S3AccessKeyIDFlag,
 	S3AccessKeySecretFlag,
+	PebblePathFlag,
+	ReplicatedFlag,
+	RetentionRollupConfigFlag,
+	RetentionL1BlockTimeFlag,
+	RetentionMarginFlag,
+	RetentionPruneIntervalFlag,
 	GenericCommFlag,
 }
 
@@ -104,6 +154,12 @@ type CLIConfig struct {
 	S3Endpoint        string
 	S3AccessKeyID     string
 	S3AccessKeySecret string
+	PebblePath        string
+	Replicated        bool
+	RollupConfigPath  string
+	L1BlockTime       time.Duration
+	RetentionMargin   time.Duration
+	PruneInterval     time.Duration
 	UseGenericComm    bool
 }
 
@@ -114,23 +170,51 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		S3Endpoint:        ctx.String(S3EndpointFlagName),
 		S3AccessKeyID:     ctx.String(S3AccessKeyIDFlagName),
 		S3AccessKeySecret: ctx.String(S3AccessKeySecretFlagName),
+		PebblePath:        ctx.String(PebblePathFlagName),
+		Replicated:        ctx.Bool(ReplicatedFlagName),
+		RollupConfigPath:  ctx.String(RetentionRollupConfigFlagName),
+		L1BlockTime:       ctx.Duration(RetentionL1BlockTimeFlagName),
+		RetentionMargin:   ctx.Duration(RetentionMarginFlagName),
+		PruneInterval:     ctx.Duration(RetentionPruneIntervalFlagName),
 		UseGenericComm:    ctx.Bool(GenericCommFlagName),
 	}
 }
 
 func (c CLIConfig) Check() error {
-	if !c.S3Enabled() && !c.FileStoreEnabled() {
+	backends := c.enabledBackends()
+	if backends == 0 {
 		return errors.New("at least one storage backend must be enabled")
 	}
-	if c.S3Enabled() && c.FileStoreEnabled() {
-		return errors.New("only one storage backend can be enabled")
+	if backends > 1 && !c.Replicated {
+		return errors.New("only one storage backend can be enabled, unless replication is enabled")
+	}
+	if backends < 2 && c.Replicated {
+		return errors.New("replication requires at least two storage backends")
 	}
 	if c.S3Enabled() && (c.S3Bucket == "" || c.S3Endpoint == "" || c.S3AccessKeyID == "" || c.S3AccessKeySecret == "") {
 		return errors.New("all S3 flags must be set")
 	}
+	if c.RetentionEnabled() {
+		if !c.PebbleEnabled() {
+			return errors.New("retention is only supported by pebble storage")
+		}
+		if c.PruneInterval == 0 {
+			return errors.New("prune interval must be set when retention is enabled")
+		}
+	}
 	return nil
 }
 
+func (c CLIConfig) enabledBackends() int {
+	count := 0
+	for _, enabled := range []bool{c.FileStoreEnabled(), c.S3Enabled(), c.PebbleEnabled()} {
+		if enabled {
+			count++
+		}
+	}
+	return count
+}
+
 func (c CLIConfig) S3Enabled() bool {
 	return !(c.S3Bucket == "" && c.S3Endpoint == "" && c.S3AccessKeyID == "" && c.S3AccessKeySecret == "")
 }
@@ -148,6 +232,45 @@ func (c CLIConfig) FileStoreEnabled() bool {
 	return c.FileStoreDirPath != ""
 }
 
+func (c CLIConfig) PebbleEnabled() bool {
+	return c.PebblePath != ""
+}
+
+func (c CLIConfig) RetentionEnabled() bool {
+	return c.RollupConfigPath != ""
+}
+
+// RetentionPeriod is how long inputs are retained for, 0 if they are kept forever.
+// The challenge and resolve windows are read from the alt-DA config of the rollup config,
+// and the retention margin is added, since the windows start at the L1 inclusion of the commitment
+// rather than when the input was stored.
+func (c CLIConfig) RetentionPeriod() (time.Duration, error) {
+	if !c.RetentionEnabled() {
+		return 0, nil
+	}
+	rollupCfg, err := jsonutil.LoadJSON[rollup.Config](c.RollupConfigPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load rollup config: %w", err)
+	}
+	altDACfg, err := rollupCfg.GetOPAltDAConfig()
+	if err != nil {
+		return 0, fmt.Errorf("invalid alt-DA config of rollup config: %w", err)
+	}
+	return altDACfg.RetentionPeriod(c.L1BlockTime) + c.RetentionMargin, nil
+}
+
+func (c CLIConfig) PebbleConfig() (PebbleConfig, error) {
+	retention, err := c.RetentionPeriod()
+	if err != nil {
+		return PebbleConfig{}, err
+	}
+	return PebbleConfig{
+		Path:          c.PebblePath,
+		Retention:     retention,
+		PruneInterval: c.PruneInterval,
+	}, nil
+}
+
 func CheckRequired(ctx *cli.Context) error {
 	for _, f := range requiredFlags {
 		if !ctx.IsSet(f.Names()[0]) {
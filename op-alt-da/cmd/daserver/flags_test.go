@@ -0,0 +1,48 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+)
+
+func writeRollupConfig(t *testing.T, cfg *rollup.Config) string {
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "rollup.json")
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	return path
+}
+
+func TestRetentionPeriod(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		cfg := CLIConfig{L1BlockTime: 12 * time.Second, RetentionMargin: time.Hour}
+		retention, err := cfg.RetentionPeriod()
+		require.NoError(t, err)
+		require.Zero(t, retention)
+	})
+
+	t.Run("FromRollupConfig", func(t *testing.T) {
+		path := writeRollupConfig(t, &rollup.Config{AltDAConfig: &rollup.AltDAConfig{
+			CommitmentType:    "KeccakCommitment",
+			DAChallengeWindow: 100,
+			DAResolveWindow:   50,
+		}})
+		cfg := CLIConfig{RollupConfigPath: path, L1BlockTime: 12 * time.Second, RetentionMargin: time.Hour}
+		retention, err := cfg.RetentionPeriod()
+		require.NoError(t, err)
+		require.Equal(t, 150*12*time.Second+time.Hour, retention)
+	})
+
+	t.Run("NoAltDA", func(t *testing.T) {
+		cfg := CLIConfig{RollupConfigPath: writeRollupConfig(t, &rollup.Config{}), L1BlockTime: 12 * time.Second}
+		_, err := cfg.RetentionPeriod()
+		require.ErrorContains(t, err, "no altDA config")
+	})
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum/go-ethereum/log"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+const (
+	// pebbleDataPrefix prefixes the keys of the stored inputs.
+	// The values are the 8 byte big-endian unix time the input was stored at, followed by the input.
+	pebbleDataPrefix = 'd'
+	// pebbleExpiryPrefix prefixes the keys of the index used for pruning:
+	// the 8 byte big-endian unix time the input was stored at, followed by the key of the input.
+	pebbleExpiryPrefix = 'e'
+)
+
+type PebbleConfig struct {
+	Path string
+	// Retention is how long inputs are kept for after they were stored. 0 keeps inputs forever.
+	// The challenge and resolve windows of a commitment start once it is included on L1, after the input was stored,
+	// so the retention must include a margin for the delay between storing an input and the L1 inclusion of its commitment.
+	Retention time.Duration
+	// PruneInterval is how often inputs past the retention period are pruned.
+	PruneInterval time.Duration
+}
+
+// PebbleStoreStats are the stats of the PebbleStore served on /stats.
+type PebbleStoreStats struct {
+	DiskSpaceUsage uint64    `json:"diskSpaceUsage"`
+	Pruned         uint64    `json:"pruned"`
+	LastPrune      time.Time `json:"lastPrune"`
+}
+
+// PebbleStore stores inputs in a local pebble database,
+// and prunes inputs once they are past the retention period.
+type PebbleStore struct {
+	log   log.Logger
+	cfg   PebbleConfig
+	clock clock.Clock
+	db    *pebble.DB
+
+	pruned    atomic.Uint64
+	lastPrune atomic.Int64
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewPebbleStore(logger log.Logger, cfg PebbleConfig, cl clock.Clock) (*PebbleStore, error) {
+	db, err := pebble.Open(cfg.Path, &pebble.Options{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open pebble db at %s: %w", cfg.Path, err)
+	}
+	return &PebbleStore{
+		log:   logger,
+		cfg:   cfg,
+		clock: cl,
+		db:    db,
+	}, nil
+}
+
+// Start starts pruning inputs in the background, if a retention period is configured.
+func (s *PebbleStore) Start() {
+	if s.cfg.Retention == 0 {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.wg.Add(1)
+	go s.pruneLoop(ctx)
+}
+
+func (s *PebbleStore) pruneLoop(ctx context.Context) {
+	defer s.wg.Done()
+	ticker := s.clock.NewTicker(s.cfg.PruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.Ch():
+			if err := s.Prune(ctx); err != nil {
+				s.log.Error("Failed to prune expired inputs", "err", err)
+			}
+		}
+	}
+}
+
+func (s *PebbleStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	value, closer, err := s.db.Get(dataKey(key))
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, altda.ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	if len(value) < 8 {
+		return nil, fmt.Errorf("invalid stored value of length %d", len(value))
+	}
+	if s.expired(binary.BigEndian.Uint64(value[:8])) {
+		// Not pruned yet, but past the retention period
+		return nil, altda.ErrNotFound
+	}
+	return append([]byte(nil), value[8:]...), nil
+}
+
+func (s *PebbleStore) Put(ctx context.Context, key []byte, value []byte) error {
+	storedAt := uint64(s.clock.Now().Unix())
+	data := make([]byte, 8, 8+len(value))
+	binary.BigEndian.PutUint64(data, storedAt)
+	data = append(data, value...)
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	if err := batch.Set(dataKey(key), data, nil); err != nil {
+		return err
+	}
+	if err := batch.Set(expiryKey(storedAt, key), nil, nil); err != nil {
+		return err
+	}
+	return batch.Commit(pebble.Sync)
+}
+
+// Prune deletes all inputs that are past the retention period.
+func (s *PebbleStore) Prune(ctx context.Context) error {
+	if s.cfg.Retention == 0 {
+		return nil
+	}
+	cutoff := uint64(s.clock.Now().Add(-s.cfg.Retention).Unix())
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: []byte{pebbleExpiryPrefix},
+		// keys of inputs stored at the cutoff time itself are not expired yet
+		UpperBound: expiryKey(cutoff, nil),
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	var pruned uint64
+	for iter.First(); iter.Valid(); iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		expKey := iter.Key()
+		storedAt := binary.BigEndian.Uint64(expKey[1:9])
+		key := dataKey(expKey[9:])
+		if err := batch.Delete(expKey, nil); err != nil {
+			return err
+		}
+		// The input may have been stored again since, in which case it is indexed under a later time.
+		value, closer, err := s.db.Get(key)
+		if errors.Is(err, pebble.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		current := len(value) >= 8 && binary.BigEndian.Uint64(value[:8]) == storedAt
+		closer.Close()
+		if current {
+			if err := batch.Delete(key, nil); err != nil {
+				return err
+			}
+			pruned++
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return err
+	}
+	s.pruned.Add(pruned)
+	s.lastPrune.Store(s.clock.Now().Unix())
+	if pruned > 0 {
+		s.log.Info("Pruned expired inputs", "count", pruned, "retention", s.cfg.Retention)
+	}
+	return nil
+}
+
+func (s *PebbleStore) expired(storedAt uint64) bool {
+	return s.cfg.Retention != 0 && storedAt < uint64(s.clock.Now().Add(-s.cfg.Retention).Unix())
+}
+
+func (s *PebbleStore) HealthCheck(ctx context.Context) error {
+	_, closer, err := s.db.Get([]byte{pebbleDataPrefix})
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("pebble db unavailable: %w", err)
+	}
+	return closer.Close()
+}
+
+func (s *PebbleStore) Stats() any {
+	var lastPrune time.Time
+	if t := s.lastPrune.Load(); t != 0 {
+		lastPrune = time.Unix(t, 0)
+	}
+	return PebbleStoreStats{
+		DiskSpaceUsage: s.db.Metrics().DiskSpaceUsage(),
+		Pruned:         s.pruned.Load(),
+		LastPrune:      lastPrune,
+	}
+}
+
+func (s *PebbleStore) Close() error {
+	if s.cancel != nil {
+		s.cancel()
+		s.wg.Wait()
+	}
+	return s.db.Close()
+}
+
+func dataKey(key []byte) []byte {
+	return append([]byte{pebbleDataPrefix}, key...)
+}
+
+func expiryKey(storedAt uint64, key []byte) []byte {
+	out := make([]byte, 9, 9+len(key))
+	out[0] = pebbleExpiryPrefix
+	binary.BigEndian.PutUint64(out[1:], storedAt)
+	return append(out, key...)
+}
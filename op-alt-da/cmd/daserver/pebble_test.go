@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestPebbleStore(t *testing.T) {
+	ctx := context.Background()
+	logger := testlog.Logger(t, log.LevelInfo)
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	store, err := NewPebbleStore(logger, PebbleConfig{Path: t.TempDir(), Retention: time.Hour, PruneInterval: time.Minute}, cl)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+
+	_, err = store.Get(ctx, []byte("missing"))
+	require.ErrorIs(t, err, altda.ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, []byte("a"), []byte("input a")))
+	cl.AdvanceTime(30 * time.Minute)
+	require.NoError(t, store.Put(ctx, []byte("b"), []byte("input b")))
+	require.NoError(t, store.HealthCheck(ctx))
+
+	value, err := store.Get(ctx, []byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("input a"), value)
+
+	cl.AdvanceTime(31 * time.Minute)
+	_, err = store.Get(ctx, []byte("a"))
+	require.ErrorIs(t, err, altda.ErrNotFound, "expired inputs are not served before they are pruned")
+
+	require.NoError(t, store.Prune(ctx))
+	require.Equal(t, uint64(1), store.Stats().(PebbleStoreStats).Pruned)
+	value, err = store.Get(ctx, []byte("b"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("input b"), value)
+
+	// Storing an input again resets its retention period
+	require.NoError(t, store.Put(ctx, []byte("b"), []byte("input b")))
+	cl.AdvanceTime(31 * time.Minute)
+	require.NoError(t, store.Prune(ctx))
+	value, err = store.Get(ctx, []byte("b"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("input b"), value)
+	require.Equal(t, uint64(1), store.Stats().(PebbleStoreStats).Pruned)
+
+	cl.AdvanceTime(30 * time.Minute)
+	require.NoError(t, store.Prune(ctx))
+	_, err = store.Get(ctx, []byte("b"))
+	require.ErrorIs(t, err, altda.ErrNotFound)
+	require.Equal(t, uint64(2), store.Stats().(PebbleStoreStats).Pruned)
+}
+
+func TestPebbleStoreWithoutRetention(t *testing.T) {
+	ctx := context.Background()
+	cl := clock.NewDeterministicClock(time.Unix(1_000_000, 0))
+	store, err := NewPebbleStore(testlog.Logger(t, log.LevelInfo), PebbleConfig{Path: t.TempDir()}, cl)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = store.Close() })
+	store.Start()
+
+	require.NoError(t, store.Put(ctx, []byte("a"), []byte("input a")))
+	cl.AdvanceTime(365 * 24 * time.Hour)
+	require.NoError(t, store.Prune(ctx))
+	value, err := store.Get(ctx, []byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("input a"), value)
+}
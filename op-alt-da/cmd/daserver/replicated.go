@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+)
+
+// NamedStore is a KVStore with a name to identify it in logs and stats.
+type NamedStore struct {
+	Name  string
+	Store altda.KVStore
+}
+
+type replicaCounters struct {
+	puts           atomic.Uint64
+	putErrors      atomic.Uint64
+	gets           atomic.Uint64
+	getErrors      atomic.Uint64
+	verifyFailures atomic.Uint64
+}
+
+// ReplicaStats are the stats of a single backend of the ReplicatedStore.
+type ReplicaStats struct {
+	Name           string `json:"name"`
+	Puts           uint64 `json:"puts"`
+	PutErrors      uint64 `json:"putErrors"`
+	Gets           uint64 `json:"gets"`
+	GetErrors      uint64 `json:"getErrors"`
+	VerifyFailures uint64 `json:"verifyFailures"`
+	Store          any    `json:"store,omitempty"`
+}
+
+// ReplicatedStore writes inputs to all of its backends,
+// and reads inputs from the first backend that returns an input matching the commitment.
+// Inputs of generic commitments cannot be verified, and are read from the first backend that has them.
+type ReplicatedStore struct {
+	log      log.Logger
+	replicas []NamedStore
+	counters []replicaCounters
+}
+
+func NewReplicatedStore(logger log.Logger, replicas []NamedStore) *ReplicatedStore {
+	return &ReplicatedStore{
+		log:      logger,
+		replicas: replicas,
+		counters: make([]replicaCounters, len(replicas)),
+	}
+}
+
+// Put stores the input in all backends concurrently, and fails if any of the backends fails.
+func (s *ReplicatedStore) Put(ctx context.Context, key []byte, value []byte) error {
+	errs := make([]error, len(s.replicas))
+	var wg sync.WaitGroup
+	for i, replica := range s.replicas {
+		wg.Add(1)
+		go func(i int, replica NamedStore) {
+			defer wg.Done()
+			if err := replica.Store.Put(ctx, key, value); err != nil {
+				s.counters[i].putErrors.Add(1)
+				errs[i] = fmt.Errorf("failed to store input in %s: %w", replica.Name, err)
+				return
+			}
+			s.counters[i].puts.Add(1)
+		}(i, replica)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// Get returns the input from the first backend that has an input matching the commitment.
+// Returns ErrNotFound if none of the backends have a valid input.
+func (s *ReplicatedStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	// Only keccak256 commitments can be verified, generic commitments are opaque.
+	comm, err := altda.DecodeCommitmentData(key)
+	if err != nil || comm.CommitmentType() != altda.Keccak256CommitmentType {
+		comm = nil
+	}
+	var errs []error
+	for i, replica := range s.replicas {
+		value, err := replica.Store.Get(ctx, key)
+		if errors.Is(err, altda.ErrNotFound) {
+			continue
+		} else if err != nil {
+			s.counters[i].getErrors.Add(1)
+			s.log.Warn("Failed to read input from replica", "replica", replica.Name, "err", err)
+			errs = append(errs, fmt.Errorf("failed to read input from %s: %w", replica.Name, err))
+			continue
+		}
+		if comm != nil {
+			if err := comm.Verify(value); err != nil {
+				s.counters[i].verifyFailures.Add(1)
+				s.log.Warn("Replica returned input not matching the commitment", "replica", replica.Name, "commitment", comm)
+				errs = append(errs, fmt.Errorf("invalid input from %s: %w", replica.Name, err))
+				continue
+			}
+		}
+		s.counters[i].gets.Add(1)
+		return value, nil
+	}
+	if len(errs) == 0 {
+		return nil, altda.ErrNotFound
+	}
+	return nil, errors.Join(errs...)
+}
+
+// HealthCheck checks all backends, since inputs can only be stored if all backends are available.
+func (s *ReplicatedStore) HealthCheck(ctx context.Context) error {
+	var errs []error
+	for _, replica := range s.replicas {
+		checker, ok := replica.Store.(altda.HealthChecker)
+		if !ok {
+			continue
+		}
+		if err := checker.HealthCheck(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%s is unhealthy: %w", replica.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *ReplicatedStore) Stats() any {
+	stats := make([]ReplicaStats, len(s.replicas))
+	for i, replica := range s.replicas {
+		stats[i] = ReplicaStats{
+			Name:           replica.Name,
+			Puts:           s.counters[i].puts.Load(),
+			PutErrors:      s.counters[i].putErrors.Load(),
+			Gets:           s.counters[i].gets.Load(),
+			GetErrors:      s.counters[i].getErrors.Load(),
+			VerifyFailures: s.counters[i].verifyFailures.Load(),
+		}
+		if reporter, ok := replica.Store.(altda.StatsReporter); ok {
+			stats[i].Store = reporter.Stats()
+		}
+	}
+	return stats
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestReplicatedStore(t *testing.T) {
+	ctx := context.Background()
+	first := altda.NewMemStore()
+	second := altda.NewMemStore()
+	store := NewReplicatedStore(testlog.Logger(t, log.LevelInfo), []NamedStore{
+		{Name: "first", Store: first},
+		{Name: "second", Store: second},
+	})
+
+	input := []byte("input")
+	key := altda.NewKeccak256Commitment(input).Encode()
+	require.NoError(t, store.Put(ctx, key, input))
+	for _, replica := range []*altda.MemStore{first, second} {
+		value, err := replica.Get(ctx, key)
+		require.NoError(t, err)
+		require.Equal(t, input, value)
+	}
+
+	// Corrupted inputs are skipped in favour of a replica with an input matching the commitment
+	require.NoError(t, first.Put(ctx, key, []byte("corrupted")))
+	value, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	require.Equal(t, input, value)
+	stats := store.Stats().([]ReplicaStats)
+	require.Equal(t, uint64(1), stats[0].VerifyFailures)
+	require.Equal(t, uint64(1), stats[1].Gets)
+
+	require.NoError(t, second.Put(ctx, key, []byte("corrupted")))
+	_, err = store.Get(ctx, key)
+	require.ErrorIs(t, err, altda.ErrCommitmentMismatch)
+
+	_, err = store.Get(ctx, altda.NewKeccak256Commitment([]byte("unknown")).Encode())
+	require.ErrorIs(t, err, altda.ErrNotFound)
+
+	// Generic commitments can't be verified, the first replica with the input is used
+	generic := altda.NewGenericCommitment([]byte("generic")).Encode()
+	require.NoError(t, second.Put(ctx, generic, []byte("generic input")))
+	value, err = store.Get(ctx, generic)
+	require.NoError(t, err)
+	require.Equal(t, []byte("generic input"), value)
+}
+
+func TestReplicatedStoreFailingReplica(t *testing.T) {
+	ctx := context.Background()
+	healthy := altda.NewMemStore()
+	failing := &failingStore{err: errors.New("unavailable")}
+	store := NewReplicatedStore(testlog.Logger(t, log.LevelInfo), []NamedStore{
+		{Name: "failing", Store: failing},
+		{Name: "healthy", Store: healthy},
+	})
+
+	input := []byte("input")
+	key := altda.NewKeccak256Commitment(input).Encode()
+	require.ErrorIs(t, store.Put(ctx, key, input), failing.err, "puts must succeed on all replicas")
+
+	value, err := store.Get(ctx, key)
+	require.NoError(t, err, "reads are served by any replica")
+	require.Equal(t, input, value)
+
+	require.ErrorIs(t, store.HealthCheck(ctx), failing.err)
+	stats := store.Stats().([]ReplicaStats)
+	require.Equal(t, uint64(1), stats[0].PutErrors)
+	require.Equal(t, uint64(1), stats[0].GetErrors)
+	require.Equal(t, uint64(1), stats[1].Puts)
+}
+
+type failingStore struct {
+	err error
+}
+
+func (s *failingStore) Get(ctx context.Context, key []byte) ([]byte, error) {
+	return nil, s.err
+}
+
+func (s *failingStore) Put(ctx context.Context, key []byte, value []byte) error {
+	return s.err
+}
+
+func (s *failingStore) HealthCheck(ctx context.Context) error {
+	return s.err
+}
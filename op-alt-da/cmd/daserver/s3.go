@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"fmt"
 	"io"
 
 	"github.com/minio/minio-go/v7"
@@ -56,6 +57,17 @@ func (s *S3Store) Get(ctx context.Context, key []byte) ([]byte, error) {
 	return data, nil
 }
 
+func (s *S3Store) HealthCheck(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.cfg.Bucket)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("bucket %s does not exist", s.cfg.Bucket)
+	}
+	return nil
+}
+
 func (s *S3Store) Put(ctx context.Context, key []byte, value []byte) error {
 	_, err := s.client.PutObject(ctx, s.cfg.Bucket, hex.EncodeToString(key), bytes.NewReader(value), int64(len(value)), minio.PutObjectOptions{})
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
@@ -59,6 +60,12 @@ type Config struct {
 	ResolveWindow uint64
 }
 
+// RetentionPeriod returns how long input data has to be retained after it is committed to,
+// until it can no longer be challenged or resolved.
+func (c Config) RetentionPeriod(l1BlockTime time.Duration) time.Duration {
+	return time.Duration(c.ChallengeWindow+c.ResolveWindow) * l1BlockTime
+}
+
 type DA struct {
 	log     log.Logger
 	cfg     Config
@@ -142,6 +142,8 @@ func (s *FakeDAServer) Start() error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/get/", s.HandleGet)
 	mux.HandleFunc("/put/", s.HandlePut)
+	mux.HandleFunc("/health", s.HandleHealth)
+	mux.HandleFunc("/stats", s.HandleStats)
 	s.httpServer.Handler = mux
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +13,7 @@ import (
 	"net/http"
 	"path"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/rpc"
@@ -26,6 +28,36 @@ type KVStore interface {
 	Put(ctx context.Context, key []byte, value []byte) error
 }
 
+// HealthChecker is implemented by KVStores that can check whether their storage backend is available.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// StatsReporter is implemented by KVStores that report statistics about their storage backend.
+// The stats are served as JSON on the /stats endpoint of the DAServer.
+type StatsReporter interface {
+	Stats() any
+}
+
+// ServerStats are the request statistics of the DAServer since it was started.
+type ServerStats struct {
+	Gets         uint64 `json:"gets"`
+	GetsNotFound uint64 `json:"getsNotFound"`
+	GetErrors    uint64 `json:"getErrors"`
+	Puts         uint64 `json:"puts"`
+	PutErrors    uint64 `json:"putErrors"`
+	// Store holds the stats of the KVStore, if it is a StatsReporter
+	Store any `json:"store,omitempty"`
+}
+
+type serverCounters struct {
+	gets         atomic.Uint64
+	getsNotFound atomic.Uint64
+	getErrors    atomic.Uint64
+	puts         atomic.Uint64
+	putErrors    atomic.Uint64
+}
+
 type DAServer struct {
 	log            log.Logger
 	endpoint       string
@@ -34,6 +66,7 @@ type DAServer struct {
 	httpServer     *http.Server
 	listener       net.Listener
 	useGenericComm bool
+	counters       serverCounters
 }
 
 func NewDAServer(host string, port int, store KVStore, log log.Logger, useGenericComm bool) *DAServer {
@@ -54,6 +87,8 @@ func (d *DAServer) Start() error {
 
 	mux.HandleFunc("/get/", d.HandleGet)
 	mux.HandleFunc("/put/", d.HandlePut)
+	mux.HandleFunc("/health", d.HandleHealth)
+	mux.HandleFunc("/stats", d.HandleStats)
 
 	d.httpServer.Handler = mux
 
@@ -108,15 +143,18 @@ func (d *DAServer) HandleGet(w http.ResponseWriter, r *http.Request) {
 
 	input, err := d.store.Get(r.Context(), comm)
 	if err != nil && errors.Is(err, ErrNotFound) {
+		d.counters.getsNotFound.Add(1)
 		d.log.Error("Commitment not found", "key", key, "error", err)
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 	if err != nil {
+		d.counters.getErrors.Add(1)
 		d.log.Error("Failed to read commitment", "err", err, "key", key)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	d.counters.gets.Add(1)
 	if _, err := w.Write(input); err != nil {
 		d.log.Error("Failed to write pre-image", "err", err, "key", key)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -158,10 +196,12 @@ func (d *DAServer) HandlePut(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if err = d.store.Put(r.Context(), comm, input); err != nil {
+			d.counters.putErrors.Add(1)
 			d.log.Error("Failed to store commitment to the DA server", "err", err, "comm", comm)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		d.counters.puts.Add(1)
 		d.log.Info("stored commitment", "key", hex.EncodeToString(comm), "input_len", len(input))
 
 		if _, err := w.Write(comm); err != nil {
@@ -179,14 +219,52 @@ func (d *DAServer) HandlePut(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if err := d.store.Put(r.Context(), comm, input); err != nil {
+			d.counters.putErrors.Add(1)
 			d.log.Error("Failed to store commitment to the DA server", "err", err, "key", key)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
+		d.counters.puts.Add(1)
 		w.WriteHeader(http.StatusOK)
 	}
 }
 
+// HandleHealth responds with 200 if the storage backend is available, and 503 otherwise.
+// Stores that do not implement HealthChecker are always considered healthy.
+func (d *DAServer) HandleHealth(w http.ResponseWriter, r *http.Request) {
+	if checker, ok := d.store.(HealthChecker); ok {
+		if err := checker.HealthCheck(r.Context()); err != nil {
+			d.log.Warn("Storage backend is unhealthy", "err", err)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(err.Error()))
+			return
+		}
+	}
+	_, _ = w.Write([]byte("OK"))
+}
+
+// HandleStats responds with the ServerStats as JSON.
+func (d *DAServer) HandleStats(w http.ResponseWriter, r *http.Request) {
+	if err := json.NewEncoder(w).Encode(d.Stats()); err != nil {
+		d.log.Error("Failed to write stats", "err", err)
+	}
+}
+
+// Stats returns the request statistics of the server, and the stats of the store if it is a StatsReporter.
+func (d *DAServer) Stats() ServerStats {
+	stats := ServerStats{
+		Gets:         d.counters.gets.Load(),
+		GetsNotFound: d.counters.getsNotFound.Load(),
+		GetErrors:    d.counters.getErrors.Load(),
+		Puts:         d.counters.puts.Load(),
+		PutErrors:    d.counters.putErrors.Load(),
+	}
+	if reporter, ok := d.store.(StatsReporter); ok {
+		stats.Store = reporter.Stats()
+	}
+	return stats
+}
+
 func (b *DAServer) HttpEndpoint() string {
 	return fmt.Sprintf("http://%s", b.listener.Addr().String())
 }
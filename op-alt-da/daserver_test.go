@@ -0,0 +1,76 @@
+package altda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAServerHealthAndStats(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	ctx := context.Background()
+	store := &checkedMemStore{MemStore: NewMemStore()}
+	server := NewDAServer("127.0.0.1", 0, store, logger, false)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { _ = server.Stop() })
+
+	client := CLIConfig{Enabled: true, DAServerURL: server.HttpEndpoint(), VerifyOnRead: true}.NewDAClient()
+	input := []byte("hello")
+	comm, err := client.SetInput(ctx, input)
+	require.NoError(t, err)
+	_, err = client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	_, err = client.GetInput(ctx, NewKeccak256Commitment([]byte("unknown")))
+	require.ErrorIs(t, err, ErrNotFound)
+
+	resp, err := http.Get(server.HttpEndpoint() + "/stats")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var stats ServerStats
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&stats))
+	require.Equal(t, uint64(1), stats.Puts)
+	require.Equal(t, uint64(1), stats.Gets)
+	require.Equal(t, uint64(1), stats.GetsNotFound)
+	require.Zero(t, stats.GetErrors)
+	require.Zero(t, stats.PutErrors)
+	require.Equal(t, map[string]any{"entries": float64(1)}, stats.Store)
+
+	health := func() (int, string) {
+		resp, err := http.Get(server.HttpEndpoint() + "/health")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+	code, _ := health()
+	require.Equal(t, http.StatusOK, code)
+
+	store.healthErr = errors.New("disk full")
+	code, body := health()
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "disk full", body)
+}
+
+type checkedMemStore struct {
+	*MemStore
+	healthErr error
+}
+
+func (s *checkedMemStore) HealthCheck(ctx context.Context) error {
+	return s.healthErr
+}
+
+func (s *checkedMemStore) Stats() any {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return map[string]any{"entries": len(s.db)}
+}
@@ -0,0 +1,248 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var ErrBundleNotSent = errors.New("bundle was not accepted by any builder")
+
+type BundleMetrics interface {
+	RecordBundleSent()
+	RecordBundleFallback()
+	RecordBundleSlot(landed bool)
+}
+
+type SlotClock interface {
+	GetSecondsPerSlot(ctx context.Context) (uint64, error)
+}
+
+// SendBundleArgs are the arguments of eth_sendBundle.
+// The transactions of a bundle may not revert unless listed in RevertingTxHashes,
+// so a batch that would revert in the target block is dropped by the builder instead of burning gas.
+type SendBundleArgs struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       hexutil.Uint64  `json:"blockNumber"`
+	MinTimestamp      *uint64         `json:"minTimestamp,omitempty"`
+	MaxTimestamp      *uint64         `json:"maxTimestamp,omitempty"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes"`
+}
+
+type SendBundleResult struct {
+	BundleHash common.Hash `json:"bundleHash"`
+}
+
+// BundleBackend is a txmgr.ETHBackend that sends the batch transactions as bundles to block builders,
+// targeting the L1 block of the slot that the batch is submitted for.
+// If no builder accepts the bundle, the transaction is sent to the mempool when the fallback is enabled.
+// Transactions that are not batch submissions are always sent to the mempool.
+type BundleBackend struct {
+	txmgr.ETHBackend
+
+	log      log.Logger
+	metr     BundleMetrics
+	builders []client.RPC
+	slots    SlotClock
+	fallback bool
+
+	mu sync.Mutex
+	// pending are the sent bundles that did not land yet, by the nonce of their transaction.
+	pending map[uint64]*pendingBundle
+}
+
+// pendingBundle tracks the bundles of a batch, fee bumps replace the transaction of the batch.
+type pendingBundle struct {
+	targetBlock uint64
+	txHashes    []common.Hash
+}
+
+func NewBundleBackend(log log.Logger, metr BundleMetrics, backend txmgr.ETHBackend, builders []client.RPC, slots SlotClock, fallback bool) *BundleBackend {
+	return &BundleBackend{
+		ETHBackend: backend,
+		log:        log,
+		metr:       metr,
+		builders:   builders,
+		slots:      slots,
+		fallback:   fallback,
+		pending:    make(map[uint64]*pendingBundle),
+	}
+}
+
+// SendTransaction sends the batch transaction as a bundle for the L1 block of its target timestamp.
+func (b *BundleBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	targetTimestamp, err := derive.SubmitTargetTimestamp(tx.Data())
+	if err != nil {
+		return b.ETHBackend.SendTransaction(ctx, tx)
+	}
+	head, err := b.ETHBackend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	b.checkMissedSlots(ctx, head.Number.Uint64())
+
+	// A batch that missed its target block reverts in any other block, so it is not sent to the mempool either.
+	// The txmgr gives up on the expired tx, which releases its nonce, and the batch is sent again for a later slot.
+	if targetTimestamp <= head.Time {
+		return fmt.Errorf("%w: target timestamp %d of batch tx %s is not after L1 head %d", txmgr.ErrTxExpired, targetTimestamp, tx.Hash(), head.Time)
+	}
+	slotTime, err := b.slots.GetSecondsPerSlot(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 slot time: %w", err)
+	}
+	// Slots are assumed not to be missed up to the target, otherwise the batch lands in a later
+	// block with a different timestamp, which the revert protection of the bundle prevents.
+	targetBlock := head.Number.Uint64() + (targetTimestamp-head.Time+slotTime-1)/slotTime
+
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode batch tx %s: %w", tx.Hash(), err)
+	}
+	args := SendBundleArgs{
+		Txs:               []hexutil.Bytes{rawTx},
+		BlockNumber:       hexutil.Uint64(targetBlock),
+		MinTimestamp:      &targetTimestamp,
+		MaxTimestamp:      &targetTimestamp,
+		RevertingTxHashes: []common.Hash{},
+	}
+	if err := b.sendBundle(ctx, args); err != nil {
+		if !b.fallback {
+			return err
+		}
+		b.log.Warn("Falling back to the mempool for batch tx", "tx", tx.Hash(), "err", err)
+		b.metr.RecordBundleFallback()
+		return b.ETHBackend.SendTransaction(ctx, tx)
+	}
+	b.log.Info("Sent batch tx bundle", "tx", tx.Hash(), "target_block", targetBlock, "target_timestamp", targetTimestamp)
+	b.metr.RecordBundleSent()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	p, ok := b.pending[tx.Nonce()]
+	if !ok || p.targetBlock != targetBlock {
+		p = &pendingBundle{targetBlock: targetBlock}
+		b.pending[tx.Nonce()] = p
+	}
+	p.txHashes = append(p.txHashes, tx.Hash())
+	return nil
+}
+
+// sendBundle sends the bundle to all builders, it succeeds if any builder accepts it.
+func (b *BundleBackend) sendBundle(ctx context.Context, args SendBundleArgs) error {
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+		sent bool
+	)
+	for i, builder := range b.builders {
+		wg.Add(1)
+		go func(i int, builder client.RPC) {
+			defer wg.Done()
+			var result SendBundleResult
+			err := builder.CallContext(ctx, &result, "eth_sendBundle", args)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("builder %d: %w", i, err))
+				return
+			}
+			b.log.Debug("Builder accepted bundle", "builder", i, "bundle", result.BundleHash)
+			sent = true
+		}(i, builder)
+	}
+	wg.Wait()
+	if !sent {
+		return fmt.Errorf("%w: %w", ErrBundleNotSent, errors.Join(errs...))
+	}
+	return nil
+}
+
+// TransactionReceipt records whether the bundle of the transaction landed in its target block.
+func (b *BundleBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	receipt, err := b.ETHBackend.TransactionReceipt(ctx, txHash)
+	if err != nil || receipt == nil {
+		return receipt, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for nonce, p := range b.pending {
+		if slices.Contains(p.txHashes, txHash) {
+			delete(b.pending, nonce)
+			b.recordSlot(p, receipt)
+			break
+		}
+	}
+	return receipt, nil
+}
+
+// checkMissedSlots records the bundles with a target block at or below the L1 head that did not land.
+// The receipts are fetched without holding the lock, so the bundles being sent are not blocked on the RPC calls.
+func (b *BundleBackend) checkMissedSlots(ctx context.Context, headNum uint64) {
+	b.mu.Lock()
+	due := make(map[uint64]*pendingBundle)
+	for nonce, p := range b.pending {
+		if p.targetBlock <= headNum {
+			due[nonce] = &pendingBundle{targetBlock: p.targetBlock, txHashes: slices.Clone(p.txHashes)}
+		}
+	}
+	b.mu.Unlock()
+
+	for nonce, p := range due {
+		landed, err := b.landedReceipt(ctx, p.txHashes)
+		if err != nil {
+			b.log.Warn("Failed to fetch receipt of bundled batch tx", "txs", p.txHashes, "err", err)
+			continue
+		}
+		b.mu.Lock()
+		// The bundle may have been recorded by a receipt lookup in the meantime, or replaced by a new target.
+		if current, ok := b.pending[nonce]; ok && current.targetBlock == p.targetBlock {
+			delete(b.pending, nonce)
+			b.recordSlot(p, landed)
+		}
+		b.mu.Unlock()
+	}
+}
+
+// landedReceipt returns the receipt of the first of the transactions that landed, or nil if none landed.
+func (b *BundleBackend) landedReceipt(ctx context.Context, txHashes []common.Hash) (*types.Receipt, error) {
+	for _, txHash := range txHashes {
+		receipt, err := b.ETHBackend.TransactionReceipt(ctx, txHash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if receipt != nil {
+			return receipt, nil
+		}
+	}
+	return nil, nil
+}
+
+func (b *BundleBackend) recordSlot(p *pendingBundle, receipt *types.Receipt) {
+	landed := receipt != nil && receipt.BlockNumber.Uint64() == p.targetBlock
+	if !landed {
+		b.log.Warn("Batch tx bundle missed its target block", "txs", p.txHashes, "target_block", p.targetBlock)
+	}
+	b.metr.RecordBundleSlot(landed)
+}
+
+func (b *BundleBackend) Close() {
+	for _, builder := range b.builders {
+		builder.Close()
+	}
+	b.ETHBackend.Close()
+}
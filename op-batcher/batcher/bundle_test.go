@@ -0,0 +1,170 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+type fakeBundleL1 struct {
+	txmgr.ETHBackend
+	head     *types.Header
+	mempool  []common.Hash
+	receipts map[common.Hash]*types.Receipt
+}
+
+func (f *fakeBundleL1) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return f.head, nil
+}
+
+func (f *fakeBundleL1) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	f.mempool = append(f.mempool, tx.Hash())
+	return nil
+}
+
+func (f *fakeBundleL1) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	if r, ok := f.receipts[txHash]; ok {
+		return r, nil
+	}
+	return nil, ethereum.NotFound
+}
+
+type fakeBuilder struct {
+	client.RPC
+	err     error
+	bundles []SendBundleArgs
+}
+
+func (f *fakeBuilder) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.bundles = append(f.bundles, args[0].(SendBundleArgs))
+	return nil
+}
+
+type fixedSlotClock uint64
+
+func (c fixedSlotClock) GetSecondsPerSlot(ctx context.Context) (uint64, error) {
+	return uint64(c), nil
+}
+
+type bundleMetrics struct {
+	sent, fallback, landed, missed int
+}
+
+func (m *bundleMetrics) RecordBundleSent()     { m.sent++ }
+func (m *bundleMetrics) RecordBundleFallback() { m.fallback++ }
+func (m *bundleMetrics) RecordBundleSlot(landed bool) {
+	if landed {
+		m.landed++
+	} else {
+		m.missed++
+	}
+}
+
+func TestBundleBackend(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(1))
+	batchTx := func(nonce uint64, targetTimestamp uint64) *types.Transaction {
+		data, err := derive.SubmitCalldataTxData(targetTimestamp, []byte{derive.DerivationVersion0})
+		require.NoError(t, err)
+		return types.MustSignNewTx(key, signer, &types.DynamicFeeTx{Nonce: nonce, Data: data, Gas: 100_000})
+	}
+	setup := func(fallback bool, builders ...*fakeBuilder) (*BundleBackend, *fakeBundleL1, *bundleMetrics) {
+		l1 := &fakeBundleL1{
+			head:     &types.Header{Number: big.NewInt(100), Time: 1200},
+			receipts: make(map[common.Hash]*types.Receipt),
+		}
+		m := new(bundleMetrics)
+		rpcs := make([]client.RPC, len(builders))
+		for i, b := range builders {
+			rpcs[i] = b
+		}
+		return NewBundleBackend(testlog.Logger(t, log.LevelDebug), m, l1, rpcs, fixedSlotClock(12), fallback), l1, m
+	}
+
+	t.Run("landed", func(t *testing.T) {
+		builder := new(fakeBuilder)
+		backend, l1, m := setup(true, builder)
+		tx := batchTx(0, 1224)
+		require.NoError(t, backend.SendTransaction(context.Background(), tx))
+		require.Empty(t, l1.mempool)
+		require.Len(t, builder.bundles, 1)
+		bundle := builder.bundles[0]
+		require.Equal(t, uint64(102), uint64(bundle.BlockNumber))
+		require.Equal(t, uint64(1224), *bundle.MinTimestamp)
+		require.Equal(t, uint64(1224), *bundle.MaxTimestamp)
+		require.Empty(t, bundle.RevertingTxHashes)
+		require.Equal(t, 1, m.sent)
+
+		l1.receipts[tx.Hash()] = &types.Receipt{BlockNumber: big.NewInt(102)}
+		_, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, 1, m.landed)
+		require.Equal(t, 0, m.missed)
+	})
+
+	t.Run("missed", func(t *testing.T) {
+		builder := new(fakeBuilder)
+		backend, l1, m := setup(true, builder)
+		require.NoError(t, backend.SendTransaction(context.Background(), batchTx(0, 1212)))
+		// A fee bump of the batch is sent for the same slot
+		require.NoError(t, backend.SendTransaction(context.Background(), batchTx(0, 1212)))
+		require.Len(t, builder.bundles, 2)
+
+		l1.head = &types.Header{Number: big.NewInt(101), Time: 1212}
+		require.NoError(t, backend.SendTransaction(context.Background(), batchTx(1, 1224)))
+		require.Equal(t, 1, m.missed, "both bundles of the batch missed one slot")
+		require.Equal(t, 0, m.landed)
+	})
+
+	t.Run("fallback", func(t *testing.T) {
+		builder := &fakeBuilder{err: errors.New("boom")}
+		backend, l1, m := setup(true, builder, new(fakeBuilder))
+		require.NoError(t, backend.SendTransaction(context.Background(), batchTx(0, 1212)))
+		require.Empty(t, l1.mempool, "one builder accepting the bundle is enough")
+
+		backend, l1, m = setup(true, builder)
+		tx := batchTx(0, 1212)
+		require.NoError(t, backend.SendTransaction(context.Background(), tx))
+		require.Equal(t, []common.Hash{tx.Hash()}, l1.mempool)
+		require.Equal(t, 1, m.fallback)
+
+		backend, l1, _ = setup(false, builder)
+		require.ErrorIs(t, backend.SendTransaction(context.Background(), tx), ErrBundleNotSent)
+		require.Empty(t, l1.mempool)
+	})
+
+	t.Run("past target", func(t *testing.T) {
+		builder := new(fakeBuilder)
+		backend, l1, _ := setup(true, builder)
+		require.ErrorIs(t, backend.SendTransaction(context.Background(), batchTx(0, 1200)), txmgr.ErrTxExpired)
+		require.Empty(t, builder.bundles)
+		require.Empty(t, l1.mempool, "missed batches are not sent to the mempool")
+	})
+
+	t.Run("not a batch", func(t *testing.T) {
+		builder := new(fakeBuilder)
+		backend, l1, _ := setup(true, builder)
+		tx := types.MustSignNewTx(key, signer, &types.DynamicFeeTx{Gas: 21_000})
+		require.NoError(t, backend.SendTransaction(context.Background(), tx))
+		require.Equal(t, []common.Hash{tx.Hash()}, l1.mempool)
+		require.Empty(t, builder.bundles)
+	})
+}
@@ -220,6 +220,10 @@ func (s *channel) PendingFrames() int {
 	return s.channelBuilder.PendingFrames()
 }
 
+func (s *channel) PendingBytes() int {
+	return s.channelBuilder.PendingBytes()
+}
+
 func (s *channel) OutputFrames() error {
 	return s.channelBuilder.OutputFrames()
 }
@@ -421,6 +421,16 @@ func (c *ChannelBuilder) PendingFrames() int {
 	return len(c.frames)
 }
 
+// PendingBytes returns the amount of output data that is not submitted yet: the
+// data of the pending frames and the bytes ready in the compression pipeline.
+func (c *ChannelBuilder) PendingBytes() int {
+	n := c.ReadyBytes()
+	for _, f := range c.frames {
+		n += len(f.data)
+	}
+	return n
+}
+
 // NextFrame returns the next available frame.
 // HasFrame must be called prior to check if there's a next frame available.
 // Panics if called when there's no next frame.
@@ -195,6 +195,29 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	return s.nextTxData(s.currentChannel)
 }
 
+// PendingData returns an estimate of the compressed bytes that still have to be submitted,
+// and the timestamp of the latest L2 block that is pending submission. The size of the blocks
+// that are not added to a channel yet is estimated with the given compression ratio.
+func (s *channelManager) PendingData(comprRatio float64) (pendingBytes uint64, latestL2Time uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, ch := range s.channelQueue {
+		pendingBytes += uint64(ch.PendingBytes())
+		if blocks := ch.channelBuilder.Blocks(); len(blocks) > 0 {
+			latestL2Time = max(latestL2Time, blocks[len(blocks)-1].Time())
+		}
+	}
+	var inputBytes uint64
+	for _, block := range s.blocks {
+		inputBytes += metrics.EstimateBatchSize(block)
+	}
+	pendingBytes += uint64(float64(inputBytes) * comprRatio)
+	if len(s.blocks) > 0 {
+		latestL2Time = max(latestL2Time, s.blocks[len(s.blocks)-1].Time())
+	}
+	return pendingBytes, latestL2Time
+}
+
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
 // space for more data (i.e. channel.IsFull returns false). If currentChannel is nil
 // or full, a new channel is created.
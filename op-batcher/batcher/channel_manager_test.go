@@ -483,3 +483,29 @@ func TestChannelManager_ChannelCreation(t *testing.T) {
 		})
 	}
 }
+
+func TestChannelManager_PendingData(t *testing.T) {
+	l := testlog.Logger(t, log.LevelCrit)
+	cfg := channelManagerTestConfig(1000, derive.SingularBatchType)
+	m := NewChannelManager(l, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	m.Clear(eth.BlockID{})
+
+	pending, latest := m.PendingData(0.5)
+	require.Zero(t, pending)
+	require.Zero(t, latest)
+
+	rng := rand.New(rand.NewSource(99))
+	a := derivetest.RandomL2BlockWithChainId(rng, 4, defaultTestRollupConfig.L2ChainID)
+	require.NoError(t, m.AddL2Block(a))
+
+	pending, latest = m.PendingData(0.5)
+	require.Equal(t, metrics.EstimateBatchSize(a)/2, pending)
+	require.Equal(t, a.Time(), latest)
+
+	// Once the block is added to a channel, its output data is pending.
+	_, err := m.TxData(eth.BlockID{})
+	require.NoError(t, err)
+	pending, latest = m.PendingData(0.5)
+	require.Equal(t, uint64(m.currentChannel.PendingBytes()), pending)
+	require.Equal(t, a.Time(), latest)
+}
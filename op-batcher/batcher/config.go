@@ -66,6 +66,10 @@ type CLIConfig struct {
 	// per blob tx, if using Blob DA.
 	TargetNumFrames int
 
+	// MaxFramesPerSlot is the maximum number of frames (blobs) to pack into the batch tx of a
+	// single target slot, to catch up on a large backlog of L2 data. TargetNumFrames is used if smaller.
+	MaxFramesPerSlot int
+
 	// ApproxComprRatio to assume (only [compressor.RatioCompressor]).
 	// Should be slightly smaller than average from experiments to avoid the
 	// chances of creating a small additional leftover frame.
@@ -104,6 +108,30 @@ type CLIConfig struct {
 
 	EvenBlocks bool
 
+	// PermissionlessSlots makes the batcher race for the slots that the election left permissionless,
+	// in addition to the slots it won.
+	PermissionlessSlots bool
+
+	// BuilderEndpoints are the RPC endpoints of the block builders to send the batch transactions to as bundles,
+	// targeting the L1 block of the slot of the batch. The mempool is used if empty.
+	BuilderEndpoints []string
+
+	// BuilderMempoolFallback sends the batch transaction to the mempool if no builder accepts its bundle.
+	BuilderMempoolFallback bool
+
+	// SubmitGasMargin is the safety margin in percent added to the simulated gas of the BatchInbox submit call.
+	SubmitGasMargin uint64
+
+	// TicketInventoryTarget is the number of election tickets to keep for the batcher account.
+	// Tickets are bought from the auction on L1 when the inventory is below it, and not bought if 0.
+	TicketInventoryTarget uint64
+
+	// TicketPriceCeilingGwei is the maximum price to pay to the auction for a buy of election tickets.
+	TicketPriceCeilingGwei float64
+
+	// TicketPollInterval is the interval to check the election ticket inventory and the auction price.
+	TicketPollInterval time.Duration
+
 	TxMgrConfig   txmgr.CLIConfig
 	LogConfig     oplog.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
@@ -152,9 +180,20 @@ func (c *CLIConfig) Check() error {
 	if c.DataAvailabilityType == flags.BlobsType && c.TargetNumFrames > 6 {
 		return errors.New("too many frames for blob transactions, max 6")
 	}
+	if c.MaxFramesPerSlot < 0 || c.MaxFramesPerSlot > 6 {
+		return fmt.Errorf("invalid MaxFramesPerSlot %d, must be between 0 and 6", c.MaxFramesPerSlot)
+	}
 	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
 		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
 	}
+	if c.TicketInventoryTarget > 0 {
+		if c.TicketPriceCeilingGwei <= 0 {
+			return errors.New("must set a ticket price ceiling to buy election tickets")
+		}
+		if c.TicketPollInterval == 0 {
+			return errors.New("must set TicketPollInterval")
+		}
+	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
 	}
@@ -187,12 +226,20 @@ func NewConfig(ctx *cli.Context) *CLIConfig {
 		MaxL1TxSize:                  ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
 		MaxBlocksPerSpanBatch:        ctx.Int(flags.MaxBlocksPerSpanBatch.Name),
 		TargetNumFrames:              ctx.Int(flags.TargetNumFramesFlag.Name),
+		MaxFramesPerSlot:             ctx.Int(flags.MaxFramesPerSlotFlag.Name),
 		ApproxComprRatio:             ctx.Float64(flags.ApproxComprRatioFlag.Name),
 		Compressor:                   ctx.String(flags.CompressorFlag.Name),
 		CompressionAlgo:              derive.CompressionAlgo(ctx.String(flags.CompressionAlgoFlag.Name)),
 		Stopped:                      ctx.Bool(flags.StoppedFlag.Name),
 		WaitNodeSync:                 ctx.Bool(flags.WaitNodeSyncFlag.Name),
 		EvenBlocks:                   ctx.Bool(flags.EvenBlocksFlag.Name),
+		PermissionlessSlots:          ctx.Bool(flags.PermissionlessSlotsFlag.Name),
+		BuilderEndpoints:             ctx.StringSlice(flags.BuilderEndpointsFlag.Name),
+		BuilderMempoolFallback:       ctx.Bool(flags.BuilderMempoolFallbackFlag.Name),
+		SubmitGasMargin:              ctx.Uint64(flags.SubmitGasMarginFlag.Name),
+		TicketInventoryTarget:        ctx.Uint64(flags.TicketInventoryTargetFlag.Name),
+		TicketPriceCeilingGwei:       ctx.Float64(flags.TicketPriceCeilingFlag.Name),
+		TicketPollInterval:           ctx.Duration(flags.TicketPollIntervalFlag.Name),
 		CheckRecentTxsDepth:          ctx.Int(flags.CheckRecentTxsDepthFlag.Name),
 		BatchType:                    ctx.Uint(flags.BatchTypeFlag.Name),
 		DataAvailabilityType:         flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
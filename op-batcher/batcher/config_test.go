@@ -106,6 +106,11 @@ func TestBatcherConfig(t *testing.T) {
 			},
 			errString: "too many frames for blob transactions, max 6",
 		},
+		{
+			name:      "larger 6 MaxFramesPerSlot",
+			override:  func(c *batcher.CLIConfig) { c.MaxFramesPerSlot = 7 },
+			errString: "invalid MaxFramesPerSlot 7, must be between 0 and 6",
+		},
 		{
 			name: "invalid compr ratio for ratio compressor",
 			override: func(c *batcher.CLIConfig) {
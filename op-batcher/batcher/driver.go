@@ -8,6 +8,7 @@ import (
 	"math/big"
 	_ "net/http/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
@@ -17,7 +18,6 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -40,6 +40,8 @@ type txRef struct {
 	id       txID
 	isCancel bool
 	isBlob   bool
+	// target is the target timestamp of the slot the tx was submitted for, 0 if it does not target a slot
+	target uint64
 }
 
 func (r txRef) String() string {
@@ -70,6 +72,7 @@ type BeaconClient interface {
 	GetEpochNumber(ctx context.Context, timestamp uint64) (uint64, error)
 	GetSlotNumber(ctx context.Context, timestamp uint64) (uint64, error)
 	GetTimeFromSlot(ctx context.Context, slot uint64) (uint64, error)
+	GetSecondsPerSlot(ctx context.Context) (uint64, error)
 }
 
 type L2Client interface {
@@ -122,15 +125,26 @@ type BatchSubmitter struct {
 
 	nextEpochToCheck uint64
 	targetTimestamps []uint64
+	// publishedTarget is the last target timestamp the batcher published for
+	publishedTarget uint64
+	// highestReceiptTarget is the highest target timestamp that a receipt was handled for.
+	// Receipts are handled concurrently to the main loop, and may arrive out of order.
+	highestReceiptTarget atomic.Uint64
+	// epochDuration is the duration in seconds of the epoch of the target timestamps
+	epochDuration uint64
 
-	state *channelManager
+	// slotConfig sizes the channels to the plan over the target slots
+	slotConfig *SlotChannelConfig
+	state      *channelManager
 }
 
 // NewBatchSubmitter initializes the BatchSubmitter driver from a preconfigured DriverSetup
 func NewBatchSubmitter(setup DriverSetup) *BatchSubmitter {
+	slotConfig := NewSlotChannelConfig(setup.ChannelConfig)
 	return &BatchSubmitter{
 		DriverSetup: setup,
-		state:       NewChannelManager(setup.Log, setup.Metr, setup.ChannelConfig, setup.RollupConfig),
+		slotConfig:  slotConfig,
+		state:       NewChannelManager(setup.Log, setup.Metr, slotConfig, setup.RollupConfig),
 	}
 }
 
@@ -427,6 +441,7 @@ func (l *BatchSubmitter) loop() {
 				l.clearState(l.shutdownCtx)
 				continue
 			}
+			l.planSlots()
 			l.publishStateToL1(queue, receiptsCh, daGroup, targetTimestamp)
 		case <-l.shutdownCtx.Done():
 			if l.Txmgr.IsClosed() {
@@ -600,8 +615,11 @@ func (l *BatchSubmitter) cancelBlockingTx(queue *txmgr.Queue[txRef], receiptsCh
 	var candidate *txmgr.TxCandidate
 	var err error
 	if isBlockedBlob {
-		candidate = l.calldataTxCandidate([]byte{})
-	} else if candidate, err = l.blobTxCandidate(emptyTxData, 0); err != nil {
+		candidate, err = l.calldataTxCandidate([]byte{}, 0)
+	} else {
+		candidate, err = l.blobTxCandidate(emptyTxData, 0)
+	}
+	if err != nil {
 		panic(err) // this error should not happen
 	}
 	l.Log.Warn("sending a cancellation transaction to unblock txpool", "blocked_blob", isBlockedBlob)
@@ -609,7 +627,7 @@ func (l *BatchSubmitter) cancelBlockingTx(queue *txmgr.Queue[txRef], receiptsCh
 }
 
 // publishToAltDAAndL1 posts the txdata to the DA Provider and then sends the commitment to L1.
-func (l *BatchSubmitter) publishToAltDAAndL1(txdata txData, queue *txmgr.Queue[txRef], receiptsCh chan txmgr.TxReceipt[txRef], daGroup *errgroup.Group) {
+func (l *BatchSubmitter) publishToAltDAAndL1(txdata txData, queue *txmgr.Queue[txRef], receiptsCh chan txmgr.TxReceipt[txRef], daGroup *errgroup.Group, targetTimestamp uint64) {
 	// sanity checks
 	if nf := len(txdata.frames); nf != 1 {
 		l.Log.Crit("Unexpected number of frames in calldata tx", "num_frames", nf)
@@ -634,7 +652,12 @@ func (l *BatchSubmitter) publishToAltDAAndL1(txdata txData, queue *txmgr.Queue[t
 			return nil
 		}
 		l.Log.Info("Set altda input", "commitment", comm, "tx", txdata.ID())
-		candidate := l.calldataTxCandidate(comm.TxData())
+		candidate, err := l.calldataTxCandidate(comm.TxData(), targetTimestamp)
+		if err != nil {
+			l.Log.Error("Failed to build Alt DA commitment transaction", "error", err)
+			l.recordFailedDARequest(txdata.ID(), err)
+			return nil
+		}
 		l.sendTx(txdata, false, candidate, queue, receiptsCh)
 		return nil
 	})
@@ -654,7 +677,7 @@ func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txRef
 
 	// if Alt DA is enabled we post the txdata to the DA Provider and replace it with the commitment.
 	if l.Config.UseAltDA {
-		l.publishToAltDAAndL1(txdata, queue, receiptsCh, daGroup)
+		l.publishToAltDAAndL1(txdata, queue, receiptsCh, daGroup, targetTimestamp)
 		// we return nil to allow publishStateToL1 to keep processing the next txdata
 		return nil
 	}
@@ -673,7 +696,9 @@ func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txRef
 		if nf := len(txdata.frames); nf != 1 {
 			l.Log.Crit("Unexpected number of frames in calldata tx", "num_frames", nf)
 		}
-		candidate = l.calldataTxCandidate(txdata.CallData())
+		if candidate, err = l.calldataTxCandidate(txdata.CallData(), targetTimestamp); err != nil {
+			return fmt.Errorf("could not create calldata tx candidate: %w", err)
+		}
 	}
 
 	// send tx using txmgr's queue
@@ -685,28 +710,19 @@ func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txRef
 // sendTx uses the txmgr queue to send the given transaction candidate.
 // It will block if the txmgr queue has reached its MaxPendingTransactions limit.
 func (l *BatchSubmitter) sendTx(txdata txData, isCancel bool, candidate *txmgr.TxCandidate, queue *txmgr.Queue[txRef], receiptsCh chan txmgr.TxReceipt[txRef]) {
-	queue.Send(txRef{id: txdata.ID(), isCancel: isCancel, isBlob: txdata.asBlob}, *candidate, receiptsCh)
+	ref := txRef{id: txdata.ID(), isCancel: isCancel, isBlob: txdata.asBlob}
+	if !isCancel {
+		// Cancel txs do not call the batch inbox, and do not target a slot
+		if target, err := derive.SubmitTargetTimestamp(candidate.TxData); err == nil {
+			ref.target = target
+		}
+	}
+	queue.Send(ref, *candidate, receiptsCh)
 }
 
+// encodeSubmitTx encodes the submitBlob call of a blob transaction for the slot of the target timestamp.
 func (l *BatchSubmitter) encodeSubmitTx(targetTimestamp uint64) ([]byte, error) {
-	batchInboxAbi := snapshots.LoadBatchInboxABI()
-	submitBlobMethod, ok := batchInboxAbi.Methods["submitBlob"]
-	if !ok {
-		return nil, fmt.Errorf("submit method not found in BatchInbox contract ABI")
-	}
-
-	// The submit method is expecting a uint256, so use big int
-	blockTimestampBigInt := new(big.Int).SetUint64(targetTimestamp)
-
-	// encode the target L1 block and attempt to submit the batch
-	txData, err := submitBlobMethod.Inputs.Pack(blockTimestampBigInt)
-
-	if err != nil {
-		return nil, fmt.Errorf("packing submit method inputs: %w", err)
-	}
-
-	submitSel := submitBlobMethod.ID
-	return append(submitSel[:], txData...), nil
+	return derive.SubmitBlobTxData(targetTimestamp)
 }
 
 func (l *BatchSubmitter) blobTxCandidate(data txData, targetTimestamp uint64) (*txmgr.TxCandidate, error) {
@@ -729,31 +745,64 @@ func (l *BatchSubmitter) blobTxCandidate(data txData, targetTimestamp uint64) (*
 		TxData: fullTxData,
 		To:     &l.RollupConfig.BatchInboxContractAddress,
 		Blobs:  blobs,
-		// TODO(spire): fix this
-		// POC ONLY: we cannot use eth_estimateGas or intrinsic gas calculation
-		// but hardcoding the gasLimit works fine on devnet. This should be updated
-		// once we finalise the BatchInbox contract.
-		GasLimit: 30000,
 	}, nil
 }
 
-func (l *BatchSubmitter) calldataTxCandidate(data []byte) *txmgr.TxCandidate {
-	l.Log.Info("Building Calldata transaction candidate", "size", len(data))
+// calldataTxCandidate wraps the data in a submitCalldata call for the slot of the target timestamp,
+// the BatchInbox contract only emits the BatchSubmitted event for calls of its submit methods.
+func (l *BatchSubmitter) calldataTxCandidate(data []byte, targetTimestamp uint64) (*txmgr.TxCandidate, error) {
+	l.Log.Info("Building Calldata transaction candidate", "size", len(data), "target", targetTimestamp)
+	txData, err := derive.SubmitCalldataTxData(targetTimestamp, data)
+	if err != nil {
+		return nil, fmt.Errorf("encoding submit transaction: %w", err)
+	}
 	return &txmgr.TxCandidate{
 		To:     &l.RollupConfig.BatchInboxContractAddress,
-		TxData: data,
-	}
+		TxData: txData,
+	}, nil
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txRef]) {
 	// Record TX Status
-	if r.Err != nil {
+	if errors.Is(r.Err, txmgr.ErrGasEstimation) {
+		l.Log.Error("Batch transaction simulation failed, it would not be accepted by the BatchInbox", logFields(r.ID.id, r.Err)...)
+		l.recordFailedTx(r.ID.id, r.Err)
+	} else if r.Err != nil {
 		l.recordFailedTx(r.ID.id, r.Err)
 	} else if r.Receipt.Status == 0 {
 		l.recordFailedTx(r.ID.id, errors.New("Transaction execution failed!"))
 	} else {
 		l.recordConfirmedTx(r.ID.id, r.Receipt)
 	}
+	l.recordTargetSlot(r)
+}
+
+// recordTargetSlot records whether the tx of the receipt landed in the slot it targeted.
+// The batch inbox reverts submissions outside of their target slot, so only included txs that succeeded hit their slot.
+// Every receipt is recorded, including receipts that arrive after receipts of later target slots.
+func (l *BatchSubmitter) recordTargetSlot(r txmgr.TxReceipt[txRef]) {
+	target := r.ID.target
+	if target == 0 {
+		return
+	}
+	hit := r.Err == nil && r.Receipt != nil && r.Receipt.Status == types.ReceiptStatusSuccessful
+	if !hit {
+		l.Log.Warn("Batch tx did not land in its target slot", "target", target, "tx", r.ID)
+	}
+	l.Metr.RecordTargetSlot(hit)
+
+	for {
+		highest := l.highestReceiptTarget.Load()
+		if target <= highest {
+			if target < highest {
+				l.Log.Debug("Receipt arrived after receipts of later target slots", "target", target, "highest", highest, "tx", r.ID)
+			}
+			return
+		}
+		if l.highestReceiptTarget.CompareAndSwap(highest, target) {
+			return
+		}
+	}
 }
 
 func (l *BatchSubmitter) recordL1Tip(l1tip eth.L1BlockRef) {
@@ -822,30 +871,55 @@ func (l *BatchSubmitter) updateL1Tip() error {
 	return nil
 }
 
-func (l *BatchSubmitter) generateTargetTimesamps(epoch uint64) ([]uint64, error) {
+// generateTargetTimesamps returns the timestamps of the slots of the epoch that the batcher may publish in,
+// and the total number of slots of the epoch.
+func (l *BatchSubmitter) generateTargetTimesamps(epoch uint64) ([]uint64, int, error) {
 	out := []uint64{}
 	ctx := l.shutdownCtx
 	rollupClient, err := l.EndpointProvider.RollupClient(ctx)
 	if err != nil {
 		l.Log.Error("Error getting rollup client", "error", err)
-		return out, err
+		return out, 0, err
 	}
 
 	electionWinners, err := rollupClient.GetElectionWinners(ctx, epoch)
 	if err != nil {
 		l.Log.Error("Error getting election winners", "error", err, "epoch", epoch, "last tip", l.lastL1Tip.Number)
-		return out, err
+		return out, 0, err
 	}
 
 	l.Log.Info("Election winners from rollup client", "electionWinners", electionWinners)
 
 	for _, electionWinner := range electionWinners {
-		if electionWinner.Address == l.Txmgr.From() {
+		if electionWinner.Address == l.Txmgr.From() || (l.Config.PermissionlessSlots && electionWinner.Permissionless) {
 			out = append(out, electionWinner.Time)
 		}
 	}
 
-	return out, nil
+	return out, len(electionWinners), nil
+}
+
+// planSlots plans the submission of the pending L2 data over the remaining target slots of the epoch,
+// and sizes the channels that are created next to the number of frames per slot of the plan.
+func (l *BatchSubmitter) planSlots() {
+	cfg := l.slotConfig.Base()
+	comprRatio := cfg.CompressorConfig.ApproxComprRatio
+	if comprRatio == 0 {
+		// assume no compression if the compressor doesn't provide a ratio
+		comprRatio = 1
+	}
+	pendingBytes, latestL2Time := l.state.PendingData(comprRatio)
+	plan := PlanSlots(cfg, l.Config.MaxFramesPerSlot, l.targetTimestamps, l.epochDuration, pendingBytes, latestL2Time)
+	l.slotConfig.SetFramesPerSlot(plan.FramesPerSlot)
+	l.Metr.RecordSlotPlan(plan.FramesPerSlot, plan.SlotsNeeded, plan.ProjectedSafeLag)
+
+	lgr := l.Log.New("slots", len(plan.Slots), "pending_bytes", plan.PendingBytes, "frames_per_slot", plan.FramesPerSlot,
+		"slots_needed", plan.SlotsNeeded, "projected_safe_lag", plan.ProjectedSafeLag)
+	if plan.SlotsNeeded > len(plan.Slots) {
+		lgr.Warn("Pending L2 data exceeds the remaining target slots of the epoch")
+	} else {
+		lgr.Info("Planned target slots")
+	}
 }
 
 func (l *BatchSubmitter) shouldPublish() (bool, uint64) {
@@ -879,8 +953,12 @@ func (l *BatchSubmitter) shouldPublish() (bool, uint64) {
 		return false, 0
 	}
 
-	// TODO(Spire): use config here
-	nextSlotTime := syncStatus.HeadL1.Time + 12
+	slotTime, err := l.BeaconClient.GetSecondsPerSlot(ctx)
+	if err != nil {
+		l.Log.Warn("Error fetching L1 slot time", "error", err)
+		return false, 0
+	}
+	nextSlotTime := syncStatus.HeadL1.Time + slotTime
 
 	epoch, err := l.BeaconClient.GetEpochNumber(ctx, nextSlotTime)
 	if err != nil {
@@ -889,19 +967,24 @@ func (l *BatchSubmitter) shouldPublish() (bool, uint64) {
 	}
 
 	if epoch >= l.nextEpochToCheck {
-		targetTimestamps, err := l.generateTargetTimesamps(epoch)
+		targetTimestamps, epochSlots, err := l.generateTargetTimesamps(epoch)
 		if err != nil {
 			l.Log.Warn("Error while generating target timestamps", "error", err)
 			return false, 0
 		}
 
 		l.targetTimestamps = targetTimestamps
+		l.epochDuration = uint64(epochSlots) * slotTime
 		l.nextEpochToCheck = epoch + 1
 		l.Log.Info("Picked new target timestamps", "target", l.targetTimestamps, "nextL1SlotTimestamp", nextSlotTime)
 	}
 
 	// Pop all missed slots
 	for len(l.targetTimestamps) > 0 && nextSlotTime > l.targetTimestamps[0] {
+		if l.targetTimestamps[0] != l.publishedTarget {
+			l.Log.Warn("Missed target slot", "target", l.targetTimestamps[0], "nextL1SlotTimestamp", nextSlotTime)
+			l.Metr.RecordTargetSlot(false)
+		}
 		l.targetTimestamps = l.targetTimestamps[1:]
 	}
 
@@ -912,6 +995,8 @@ func (l *BatchSubmitter) shouldPublish() (bool, uint64) {
 
 	if nextSlotTime == l.targetTimestamps[0] {
 		l.Log.Info("Should publish in the next slot", "nextSlotTime", nextSlotTime, "nextBlockNumber", l.lastL1Tip.Number+1)
+		// Whether the slot is hit is recorded from the receipts of the submitted txs
+		l.publishedTarget = nextSlotTime
 		return true, nextSlotTime
 	}
 
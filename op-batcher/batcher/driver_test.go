@@ -6,10 +6,13 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -131,3 +134,55 @@ func TestBatchSubmitter_EncodeSubmitTx(t *testing.T) {
 		require.Greater(t, len(txData), 0, "Encoded tx data should not be empty")
 	})
 }
+
+func TestBatchSubmitter_CalldataTxCandidate(t *testing.T) {
+	bs, _ := setup(t)
+
+	data := []byte{derive.DerivationVersion0, 0x01, 0x02, 0x03}
+	candidate, err := bs.calldataTxCandidate(data, 123456)
+	require.NoError(t, err)
+	require.Equal(t, &bs.RollupConfig.BatchInboxContractAddress, candidate.To)
+
+	payload, err := derive.UnpackSubmitCalldata(candidate.TxData)
+	require.NoError(t, err)
+	require.Equal(t, data, payload)
+}
+
+type targetSlotMetrics struct {
+	metrics.Metricer
+	hits   int
+	misses int
+}
+
+func (m *targetSlotMetrics) RecordTargetSlot(hit bool) {
+	if hit {
+		m.hits++
+	} else {
+		m.misses++
+	}
+}
+
+func TestBatchSubmitter_RecordTargetSlot(t *testing.T) {
+	bs, _ := setup(t)
+	m := &targetSlotMetrics{Metricer: metrics.NoopMetrics}
+	bs.Metr = m
+
+	receipt := func(target uint64, status uint64) txmgr.TxReceipt[txRef] {
+		return txmgr.TxReceipt[txRef]{ID: txRef{target: target}, Receipt: &types.Receipt{Status: status}}
+	}
+	bs.recordTargetSlot(receipt(0, types.ReceiptStatusSuccessful))
+	require.Zero(t, m.hits, "txs without a target slot are not recorded")
+
+	bs.recordTargetSlot(receipt(1200, types.ReceiptStatusSuccessful))
+	bs.recordTargetSlot(receipt(1200, types.ReceiptStatusSuccessful))
+	require.Equal(t, 2, m.hits, "every tx of a slot is recorded")
+
+	bs.recordTargetSlot(receipt(1212, types.ReceiptStatusFailed))
+	require.Equal(t, 1, m.misses)
+	bs.recordTargetSlot(txmgr.TxReceipt[txRef]{ID: txRef{target: 1224}, Err: errors.New("tx not included")})
+	require.Equal(t, 2, m.misses)
+
+	bs.recordTargetSlot(receipt(1212, types.ReceiptStatusSuccessful))
+	require.Equal(t, 3, m.hits, "receipts that arrive out of order are recorded")
+	require.Equal(t, uint64(1224), bs.highestReceiptTarget.Load())
+}
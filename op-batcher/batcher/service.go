@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 
@@ -47,7 +48,9 @@ type BatcherConfig struct {
 	WaitNodeSync        bool
 	CheckRecentTxsDepth int
 
-	EvenBlocks bool
+	EvenBlocks          bool
+	PermissionlessSlots bool
+	MaxFramesPerSlot    int
 }
 
 // BatcherService represents a full batch-submitter instance and its resources,
@@ -60,6 +63,7 @@ type BatcherService struct {
 	EndpointProvider dial.L2EndpointProvider
 	TxManager        *txmgr.SimpleTxManager
 	AltDA            *altda.DAClient
+	TicketBuyer      *TicketBuyer
 
 	BatcherConfig
 
@@ -105,15 +109,20 @@ func (bs *BatcherService) initFromCLIConfig(ctx context.Context, version string,
 	bs.CheckRecentTxsDepth = cfg.CheckRecentTxsDepth
 	bs.WaitNodeSync = cfg.WaitNodeSync
 	bs.EvenBlocks = cfg.EvenBlocks
+	bs.PermissionlessSlots = cfg.PermissionlessSlots
+	bs.MaxFramesPerSlot = cfg.MaxFramesPerSlot
 	if err := bs.initRPCClients(ctx, cfg); err != nil {
 		return err
 	}
 	if err := bs.initRollupConfig(ctx); err != nil {
 		return fmt.Errorf("failed to load rollup config: %w", err)
 	}
-	if err := bs.initTxManager(cfg); err != nil {
+	if err := bs.initTxManager(ctx, cfg); err != nil {
 		return fmt.Errorf("failed to init Tx manager: %w", err)
 	}
+	if err := bs.initTicketBuyer(cfg); err != nil {
+		return fmt.Errorf("failed to init ticket buyer: %w", err)
+	}
 	// must be init before driver and channel config
 	if err := bs.initAltDA(cfg); err != nil {
 		return fmt.Errorf("failed to init AltDA: %w", err)
@@ -280,8 +289,26 @@ func (bs *BatcherService) initChannelConfig(cfg *CLIConfig) error {
 	return nil
 }
 
-func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
-	txManager, err := txmgr.NewSimpleTxManager("batcher", bs.Log, bs.Metrics, cfg.TxMgrConfig)
+func (bs *BatcherService) initTxManager(ctx context.Context, cfg *CLIConfig) error {
+	txmgrConfig, err := txmgr.NewConfig(cfg.TxMgrConfig, bs.Log)
+	if err != nil {
+		return err
+	}
+	estimator := NewSubmitGasEstimator(bs.Log, client.NewBaseRPCClient(bs.L1Client.Client()), bs.RollupConfig.BatchInboxContractAddress, cfg.SubmitGasMargin)
+	txmgrConfig.Backend = NewSubmitGasBackend(txmgrConfig.Backend, bs.RollupConfig.BatchInboxContractAddress, estimator)
+	if len(cfg.BuilderEndpoints) > 0 {
+		builders := make([]client.RPC, 0, len(cfg.BuilderEndpoints))
+		for _, endpoint := range cfg.BuilderEndpoints {
+			builder, err := client.NewRPC(ctx, bs.Log, endpoint)
+			if err != nil {
+				return fmt.Errorf("failed to dial builder %s: %w", endpoint, err)
+			}
+			builders = append(builders, builder)
+		}
+		bs.Log.Info("Sending batch transactions as bundles", "builders", len(builders), "mempool_fallback", cfg.BuilderMempoolFallback)
+		txmgrConfig.Backend = NewBundleBackend(bs.Log, bs.Metrics, txmgrConfig.Backend, builders, bs.BeaconClient, cfg.BuilderMempoolFallback)
+	}
+	txManager, err := txmgr.NewSimpleTxManagerFromConfig("batcher", bs.Log, bs.Metrics, txmgrConfig)
 	if err != nil {
 		return err
 	}
@@ -289,6 +316,25 @@ func (bs *BatcherService) initTxManager(cfg *CLIConfig) error {
 	return nil
 }
 
+func (bs *BatcherService) initTicketBuyer(cfg *CLIConfig) error {
+	if cfg.TicketInventoryTarget == 0 {
+		return nil
+	}
+	if bs.RollupConfig.AuctionContractAddress == (common.Address{}) {
+		return errors.New("rollup config has no auction contract to buy election tickets from")
+	}
+	priceCeiling, err := eth.GweiToWei(cfg.TicketPriceCeilingGwei)
+	if err != nil {
+		return fmt.Errorf("invalid ticket price ceiling: %w", err)
+	}
+	bs.TicketBuyer = NewTicketBuyer(bs.Log, bs.Metrics, TicketBuyerConfig{
+		InventoryTarget: cfg.TicketInventoryTarget,
+		PriceCeiling:    priceCeiling,
+		PollInterval:    cfg.TicketPollInterval,
+	}, bs.RollupConfig, bs.L1Client, bs.EndpointProvider, bs.TxManager)
+	return nil
+}
+
 func (bs *BatcherService) initPProf(cfg *CLIConfig) error {
 	bs.pprofService = oppprof.New(
 		cfg.PprofConfig.ListenEnabled,
@@ -376,6 +422,12 @@ func (bs *BatcherService) initAltDA(cfg *CLIConfig) error {
 func (bs *BatcherService) Start(_ context.Context) error {
 	bs.driver.Log.Info("Starting batcher", "notSubmittingOnStart", bs.NotSubmittingOnStart)
 
+	if bs.TicketBuyer != nil {
+		if err := bs.TicketBuyer.Start(); err != nil {
+			return err
+		}
+	}
+
 	if !bs.NotSubmittingOnStart {
 		return bs.driver.StartBatchSubmitting()
 	}
@@ -403,7 +455,11 @@ func (bs *BatcherService) Stop(ctx context.Context) error {
 	}
 	bs.Log.Info("Stopping batcher")
 
-	// close the TxManager first, so that new work is denied, in-flight work is cancelled as early as possible
+	// stop the TicketBuyer first, it waits for an in-flight purchase, so no purchase hits a closed TxManager
+	if bs.TicketBuyer != nil {
+		bs.TicketBuyer.Stop()
+	}
+	// then close the TxManager, so that new work is denied, in-flight work is cancelled as early as possible
 	// (transactions which are expected to be confirmed are still waited for)
 	if bs.TxManager != nil {
 		bs.TxManager.Close()
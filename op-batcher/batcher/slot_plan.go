@@ -0,0 +1,97 @@
+package batcher
+
+import (
+	"sync"
+)
+
+// SlotPlan is the plan to submit the pending L2 data over the target slots
+// that the batcher was assigned in the current epoch.
+type SlotPlan struct {
+	// Slots are the timestamps of the remaining target slots of the epoch.
+	Slots []uint64
+	// PendingBytes is the estimated amount of compressed data that still has to be submitted.
+	PendingBytes uint64
+	// FramesPerSlot is the number of frames to pack into the batch tx of each slot.
+	// It is 0 if there are no slots to plan over.
+	FramesPerSlot int
+	// SlotsNeeded is the number of slots it takes to submit the pending data.
+	// It is larger than len(Slots) if the data doesn't fit into the slots of the epoch.
+	SlotsNeeded int
+	// ProjectedSafeLag is the projected time in seconds between the latest pending L2 block
+	// and the slot in which the last of the pending data is submitted.
+	ProjectedSafeLag uint64
+}
+
+// PlanSlots plans the submission of pendingBytes of compressed data over the given target slots,
+// spreading the data evenly over the slots. The number of frames per slot is at least 1 and
+// at most maxFramesPerSlot, or cfg.TargetNumFrames if maxFramesPerSlot is smaller.
+// Calldata txs always carry a single frame.
+//
+// If the data doesn't fit into the slots, the plan assumes that the batcher gets assigned the same
+// slots in the following epochs, each epochDuration seconds apart, to project the safe head lag.
+func PlanSlots(cfg ChannelConfig, maxFramesPerSlot int, slots []uint64, epochDuration uint64, pendingBytes uint64, latestL2Time uint64) SlotPlan {
+	plan := SlotPlan{Slots: slots, PendingBytes: pendingBytes}
+	if len(slots) == 0 {
+		return plan
+	}
+
+	maxFrames := 1
+	if cfg.UseBlobs {
+		maxFrames = max(maxFramesPerSlot, cfg.TargetNumFrames)
+	}
+	frameSize := MaxDataSize(1, cfg.MaxFrameSize)
+	pendingFrames := int((pendingBytes + frameSize - 1) / frameSize)
+
+	plan.FramesPerSlot = min(max((pendingFrames+len(slots)-1)/len(slots), 1), maxFrames)
+	plan.SlotsNeeded = (pendingFrames + plan.FramesPerSlot - 1) / plan.FramesPerSlot
+	if plan.SlotsNeeded == 0 {
+		return plan
+	}
+
+	last := plan.SlotsNeeded - 1
+	lastSlot := slots[last%len(slots)] + uint64(last/len(slots))*epochDuration
+	if lastSlot > latestL2Time {
+		plan.ProjectedSafeLag = lastSlot - latestL2Time
+	}
+	return plan
+}
+
+// SlotChannelConfig is a [ChannelConfigProvider] that sizes the blob channels of the wrapped
+// provider to the number of frames per slot of the current [SlotPlan], so that a
+// channel is submitted in the batch tx of a single slot.
+type SlotChannelConfig struct {
+	mu            sync.Mutex
+	base          ChannelConfigProvider
+	framesPerSlot int
+}
+
+func NewSlotChannelConfig(base ChannelConfigProvider) *SlotChannelConfig {
+	return &SlotChannelConfig{base: base}
+}
+
+// SetFramesPerSlot sets the number of frames of the channels created next.
+// The configuration of the wrapped provider is used unchanged if 0.
+func (s *SlotChannelConfig) SetFramesPerSlot(frames int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.framesPerSlot = frames
+}
+
+// Base returns the configuration of the wrapped provider.
+func (s *SlotChannelConfig) Base() ChannelConfig {
+	return s.base.ChannelConfig()
+}
+
+func (s *SlotChannelConfig) ChannelConfig() ChannelConfig {
+	s.mu.Lock()
+	frames := s.framesPerSlot
+	s.mu.Unlock()
+
+	cfg := s.base.ChannelConfig()
+	if !cfg.UseBlobs || frames == 0 || frames == cfg.TargetNumFrames {
+		return cfg
+	}
+	cfg.TargetNumFrames = frames
+	cfg.ReinitCompressorConfig()
+	return cfg
+}
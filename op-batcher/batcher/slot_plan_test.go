@@ -0,0 +1,138 @@
+package batcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestPlanSlots(t *testing.T) {
+	blobCfg := ChannelConfig{
+		MaxFrameSize:    eth.MaxBlobDataSize - 1,
+		TargetNumFrames: 2,
+		UseBlobs:        true,
+	}
+	calldataCfg := ChannelConfig{
+		MaxFrameSize:    120_000 - 1,
+		TargetNumFrames: 1,
+	}
+	frame := MaxDataSize(1, blobCfg.MaxFrameSize)
+	slots := []uint64{1012, 1060, 1120}
+
+	tests := []struct {
+		name             string
+		cfg              ChannelConfig
+		maxFramesPerSlot int
+		slots            []uint64
+		pendingBytes     uint64
+		latestL2Time     uint64
+		frames           int
+		slotsNeeded      int
+		lag              uint64
+	}{
+		{
+			name:   "no-slots",
+			cfg:    blobCfg,
+			slots:  nil,
+			frames: 0,
+		},
+		{
+			name:         "no-data",
+			cfg:          blobCfg,
+			slots:        slots,
+			latestL2Time: 1000,
+			frames:       1,
+		},
+		{
+			name:         "spread-over-slots",
+			cfg:          blobCfg,
+			slots:        slots,
+			pendingBytes: 3 * frame,
+			latestL2Time: 1000,
+			frames:       1,
+			slotsNeeded:  3,
+			lag:          120,
+		},
+		{
+			name:         "fits-in-first-slot",
+			cfg:          blobCfg,
+			slots:        slots,
+			pendingBytes: frame / 2,
+			latestL2Time: 1000,
+			frames:       1,
+			slotsNeeded:  1,
+			lag:          12,
+		},
+		{
+			name:             "pack-blobs-for-backlog",
+			cfg:              blobCfg,
+			maxFramesPerSlot: 6,
+			slots:            slots,
+			pendingBytes:     13 * frame,
+			latestL2Time:     1000,
+			frames:           5,
+			slotsNeeded:      3,
+			lag:              120,
+		},
+		{
+			name:         "capped-at-target-frames",
+			cfg:          blobCfg,
+			slots:        slots,
+			pendingBytes: 8 * frame,
+			latestL2Time: 1000,
+			frames:       2,
+			slotsNeeded:  4,
+			// the fourth slot is the first slot of the next epoch
+			lag: 12 + 192,
+		},
+		{
+			name:             "calldata-single-frame",
+			cfg:              calldataCfg,
+			maxFramesPerSlot: 6,
+			slots:            slots,
+			pendingBytes:     2 * MaxDataSize(1, calldataCfg.MaxFrameSize),
+			latestL2Time:     1000,
+			frames:           1,
+			slotsNeeded:      2,
+			lag:              60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := PlanSlots(tt.cfg, tt.maxFramesPerSlot, tt.slots, 192, tt.pendingBytes, tt.latestL2Time)
+			require.Equal(t, tt.frames, plan.FramesPerSlot, "frames per slot")
+			require.Equal(t, tt.slotsNeeded, plan.SlotsNeeded, "slots needed")
+			require.Equal(t, tt.lag, plan.ProjectedSafeLag, "projected safe lag")
+		})
+	}
+}
+
+func TestSlotChannelConfig(t *testing.T) {
+	blobCfg := ChannelConfig{
+		MaxFrameSize:    eth.MaxBlobDataSize - 1,
+		TargetNumFrames: 2,
+		UseBlobs:        true,
+	}
+	blobCfg.InitShadowCompressor(derive.Zlib)
+	calldataCfg := ChannelConfig{
+		MaxFrameSize:    120_000 - 1,
+		TargetNumFrames: 1,
+	}
+	calldataCfg.InitShadowCompressor(derive.Zlib)
+
+	scc := NewSlotChannelConfig(blobCfg)
+	require.Equal(t, blobCfg, scc.ChannelConfig(), "base config without plan")
+
+	scc.SetFramesPerSlot(5)
+	cfg := scc.ChannelConfig()
+	require.Equal(t, 5, cfg.TargetNumFrames)
+	require.Equal(t, MaxDataSize(5, blobCfg.MaxFrameSize), cfg.CompressorConfig.TargetOutputSize)
+	require.Equal(t, blobCfg, scc.Base())
+
+	scc = NewSlotChannelConfig(calldataCfg)
+	scc.SetFramesPerSlot(5)
+	require.Equal(t, calldataCfg, scc.ChannelConfig(), "calldata channels are not resized")
+}
@@ -0,0 +1,182 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var ErrSubmitReverted = errors.New("BatchInbox submit call reverted")
+
+// submitMeterCode returns the code of a contract that calls the BatchInbox with its own calldata,
+// and returns the gas used by the call and whether it succeeded, as two words.
+// It is placed at the account of the batcher with a state override, so that the BatchInbox
+// sees the batcher as the sender of the submission.
+func submitMeterCode(inbox common.Address) []byte {
+	code := []byte{
+		0x36,       // CALLDATASIZE
+		0x60, 0x00, // PUSH1 0
+		0x60, 0x00, // PUSH1 0
+		0x37,       // CALLDATACOPY
+		0x5a,       // GAS
+		0x60, 0x00, // PUSH1 0 (retSize)
+		0x60, 0x00, // PUSH1 0 (retOffset)
+		0x36,       // CALLDATASIZE (argsSize)
+		0x60, 0x00, // PUSH1 0 (argsOffset)
+		0x60, 0x00, // PUSH1 0 (value)
+		0x73, // PUSH20 inbox
+	}
+	code = append(code, inbox.Bytes()...)
+	return append(code,
+		0x5a,       // GAS
+		0xf1,       // CALL
+		0x5a,       // GAS
+		0x90,       // SWAP1
+		0x60, 0x20, // PUSH1 32
+		0x52,       // MSTORE success
+		0x90,       // SWAP1
+		0x03,       // SUB
+		0x60, 0x00, // PUSH1 0
+		0x52,       // MSTORE used gas
+		0x60, 0x40, // PUSH1 64
+		0x60, 0x00, // PUSH1 0
+		0xf3, // RETURN
+	)
+}
+
+type overrideAccount struct {
+	Code hexutil.Bytes `json:"code"`
+}
+
+type blockOverrides struct {
+	Time hexutil.Uint64 `json:"time"`
+}
+
+type submitGasKey struct {
+	codeHash common.Hash
+	selector [4]byte
+}
+
+// SubmitGasEstimator estimates the gas of the BatchInbox submit calls. The submit methods only succeed
+// in the block of their target timestamp, so eth_estimateGas cannot be used. Instead, the call is
+// simulated with the timestamp of the target block, by a metering contract at the batcher account.
+// The gas used by the BatchInbox does not depend on the submitted data,
+// so it is cached per code hash of the BatchInbox and submit method.
+type SubmitGasEstimator struct {
+	log   log.Logger
+	l1    client.RPC
+	inbox common.Address
+	// margin is the safety margin in percent added to the simulated gas of the BatchInbox
+	margin uint64
+
+	mu    sync.Mutex
+	cache map[submitGasKey]uint64
+}
+
+func NewSubmitGasEstimator(log log.Logger, l1 client.RPC, inbox common.Address, margin uint64) *SubmitGasEstimator {
+	return &SubmitGasEstimator{
+		log:    log,
+		l1:     l1,
+		inbox:  inbox,
+		margin: margin,
+		cache:  make(map[submitGasKey]uint64),
+	}
+}
+
+// EstimateSubmitGas returns the gas limit of a submit transaction of the batcher with the given calldata,
+// for the block of the target timestamp.
+func (e *SubmitGasEstimator) EstimateSubmitGas(ctx context.Context, from common.Address, data []byte, targetTimestamp uint64) (uint64, error) {
+	var code hexutil.Bytes
+	if err := e.l1.CallContext(ctx, &code, "eth_getCode", e.inbox, "latest"); err != nil {
+		return 0, fmt.Errorf("failed to fetch BatchInbox code: %w", err)
+	}
+	if len(code) == 0 {
+		return 0, fmt.Errorf("no BatchInbox code at %s", e.inbox)
+	}
+	key := submitGasKey{codeHash: crypto.Keccak256Hash(code)}
+	copy(key.selector[:], data)
+
+	e.mu.Lock()
+	used, ok := e.cache[key]
+	e.mu.Unlock()
+	if !ok {
+		var err error
+		if used, err = e.simulate(ctx, from, data, targetTimestamp); err != nil {
+			return 0, err
+		}
+		e.log.Info("Simulated BatchInbox submit call", "code_hash", key.codeHash, "selector", hexutil.Bytes(key.selector[:]), "gas_used", used)
+		e.mu.Lock()
+		e.cache[key] = used
+		e.mu.Unlock()
+	}
+
+	intrinsic, err := core.IntrinsicGas(data, nil, false, true, true, true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute intrinsic gas: %w", err)
+	}
+	return intrinsic + used*(100+e.margin)/100, nil
+}
+
+func (e *SubmitGasEstimator) simulate(ctx context.Context, from common.Address, data []byte, targetTimestamp uint64) (uint64, error) {
+	args := map[string]any{
+		"from":  from,
+		"to":    from,
+		"input": hexutil.Bytes(data),
+	}
+	overrides := map[common.Address]overrideAccount{
+		from: {Code: submitMeterCode(e.inbox)},
+	}
+	var result hexutil.Bytes
+	err := e.l1.CallContext(ctx, &result, "eth_call", args, "latest", overrides, blockOverrides{Time: hexutil.Uint64(targetTimestamp)})
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate BatchInbox submit call: %w", err)
+	}
+	if len(result) != 64 {
+		return 0, fmt.Errorf("unexpected submit simulation result length %d", len(result))
+	}
+	if new(big.Int).SetBytes(result[32:]).Sign() == 0 {
+		return 0, fmt.Errorf("%w for target timestamp %d", ErrSubmitReverted, targetTimestamp)
+	}
+	return new(big.Int).SetBytes(result[:32]).Uint64(), nil
+}
+
+// SubmitGasBackend is a txmgr.ETHBackend that estimates the gas of the BatchInbox submit calls
+// with the SubmitGasEstimator, and of all other calls with the underlying backend.
+type SubmitGasBackend struct {
+	txmgr.ETHBackend
+
+	inbox     common.Address
+	estimator *SubmitGasEstimator
+}
+
+func NewSubmitGasBackend(backend txmgr.ETHBackend, inbox common.Address, estimator *SubmitGasEstimator) *SubmitGasBackend {
+	return &SubmitGasBackend{
+		ETHBackend: backend,
+		inbox:      inbox,
+		estimator:  estimator,
+	}
+}
+
+func (b *SubmitGasBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	if msg.To == nil || *msg.To != b.inbox {
+		return b.ETHBackend.EstimateGas(ctx, msg)
+	}
+	targetTimestamp, err := derive.SubmitTargetTimestamp(msg.Data)
+	if err != nil {
+		return b.ETHBackend.EstimateGas(ctx, msg)
+	}
+	return b.estimator.EstimateSubmitGas(ctx, msg.From, msg.Data, targetTimestamp)
+}
@@ -0,0 +1,146 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm/runtime"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var (
+	// testInboxCode reverts unless the first argument is the block timestamp, and logs the caller, like the BatchInbox.
+	testInboxCode = common.FromHex("0x600435421460" + "0c" + "57600080fd5b3360006000a100")
+	revertingCode = common.FromHex("0x600080fd")
+)
+
+// fakeSimulationRPC runs the eth_call of the submit simulation in an in-memory EVM.
+type fakeSimulationRPC struct {
+	client.RPC
+	t     *testing.T
+	inbox common.Address
+	code  []byte
+	calls int
+}
+
+func (f *fakeSimulationRPC) newState() *state.StateDB {
+	st, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(f.t, err)
+	st.SetCode(f.inbox, f.code)
+	return st
+}
+
+func (f *fakeSimulationRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	switch method {
+	case "eth_getCode":
+		*result.(*hexutil.Bytes) = f.code
+		return nil
+	case "eth_call":
+		f.calls++
+		call := args[0].(map[string]any)
+		from := call["from"].(common.Address)
+		st := f.newState()
+		for addr, account := range args[2].(map[common.Address]overrideAccount) {
+			st.SetCode(addr, account.Code)
+		}
+		ret, _, err := runtime.Call(call["to"].(common.Address), call["input"].(hexutil.Bytes), &runtime.Config{
+			Origin:   from,
+			Time:     uint64(args[3].(blockOverrides).Time),
+			GasLimit: 30_000_000,
+			State:    st,
+		})
+		if err != nil {
+			return err
+		}
+		*result.(*hexutil.Bytes) = ret
+		return nil
+	}
+	return errors.New("unexpected method")
+}
+
+func TestSubmitGasEstimator(t *testing.T) {
+	inbox := common.Address{0x1b}
+	from := common.Address{0xba}
+	rpc := &fakeSimulationRPC{t: t, inbox: inbox, code: testInboxCode}
+	estimator := NewSubmitGasEstimator(testlog.Logger(t, log.LevelDebug), rpc, inbox, 20)
+
+	data, err := derive.SubmitCalldataTxData(1200, []byte{derive.DerivationVersion0, 0xaa})
+	require.NoError(t, err)
+	gas, err := estimator.EstimateSubmitGas(context.Background(), from, data, 1200)
+	require.NoError(t, err)
+	require.Equal(t, 1, rpc.calls)
+
+	// The submission succeeds with the estimated gas in the block of its target timestamp
+	intrinsic, err := core.IntrinsicGas(data, nil, false, true, true, true)
+	require.NoError(t, err)
+	require.Greater(t, gas, intrinsic)
+	_, _, err = runtime.Call(inbox, data, &runtime.Config{Origin: from, Time: 1200, GasLimit: gas - intrinsic, State: rpc.newState()})
+	require.NoError(t, err)
+
+	// The gas of the call is cached for the code, the intrinsic gas depends on the data
+	data, err = derive.SubmitCalldataTxData(1212, make([]byte, 1000))
+	require.NoError(t, err)
+	larger, err := estimator.EstimateSubmitGas(context.Background(), from, data, 1212)
+	require.NoError(t, err)
+	require.Equal(t, 1, rpc.calls)
+	require.Greater(t, larger, gas)
+
+	// Other submit methods and changed code are simulated again
+	data, err = derive.SubmitBlobTxData(1224)
+	require.NoError(t, err)
+	_, err = estimator.EstimateSubmitGas(context.Background(), from, data, 1224)
+	require.NoError(t, err)
+	require.Equal(t, 2, rpc.calls)
+
+	rpc.code = revertingCode
+	_, err = estimator.EstimateSubmitGas(context.Background(), from, data, 1224)
+	require.ErrorIs(t, err, ErrSubmitReverted)
+	require.Equal(t, 3, rpc.calls)
+}
+
+type fakeEstimateBackend struct {
+	txmgr.ETHBackend
+	estimated int
+}
+
+func (f *fakeEstimateBackend) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	f.estimated++
+	return 21_000, nil
+}
+
+func TestSubmitGasBackend(t *testing.T) {
+	inbox := common.Address{0x1b}
+	rpc := &fakeSimulationRPC{t: t, inbox: inbox, code: testInboxCode}
+	l1 := new(fakeEstimateBackend)
+	backend := NewSubmitGasBackend(l1, inbox, NewSubmitGasEstimator(testlog.Logger(t, log.LevelDebug), rpc, inbox, 20))
+
+	data, err := derive.SubmitBlobTxData(1200)
+	require.NoError(t, err)
+	_, err = backend.EstimateGas(context.Background(), ethereum.CallMsg{To: &inbox, Data: data})
+	require.NoError(t, err)
+	require.Equal(t, 1, rpc.calls)
+	require.Equal(t, 0, l1.estimated)
+
+	// Other calls are estimated by the underlying backend
+	_, err = backend.EstimateGas(context.Background(), ethereum.CallMsg{To: &common.Address{0xac}, Data: data})
+	require.NoError(t, err)
+	_, err = backend.EstimateGas(context.Background(), ethereum.CallMsg{To: &inbox, Data: []byte{0x01}})
+	require.NoError(t, err)
+	require.Equal(t, 1, rpc.calls)
+	require.Equal(t, 2, l1.estimated)
+}
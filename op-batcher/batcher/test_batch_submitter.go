@@ -29,8 +29,11 @@ func (l *TestBatchSubmitter) JamTxPool(ctx context.Context) error {
 	var err error
 	cc := l.state.cfgProvider.ChannelConfig()
 	if cc.UseBlobs {
-		candidate = l.calldataTxCandidate([]byte{})
-	} else if candidate, err = l.blobTxCandidate(emptyTxData, 0); err != nil {
+		candidate, err = l.calldataTxCandidate([]byte{}, 0)
+	} else {
+		candidate, err = l.blobTxCandidate(emptyTxData, 0)
+	}
+	if err != nil {
 		return err
 	}
 	if candidate.GasLimit, err = core.IntrinsicGas(candidate.TxData, nil, false, true, true, false); err != nil {
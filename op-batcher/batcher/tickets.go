@@ -0,0 +1,330 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+var ErrTicketBuyerRunning = errors.New("ticket buyer is already running")
+
+type TicketMetrics interface {
+	RecordTicketAuction(price *big.Int, ticketsLeft uint64)
+	RecordTicketInventory(owned uint64, pending uint64)
+	RecordTicketsBought(amount uint64)
+	RecordTicketBuyFailed()
+	RecordTicketSale(own bool)
+}
+
+type TicketsL1Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+type TicketBuyerConfig struct {
+	// InventoryTarget is the number of election tickets to hold on L2, including the tickets bought but not minted yet.
+	InventoryTarget uint64
+	// PriceCeiling is the maximum price in wei to pay to the auction for a buy.
+	PriceCeiling *big.Int
+	PollInterval time.Duration
+}
+
+// pendingTickets are tickets bought on L1, that are not minted on L2 yet.
+type pendingTickets struct {
+	l1Block uint64
+	amount  uint64
+}
+
+// TicketBuyer keeps the election ticket inventory of the batcher account at its target,
+// by buying tickets from the BlockDutchAuction on L1 when its price is within the ceiling.
+// The bought tickets are minted on L2 by a deposit of the auction, the buyer counts them as
+// pending until they appear on the ticket stack of the account.
+type TicketBuyer struct {
+	log       log.Logger
+	metr      TicketMetrics
+	cfg       TicketBuyerConfig
+	rollupCfg *rollup.Config
+
+	l1    TicketsL1Client
+	l2    dial.L2EndpointProvider
+	txmgr txmgr.TxManager
+
+	auctionABI *abi.ABI
+	ticketsABI *abi.ABI
+
+	// lastL1 is the last L1 block scanned for TicketBought events
+	lastL1 uint64
+	// top is the top of the ticket stack of the account, it changes when tickets are minted or burned
+	top *big.Int
+	// tickets are the IDs of the tickets on the ticket stack of the account
+	tickets map[string]struct{}
+	pending []pendingTickets
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewTicketBuyer(log log.Logger, metr TicketMetrics, cfg TicketBuyerConfig, rollupCfg *rollup.Config, l1 TicketsL1Client, l2 dial.L2EndpointProvider, txMgr txmgr.TxManager) *TicketBuyer {
+	return &TicketBuyer{
+		log:        log,
+		metr:       metr,
+		cfg:        cfg,
+		rollupCfg:  rollupCfg,
+		l1:         l1,
+		l2:         l2,
+		txmgr:      txMgr,
+		auctionABI: snapshots.LoadBlockDutchAuctionABI(),
+		ticketsABI: snapshots.LoadElectionTicketsABI(),
+		tickets:    make(map[string]struct{}),
+	}
+}
+
+func (b *TicketBuyer) Start() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel != nil {
+		return ErrTicketBuyerRunning
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.wg.Add(1)
+	go b.loop(ctx)
+	b.log.Info("Started ticket buyer", "auction", b.rollupCfg.AuctionContractAddress,
+		"inventory_target", b.cfg.InventoryTarget, "price_ceiling", b.cfg.PriceCeiling)
+	return nil
+}
+
+// Stop stops the ticket buyer, and waits for an in-flight buy to be cancelled.
+func (b *TicketBuyer) Stop() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel == nil {
+		return
+	}
+	b.cancel()
+	b.wg.Wait()
+	b.cancel = nil
+}
+
+func (b *TicketBuyer) loop(ctx context.Context) {
+	defer b.wg.Done()
+	ticker := time.NewTicker(b.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := b.Step(ctx); err != nil && ctx.Err() == nil {
+			b.log.Warn("Ticket buyer step failed", "err", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Step scans the auction for new sales, updates the ticket inventory,
+// and buys the missing tickets if the auction price is within the ceiling.
+func (b *TicketBuyer) Step(ctx context.Context) error {
+	head, err := b.l1.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if err := b.scanSales(ctx, head); err != nil {
+		return err
+	}
+	owned, err := b.updateInventory(ctx, head)
+	if err != nil {
+		return err
+	}
+	var pending uint64
+	for _, p := range b.pending {
+		pending += p.amount
+	}
+	b.metr.RecordTicketInventory(owned, pending)
+	if owned+pending >= b.cfg.InventoryTarget {
+		return nil
+	}
+
+	price, err := b.callAuction(ctx, "getPrice")
+	if err != nil {
+		return err
+	}
+	left, err := b.callAuction(ctx, "ticketsLeft")
+	if err != nil {
+		return err
+	}
+	b.metr.RecordTicketAuction(price, left.Uint64())
+	if price.Cmp(b.cfg.PriceCeiling) > 0 {
+		b.log.Debug("Ticket price above ceiling", "price", price, "ceiling", b.cfg.PriceCeiling)
+		return nil
+	}
+	amount := min(b.cfg.InventoryTarget-owned-pending, left.Uint64(), 255)
+	if amount == 0 {
+		return nil
+	}
+	return b.buy(ctx, uint8(amount), price)
+}
+
+// scanSales records the TicketBought events of the auction since the last scanned L1 block.
+func (b *TicketBuyer) scanSales(ctx context.Context, head uint64) error {
+	from := b.lastL1 + 1
+	if b.lastL1 == 0 {
+		from = head
+	}
+	if from > head {
+		return nil
+	}
+	logs, err := b.l1.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(from),
+		ToBlock:   new(big.Int).SetUint64(head),
+		Addresses: []common.Address{b.rollupCfg.AuctionContractAddress},
+		Topics:    [][]common.Hash{{b.auctionABI.Events["TicketBought"].ID}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch TicketBought events: %w", err)
+	}
+	for _, l := range logs {
+		if len(l.Topics) != 3 {
+			continue
+		}
+		buyer := common.BytesToAddress(l.Topics[1].Bytes())
+		sale, err := b.auctionABI.Unpack("TicketBought", l.Data)
+		if err != nil {
+			b.log.Warn("Invalid TicketBought event", "tx", l.TxHash, "err", err)
+			continue
+		}
+		own := buyer == b.txmgr.From()
+		b.log.Info("Election tickets bought", "buyer", buyer, "own", own, "auction_start", l.Topics[2].Big(),
+			"price", sale[0].(*big.Int), "tickets_left", sale[1].(uint8), "l1_block", l.BlockNumber)
+		b.metr.RecordTicketSale(own)
+	}
+	b.lastL1 = head
+	return nil
+}
+
+// updateInventory reads the ticket stack of the account on L2, and clears the pending tickets that were minted.
+// The stack is only traversed when its top changed, since minting and burning both change the top.
+func (b *TicketBuyer) updateInventory(ctx context.Context, head uint64) (uint64, error) {
+	l2, err := b.l2.EthClient(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get L2 client: %w", err)
+	}
+	top, err := b.callTickets(ctx, l2, "top", b.txmgr.From())
+	if err != nil {
+		return 0, err
+	}
+	if b.top != nil && b.top.Cmp(top[0].(*big.Int)) == 0 {
+		b.expirePending(head)
+		return uint64(len(b.tickets)), nil
+	}
+	stack, err := b.callTickets(ctx, l2, "traverseTicketStack", b.txmgr.From())
+	if err != nil {
+		return 0, err
+	}
+	ids := stack[0].([]*big.Int)
+	tickets := make(map[string]struct{}, len(ids))
+	var minted uint64
+	for _, id := range ids {
+		if _, ok := b.tickets[id.String()]; !ok && b.top != nil {
+			minted++
+		}
+		tickets[id.String()] = struct{}{}
+	}
+	b.top = top[0].(*big.Int)
+	b.tickets = tickets
+	for len(b.pending) > 0 && minted > 0 {
+		n := min(minted, b.pending[0].amount)
+		b.pending[0].amount -= n
+		minted -= n
+		if b.pending[0].amount == 0 {
+			b.pending = b.pending[1:]
+		}
+	}
+	b.expirePending(head)
+	return uint64(len(ids)), nil
+}
+
+// expirePending drops the pending tickets that were not minted within the sequencing window,
+// in which the deposit minting them must have been included on L2.
+func (b *TicketBuyer) expirePending(head uint64) {
+	for len(b.pending) > 0 && b.pending[0].l1Block+b.rollupCfg.SeqWindowSize < head {
+		b.log.Warn("Bought election tickets were not minted on L2", "amount", b.pending[0].amount, "l1_block", b.pending[0].l1Block)
+		b.pending = b.pending[1:]
+	}
+}
+
+// buy buys the tickets at the given price. The auction charges its price once per buy,
+// and reverts if the price increased because a new auction started.
+func (b *TicketBuyer) buy(ctx context.Context, amount uint8, price *big.Int) error {
+	data, err := b.auctionABI.Pack("buy", amount)
+	if err != nil {
+		return fmt.Errorf("failed to pack buy call: %w", err)
+	}
+	b.log.Info("Buying election tickets", "amount", amount, "price", price)
+	receipt, err := b.txmgr.Send(ctx, txmgr.TxCandidate{
+		TxData: data,
+		To:     &b.rollupCfg.AuctionContractAddress,
+		Value:  price,
+	})
+	if err != nil {
+		b.metr.RecordTicketBuyFailed()
+		return fmt.Errorf("failed to buy %d tickets: %w", amount, err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		b.metr.RecordTicketBuyFailed()
+		return fmt.Errorf("ticket buy tx %s reverted", receipt.TxHash)
+	}
+	b.log.Info("Bought election tickets", "amount", amount, "price", price, "tx", receipt.TxHash, "l1_block", receipt.BlockNumber)
+	b.metr.RecordTicketsBought(uint64(amount))
+	b.pending = append(b.pending, pendingTickets{l1Block: receipt.BlockNumber.Uint64(), amount: uint64(amount)})
+	return nil
+}
+
+func (b *TicketBuyer) callAuction(ctx context.Context, method string) (*big.Int, error) {
+	data, err := b.auctionABI.Pack(method)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+	res, err := b.l1.CallContract(ctx, ethereum.CallMsg{To: &b.rollupCfg.AuctionContractAddress, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call auction %s: %w", method, err)
+	}
+	out, err := b.auctionABI.Unpack(method, res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack auction %s result: %w", method, err)
+	}
+	return out[0].(*big.Int), nil
+}
+
+func (b *TicketBuyer) callTickets(ctx context.Context, l2 dial.EthClientInterface, method string, args ...any) ([]any, error) {
+	data, err := b.ticketsABI.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to pack %s call: %w", method, err)
+	}
+	res, err := l2.CallContract(ctx, ethereum.CallMsg{To: &derive.ElectionTickets, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call election tickets %s: %w", method, err)
+	}
+	out, err := b.ticketsABI.Unpack(method, res)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack election tickets %s result: %w", method, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,177 @@
+package batcher
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+type fakeAuction struct {
+	head  uint64
+	price *big.Int
+	left  uint64
+	logs  []types.Log
+}
+
+func (f *fakeAuction) BlockNumber(ctx context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeAuction) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	auction := snapshots.LoadBlockDutchAuctionABI()
+	method, err := auction.MethodById(msg.Data)
+	if err != nil {
+		return nil, err
+	}
+	switch method.Name {
+	case "getPrice":
+		return method.Outputs.Pack(f.price)
+	default:
+		return method.Outputs.Pack(new(big.Int).SetUint64(f.left))
+	}
+}
+
+func (f *fakeAuction) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	logs := f.logs
+	f.logs = nil
+	return logs, nil
+}
+
+type fakeTicketsTxMgr struct {
+	txmgr.TxManager
+	from common.Address
+	sent []txmgr.TxCandidate
+	l1   *fakeAuction
+}
+
+func (f *fakeTicketsTxMgr) From() common.Address {
+	return f.from
+}
+
+func (f *fakeTicketsTxMgr) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	f.sent = append(f.sent, candidate)
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful, BlockNumber: new(big.Int).SetUint64(f.l1.head)}, nil
+}
+
+type ticketMetrics struct {
+	owned, pending, bought, sales uint64
+}
+
+func (m *ticketMetrics) RecordTicketAuction(*big.Int, uint64) {}
+func (m *ticketMetrics) RecordTicketInventory(owned uint64, pending uint64) {
+	m.owned, m.pending = owned, pending
+}
+func (m *ticketMetrics) RecordTicketsBought(amount uint64) { m.bought += amount }
+func (m *ticketMetrics) RecordTicketBuyFailed()            {}
+func (m *ticketMetrics) RecordTicketSale(bool)             { m.sales++ }
+
+func TestTicketBuyer(t *testing.T) {
+	cfg := &rollup.Config{AuctionContractAddress: common.Address{0xac}, SeqWindowSize: 10}
+	auctionABI := snapshots.LoadBlockDutchAuctionABI()
+	ticketsABI := snapshots.LoadElectionTicketsABI()
+	from := common.Address{0xbb}
+
+	l1 := &fakeAuction{head: 100, price: big.NewInt(150), left: 2}
+	txMgr := &fakeTicketsTxMgr{from: from, l1: l1}
+	ep := newEndpointProvider()
+	m := new(ticketMetrics)
+	buyer := NewTicketBuyer(testlog.Logger(t, log.LevelDebug), m, TicketBuyerConfig{
+		InventoryTarget: 3,
+		PriceCeiling:    big.NewInt(100),
+		PollInterval:    time.Second,
+	}, cfg, l1, ep, txMgr)
+
+	expectStack := func(ids ...int64) {
+		stack := make([]*big.Int, len(ids))
+		for i, id := range ids {
+			stack[i] = big.NewInt(id)
+		}
+		top := new(big.Int)
+		if len(ids) > 0 {
+			top = stack[0]
+		}
+		topData, err := ticketsABI.Pack("top", from)
+		require.NoError(t, err)
+		topRes, err := ticketsABI.Methods["top"].Outputs.Pack(top)
+		require.NoError(t, err)
+		ep.ethClient.ExpectCallContract(ethereum.CallMsg{To: &derive.ElectionTickets, Data: topData}, nil, topRes, nil)
+		if buyer.top != nil && buyer.top.Cmp(top) == 0 {
+			return
+		}
+		stackData, err := ticketsABI.Pack("traverseTicketStack", from)
+		require.NoError(t, err)
+		stackRes, err := ticketsABI.Methods["traverseTicketStack"].Outputs.Pack(stack)
+		require.NoError(t, err)
+		ep.ethClient.ExpectCallContract(ethereum.CallMsg{To: &derive.ElectionTickets, Data: stackData}, nil, stackRes, nil)
+	}
+	sale := func(buyer common.Address) types.Log {
+		data, err := auctionABI.Events["TicketBought"].Inputs.NonIndexed().Pack(big.NewInt(150), uint8(1))
+		require.NoError(t, err)
+		return types.Log{
+			Address: cfg.AuctionContractAddress,
+			Topics:  []common.Hash{auctionABI.Events["TicketBought"].ID, common.BytesToHash(buyer.Bytes()), common.BigToHash(big.NewInt(90))},
+			Data:    data,
+		}
+	}
+
+	// The price is above the ceiling, another buyer buys a ticket
+	l1.logs = []types.Log{sale(common.Address{0xcc})}
+	expectStack()
+	require.NoError(t, buyer.Step(context.Background()))
+	require.Empty(t, txMgr.sent)
+	require.Equal(t, uint64(1), m.sales)
+
+	// The price drops below the ceiling, all tickets left are bought
+	l1.price = big.NewInt(90)
+	expectStack()
+	require.NoError(t, buyer.Step(context.Background()))
+	require.Len(t, txMgr.sent, 1)
+	buy, err := auctionABI.Pack("buy", uint8(2))
+	require.NoError(t, err)
+	require.Equal(t, buy, txMgr.sent[0].TxData)
+	require.Equal(t, cfg.AuctionContractAddress, *txMgr.sent[0].To)
+	require.Equal(t, big.NewInt(90), txMgr.sent[0].Value)
+	require.Equal(t, uint64(2), m.bought)
+
+	// The bought tickets are pending until minted on L2, and the auction is sold out
+	l1.head++
+	l1.left = 0
+	expectStack()
+	require.NoError(t, buyer.Step(context.Background()))
+	require.Len(t, txMgr.sent, 1)
+	require.Equal(t, uint64(2), m.pending)
+
+	// The tickets are minted, and the next auction has tickets left for the missing one
+	l1.head++
+	l1.left = 5
+	expectStack(2, 1)
+	require.NoError(t, buyer.Step(context.Background()))
+	require.Equal(t, uint64(2), m.owned)
+	require.Len(t, txMgr.sent, 2)
+	buy, err = auctionABI.Pack("buy", uint8(1))
+	require.NoError(t, err)
+	require.Equal(t, buy, txMgr.sent[1].TxData)
+
+	// A ticket is burned, while the bought ticket is not minted within the sequencing window
+	l1.head += cfg.SeqWindowSize + 1
+	l1.price = big.NewInt(200)
+	expectStack(1)
+	require.NoError(t, buyer.Step(context.Background()))
+	require.Equal(t, uint64(1), m.owned)
+	require.Equal(t, uint64(0), m.pending)
+	ep.ethClient.AssertExpectations(t)
+}
@@ -167,6 +167,52 @@ var (
 		Usage:   "Indicates if this batcher should send the batches on even blocks. POC ONLY.",
 		EnvVars: prefixEnvVars("EVEN_BLOCKS"),
 	}
+	PermissionlessSlotsFlag = &cli.BoolFlag{
+		Name:    "permissionless-slots",
+		Usage:   "Indicates if this batcher should also submit batches for permissionless slots, racing other batchers for them.",
+		EnvVars: prefixEnvVars("PERMISSIONLESS_SLOTS"),
+	}
+	MaxFramesPerSlotFlag = &cli.IntFlag{
+		Name: "max-frames-per-slot",
+		Usage: "Maximum number of frames (blobs) to pack into the batch tx of a single target slot when the pending L2 data " +
+			"doesn't fit into the remaining target slots of the epoch. The target number of frames is used if smaller.",
+		EnvVars: prefixEnvVars("MAX_FRAMES_PER_SLOT"),
+	}
+	BuilderEndpointsFlag = &cli.StringSliceFlag{
+		Name: "builder-endpoints",
+		Usage: "Comma-separated list of block builder or relay RPC endpoints to send the batch transactions to " +
+			"as eth_sendBundle bundles, targeting the L1 block of the slot of the batch. The mempool is used if empty.",
+		EnvVars: prefixEnvVars("BUILDER_ENDPOINTS"),
+	}
+	BuilderMempoolFallbackFlag = &cli.BoolFlag{
+		Name:    "builder-mempool-fallback",
+		Usage:   "Send the batch transaction to the mempool if no builder accepts its bundle.",
+		Value:   true,
+		EnvVars: prefixEnvVars("BUILDER_MEMPOOL_FALLBACK"),
+	}
+	SubmitGasMarginFlag = &cli.Uint64Flag{
+		Name:    "submit-gas-margin",
+		Usage:   "Safety margin (in percent) added to the simulated gas of the BatchInbox submit call of the batch transactions.",
+		Value:   20,
+		EnvVars: prefixEnvVars("SUBMIT_GAS_MARGIN"),
+	}
+	TicketInventoryTargetFlag = &cli.Uint64Flag{
+		Name: "ticket-inventory-target",
+		Usage: "Number of election tickets to keep for the batcher account, buying tickets from the auction on L1 when " +
+			"the inventory is below it. Tickets are not bought if 0.",
+		EnvVars: prefixEnvVars("TICKET_INVENTORY_TARGET"),
+	}
+	TicketPriceCeilingFlag = &cli.Float64Flag{
+		Name:    "ticket-price-ceiling",
+		Usage:   "The maximum price (in GWei) to pay to the auction for a buy of election tickets.",
+		EnvVars: prefixEnvVars("TICKET_PRICE_CEILING"),
+	}
+	TicketPollIntervalFlag = &cli.DurationFlag{
+		Name:    "ticket-poll-interval",
+		Usage:   "How frequently to check the election ticket inventory and the auction price.",
+		Value:   12 * time.Second,
+		EnvVars: prefixEnvVars("TICKET_POLL_INTERVAL"),
+	}
 	// Legacy Flags
 	SequencerHDPathFlag = txmgr.SequencerHDPathFlag
 )
@@ -180,6 +226,14 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	EvenBlocksFlag,
+	PermissionlessSlotsFlag,
+	MaxFramesPerSlotFlag,
+	BuilderEndpointsFlag,
+	BuilderMempoolFallbackFlag,
+	SubmitGasMarginFlag,
+	TicketInventoryTargetFlag,
+	TicketPriceCeilingFlag,
+	TicketPollIntervalFlag,
 	WaitNodeSyncFlag,
 	CheckRecentTxsDepthFlag,
 	SubSafetyMarginFlag,
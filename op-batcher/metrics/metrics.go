@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/prometheus/client_golang/prometheus"
 
@@ -48,6 +49,19 @@ type Metricer interface {
 
 	RecordBlobUsedBytes(num int)
 
+	RecordBundleSent()
+	RecordBundleFallback()
+	RecordBundleSlot(landed bool)
+
+	RecordTargetSlot(hit bool)
+	RecordSlotPlan(framesPerSlot int, slotsNeeded int, projectedSafeLag uint64)
+
+	RecordTicketAuction(price *big.Int, ticketsLeft uint64)
+	RecordTicketInventory(owned uint64, pending uint64)
+	RecordTicketsBought(amount uint64)
+	RecordTicketBuyFailed()
+	RecordTicketSale(own bool)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -83,6 +97,23 @@ type Metrics struct {
 	batcherTxEvs opmetrics.EventVec
 
 	blobUsedBytes prometheus.Histogram
+
+	// label by sent, fallback, landed, missed
+	bundleEvs opmetrics.EventVec
+
+	// label by hit, missed
+	targetSlotEvs opmetrics.EventVec
+
+	slotPlanFrames      prometheus.Gauge
+	slotPlanSlotsNeeded prometheus.Gauge
+	projectedSafeLag    prometheus.Gauge
+
+	ticketPrice        prometheus.Gauge
+	ticketsLeft        prometheus.Gauge
+	tickets            prometheus.GaugeVec
+	ticketsBoughtTotal prometheus.Counter
+	// label by bought, buy_failed, sold_own, sold_other
+	ticketEvs opmetrics.EventVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -193,6 +224,48 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		bundleEvs: opmetrics.NewEventVec(factory, ns, "", "bundle", "Bundle", []string{"stage"}),
+
+		targetSlotEvs: opmetrics.NewEventVec(factory, ns, "", "target_slot", "TargetSlot", []string{"stage"}),
+
+		slotPlanFrames: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "slot_plan_frames_per_slot",
+			Help:      "Number of frames planned per target slot to submit the pending L2 data.",
+		}),
+		slotPlanSlotsNeeded: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "slot_plan_slots_needed",
+			Help:      "Number of target slots needed to submit the pending L2 data.",
+		}),
+		projectedSafeLag: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "projected_safe_lag_seconds",
+			Help:      "Projected lag of the safe head behind the latest pending L2 block once the pending data is submitted.",
+		}),
+
+		ticketPrice: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "ticket_price",
+			Help:      "Current price of an election ticket in the auction, in ETH.",
+		}),
+		ticketsLeft: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "tickets_left",
+			Help:      "Number of election tickets left in the current auction.",
+		}),
+		tickets: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "tickets",
+			Help:      "Number of election tickets of the batcher, owned on L2 or bought and pending to be minted.",
+		}, []string{"stage"}),
+		ticketsBoughtTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "tickets_bought_total",
+			Help:      "Total number of election tickets bought by the batcher.",
+		}),
+		ticketEvs: opmetrics.NewEventVec(factory, ns, "", "ticket", "Ticket", []string{"stage"}),
 	}
 }
 
@@ -231,6 +304,21 @@ const (
 	TxStageSubmitted = "submitted"
 	TxStageSuccess   = "success"
 	TxStageFailed    = "failed"
+
+	BundleStageSent     = "sent"
+	BundleStageFallback = "fallback"
+	BundleStageLanded   = "landed"
+	BundleStageMissed   = "missed"
+
+	TargetSlotStageHit    = "hit"
+	TargetSlotStageMissed = "missed"
+
+	TicketStageOwned     = "owned"
+	TicketStagePending   = "pending"
+	TicketStageBought    = "bought"
+	TicketStageBuyFailed = "buy_failed"
+	TicketStageSoldOwn   = "sold_own"
+	TicketStageSoldOther = "sold_other"
 )
 
 func (m *Metrics) RecordLatestL1Block(l1ref eth.L1BlockRef) {
@@ -278,13 +366,13 @@ func (m *Metrics) RecordChannelClosed(id derive.ChannelID, numPendingBlocks int,
 }
 
 func (m *Metrics) RecordL2BlockInPendingQueue(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesTotal.Add(size)
 	m.pendingBlocksBytesCurrent.Add(size)
 }
 
 func (m *Metrics) RecordL2BlockInChannel(block *types.Block) {
-	size := float64(estimateBatchSize(block))
+	size := float64(EstimateBatchSize(block))
 	m.pendingBlocksBytesCurrent.Add(-1 * size)
 	// Refer to RecordL2BlocksAdded to see the current + count of bytes added to a channel
 }
@@ -318,8 +406,68 @@ func (m *Metrics) RecordBlobUsedBytes(num int) {
 	m.blobUsedBytes.Observe(float64(num))
 }
 
-// estimateBatchSize estimates the size of the batch
-func estimateBatchSize(block *types.Block) uint64 {
+func (m *Metrics) RecordBundleSent() {
+	m.bundleEvs.Record(BundleStageSent)
+}
+
+func (m *Metrics) RecordBundleFallback() {
+	m.bundleEvs.Record(BundleStageFallback)
+}
+
+// RecordBundleSlot records whether a bundle landed in the block of the slot it targeted.
+func (m *Metrics) RecordBundleSlot(landed bool) {
+	if landed {
+		m.bundleEvs.Record(BundleStageLanded)
+	} else {
+		m.bundleEvs.Record(BundleStageMissed)
+	}
+}
+
+// RecordTargetSlot records whether a batch tx landed in a slot the batcher won the election for.
+func (m *Metrics) RecordTargetSlot(hit bool) {
+	if hit {
+		m.targetSlotEvs.Record(TargetSlotStageHit)
+	} else {
+		m.targetSlotEvs.Record(TargetSlotStageMissed)
+	}
+}
+
+func (m *Metrics) RecordSlotPlan(framesPerSlot int, slotsNeeded int, projectedSafeLag uint64) {
+	m.slotPlanFrames.Set(float64(framesPerSlot))
+	m.slotPlanSlotsNeeded.Set(float64(slotsNeeded))
+	m.projectedSafeLag.Set(float64(projectedSafeLag))
+}
+
+func (m *Metrics) RecordTicketAuction(price *big.Int, ticketsLeft uint64) {
+	m.ticketPrice.Set(eth.WeiToEther(price))
+	m.ticketsLeft.Set(float64(ticketsLeft))
+}
+
+func (m *Metrics) RecordTicketInventory(owned uint64, pending uint64) {
+	m.tickets.WithLabelValues(TicketStageOwned).Set(float64(owned))
+	m.tickets.WithLabelValues(TicketStagePending).Set(float64(pending))
+}
+
+func (m *Metrics) RecordTicketsBought(amount uint64) {
+	m.ticketEvs.Record(TicketStageBought)
+	m.ticketsBoughtTotal.Add(float64(amount))
+}
+
+func (m *Metrics) RecordTicketBuyFailed() {
+	m.ticketEvs.Record(TicketStageBuyFailed)
+}
+
+// RecordTicketSale records a sale of the auction, to the batcher or to another buyer.
+func (m *Metrics) RecordTicketSale(own bool) {
+	if own {
+		m.ticketEvs.Record(TicketStageSoldOwn)
+	} else {
+		m.ticketEvs.Record(TicketStageSoldOther)
+	}
+}
+
+// EstimateBatchSize estimates the uncompressed size of the batch of the block.
+func EstimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
 	for _, tx := range block.Transactions() {
 		// Don't include deposit transactions in the batch.
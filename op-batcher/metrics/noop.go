@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -43,6 +44,19 @@ func (*noopMetrics) RecordBatchTxSubmitted() {}
 func (*noopMetrics) RecordBatchTxSuccess()   {}
 func (*noopMetrics) RecordBatchTxFailed()    {}
 func (*noopMetrics) RecordBlobUsedBytes(int) {}
+func (*noopMetrics) RecordBundleSent()       {}
+func (*noopMetrics) RecordBundleFallback()   {}
+func (*noopMetrics) RecordBundleSlot(bool)   {}
+func (*noopMetrics) RecordTargetSlot(bool)   {}
+
+func (*noopMetrics) RecordSlotPlan(int, int, uint64) {}
+
+func (*noopMetrics) RecordTicketAuction(*big.Int, uint64) {}
+func (*noopMetrics) RecordTicketInventory(uint64, uint64) {}
+func (*noopMetrics) RecordTicketsBought(uint64)           {}
+func (*noopMetrics) RecordTicketBuyFailed()               {}
+func (*noopMetrics) RecordTicketSale(bool)                {}
+
 func (*noopMetrics) StartBalanceMetrics(log.Logger, *ethclient.Client, common.Address) io.Closer {
 	return nil
 }
@@ -30,6 +30,10 @@ func (g *gossipNoop) OnUnsafeL2Payload(_ context.Context, _ peer.ID, _ *eth.Exec
 	return nil
 }
 
+func (g *gossipNoop) OnPreconfirmation(_ context.Context, _ peer.ID, _ *eth.Preconfirmation) error {
+	return nil
+}
+
 type gossipConfig struct{}
 
 func (g *gossipConfig) P2PSequencerAddress() common.Address {
@@ -67,7 +71,7 @@ func Main(cliCtx *cli.Context) error {
 		p2pConfig.EnableReqRespSync = false
 	}
 
-	p2pNode, err := p2p.NewNodeP2P(ctx, config, logger, p2pConfig, &gossipNoop{}, &l2Chain{}, &gossipConfig{}, m, false)
+	p2pNode, err := p2p.NewNodeP2P(ctx, config, logger, p2pConfig, &gossipNoop{}, &l2Chain{}, &gossipConfig{}, nil, m, false)
 	if err != nil || p2pNode == nil {
 		return err
 	}
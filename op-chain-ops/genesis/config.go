@@ -760,6 +760,10 @@ type L1DependenciesConfig struct {
 	// BatchInbox represents the address or the BatchInbox contract on L1 and is
 	// as an address where batches are sent to.
 	BatchInbox common.Address `json:"batchInbox"`
+	// OperatorRegistry represents the address of the L1 operator registry that validators register
+	// their sequencer operators in. It is not deployed with the chain, and is optional:
+	// without it the rollup nodes must read the operators from the validator pubkeys of a fake lookahead.
+	OperatorRegistry common.Address `json:"operatorRegistry"`
 
 	// DAChallengeProxy represents the L1 address of the DataAvailabilityChallenge contract.
 	DAChallengeProxy common.Address `json:"daChallengeProxy"`
@@ -965,6 +969,7 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Header, l2GenesisBlockHa
 		DepositContractAddress:    d.OptimismPortalProxy,
 		L1SystemConfigAddress:     d.SystemConfigProxy,
 		AuctionContractAddress:    d.BlockDutchAuction,
+		OperatorRegistryAddress:   d.OperatorRegistry,
 		RegolithTime:              d.RegolithTime(l1StartTime),
 		CanyonTime:                d.CanyonTime(l1StartTime),
 		DeltaTime:                 d.DeltaTime(l1StartTime),
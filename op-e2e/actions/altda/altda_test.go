@@ -18,6 +18,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
@@ -164,8 +165,11 @@ func (a *L2AltDA) ActNewL2Tx(t helpers.Testing) {
 	a.batcher.ActL2BatchBuffer(t)
 	a.batcher.ActL2ChannelClose(t)
 	a.batcher.ActL2BatchSubmit(t, func(tx *types.DynamicFeeTx) {
+		// the commitment is submitted in a submitCalldata call
+		txData, err := derive.UnpackSubmitCalldata(tx.Data)
+		require.NoError(t, err)
 		// skip txdata version byte
-		a.lastComm = tx.Data[1:]
+		a.lastComm = txData[1:]
 	})
 
 	a.miner.ActL1StartBlock(12)(t)
@@ -260,6 +264,34 @@ func (a *L2AltDA) ActL1Finalized(t helpers.Testing) {
 	a.sequencer.ActL1FinalizedSignal(t)
 }
 
+// Commitment is submitted in a submitCalldata call, and the verifier derives the input from it.
+func TestAltDA_SubmitCalldata(gt *testing.T) {
+	if !e2eutils.UseAltDA() {
+		gt.Skip("AltDA is not enabled")
+	}
+
+	t := helpers.NewDefaultTesting(gt)
+	harness := NewL2AltDA(t)
+
+	harness.ActL1Blocks(t, 5)
+	harness.ActNewL2Tx(t)
+
+	batchTx := harness.batcher.LastSubmitted
+	txData, err := derive.ExtractPayload(batchTx)
+	require.NoError(t, err)
+	require.Equal(t, harness.lastComm, []byte(txData[1:]))
+	receipt, err := harness.miner.EthClient().TransactionReceipt(t.Ctx(), batchTx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "BatchInbox must accept the commitment")
+
+	blk := harness.GetLastTxBlock(t)
+	harness.sequencer.ActL2PipelineFull(t)
+
+	verifier := harness.NewVerifier(t)
+	verifier.ActL2PipelineFull(t)
+	require.GreaterOrEqual(t, verifier.L2Safe().Number, blk.NumberU64(), "verifier derives the input of the commitment")
+}
+
 // Commitment is challenged but never resolved, chain reorgs when challenge window expires.
 func TestAltDA_ChallengeExpired(gt *testing.T) {
 	if !e2eutils.UseAltDA() {
@@ -1,6 +1,7 @@
 package derivation
 
 import (
+	"context"
 	"testing"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
@@ -8,6 +9,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
 	upgradesHelpers "github.com/ethereum-optimism/optimism/op-e2e/actions/upgrades/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/node/electiondb"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/election_client"
@@ -95,7 +97,7 @@ func TestDeriveChainFromNearL1Genesis(gt *testing.T) {
 	l2Cl, err := sources.NewEngineClient(seqEngine.RPCClient(), logger, nil, sources.EngineClientDefaultConfig(sd.RollupCfg))
 	require.NoError(gt, err)
 	l1Cl := miner.L1Client(t, sd.RollupCfg)
-	electionStore := election_client.NewElectionStore(logger)
+	electionStore := election_client.NewElectionStore(context.Background(), logger, electiondb.Disabled, nil, nil)
 	verifier := helpers.NewL2Verifier(t, logger, l1Cl, miner.BlobStore(), miner.BeaconClient(), altda.Disabled,
 		l2Cl, l1Cl.EthClient, sequencer.RollupCfg, &sync.Config{}, safedb.Disabled, nil, electionStore)
 	verifier.ActL2PipelineFull(t) // Should not get stuck in a reset loop forever
@@ -4,8 +4,10 @@ import (
 	"math/big"
 	"testing"
 
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
 	actionsHelpers "github.com/ethereum-optimism/optimism/op-e2e/actions/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
@@ -131,3 +133,52 @@ func setupBatcher(t actionsHelpers.Testing, log log.Logger, sd *e2eutils.SetupDa
 		L1BlockTime:          dp.DeployConfig.L1BlockTime,
 	}, sequencer.RollupClient(), miner.EthClient(), engine.EthClient(), engine.EngineClient(t, sd.RollupCfg))
 }
+
+// Tests that the batches of the election winner are derived for both calldata and blob DA.
+// Calldata batches are wrapped in a submitCalldata call to the BatchInbox contract,
+// which emits the BatchSubmitted event that the derivation checks the submitter with.
+func TestElectionDataAvailabilityTypes(gt *testing.T) {
+	for _, daType := range []batcherFlags.DataAvailabilityType{batcherFlags.CalldataType, batcherFlags.BlobsType} {
+		gt.Run(string(daType), func(gt *testing.T) {
+			t := actionsHelpers.NewDefaultTesting(gt)
+			dp := e2eutils.MakeDeployParams(t, actionsHelpers.DefaultRollupTestParams)
+			sd := e2eutils.Setup(t, dp, actionsHelpers.DefaultAlloc)
+			log := testlog.Logger(t, log.LevelDebug)
+			miner, l2Engine, sequencer, verifier, _, _ := actionsHelpers.SetupElectionTest(t, dp, sd, log)
+			batcherCfg := actionsHelpers.DefaultBatcherCfg(dp)
+			batcherCfg.DataAvailabilityType = daType
+			batcher := actionsHelpers.NewL2Batcher(log, sd.RollupCfg, batcherCfg,
+				sequencer.RollupClient(), miner.EthClient(), l2Engine.EthClient(), l2Engine.EngineClient(t, sd.RollupCfg))
+
+			sequencer.ActL2PipelineFull(t)
+			verifier.ActL2PipelineFull(t)
+
+			miner.ActEmptyBlock(t)
+			miner.ActL1SafeNext(t)
+			miner.ActL1FinalizeNext(t)
+			sequencer.ActL1HeadSignal(t)
+			sequencer.ActBuildToL1Head(t)
+
+			batcher.ActSubmitAll(t)
+			batchTx := batcher.LastSubmitted
+			if daType == batcherFlags.CalldataType {
+				require.Equal(t, uint8(types.DynamicFeeTxType), batchTx.Type(), "batch tx must be calldata-tx")
+				_, err := derive.ExtractPayload(batchTx)
+				require.NoError(t, err, "calldata batch must be a submitCalldata call")
+			} else {
+				require.Equal(t, uint8(types.BlobTxType), batchTx.Type(), "batch tx must be blob-tx")
+			}
+			miner.ActL1StartBlock(12)(t)
+			miner.ActL1IncludeTxByHash(batchTx.Hash())(t)
+			miner.ActL1EndBlock(t)
+
+			receipt, err := miner.EthClient().TransactionReceipt(t.Ctx(), batchTx.Hash())
+			require.NoError(t, err)
+			require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status, "BatchInbox must accept the batch")
+
+			verifier.ActL1HeadSignal(t)
+			verifier.ActL2PipelineFull(t)
+			require.Equal(t, verifier.L2Safe(), sequencer.L2Unsafe(), "verifier syncs from sequencer via L1")
+		})
+	}
+}
@@ -13,11 +13,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
-	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
@@ -26,9 +26,9 @@ import (
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 )
 
 type SyncStatusAPI interface {
@@ -43,6 +43,7 @@ type L1TxAPI interface {
 	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	Client() *rpc.Client
 }
 
 type AltDAInputSetter interface {
@@ -119,7 +120,8 @@ type L2Batcher struct {
 	l1            L1TxAPI
 	engCl         L2BlockRefs
 
-	l1Signer types.Signer
+	l1Signer     types.Signer
+	gasEstimator *batcher.SubmitGasEstimator
 
 	L2ChannelOut     ChannelOutIface
 	l2Submitting     bool // when the channel out is being submitted, and not safe to write to without resetting
@@ -142,6 +144,7 @@ func NewL2Batcher(log log.Logger, rollupCfg *rollup.Config, batcherCfg *BatcherC
 		l2BatcherCfg:  batcherCfg,
 		l1Signer:      types.LatestSignerForChainID(rollupCfg.L1ChainID),
 		BatcherAddr:   crypto.PubkeyToAddress(batcherCfg.BatcherKey.PublicKey),
+		gasEstimator:  batcher.NewSubmitGasEstimator(log, client.NewBaseRPCClient(l1.Client()), rollupCfg.BatchInboxContractAddress, 20),
 	}
 }
 
@@ -305,9 +308,9 @@ func (s *L2Batcher) ActL2BatchSubmitRaw(t Testing, payload []byte, txOpts ...fun
 			opt(rawTx)
 		}
 
-		gas, err := core.IntrinsicGas(rawTx.Data, nil, false, true, true, false)
-		require.NoError(t, err, "need to compute intrinsic gas")
-		rawTx.Gas = gas + 30000 // TODO(spire): POC only
+		gas, err := s.gasEstimator.EstimateSubmitGas(t.Ctx(), s.BatcherAddr, rawTx.Data, nextBlockTime)
+		require.NoError(t, err, "need to estimate submit gas")
+		rawTx.Gas = gas
 		txData = rawTx
 	} else if s.l2BatcherCfg.DataAvailabilityType == batcherFlags.BlobsType {
 		var b eth.Blob
@@ -320,11 +323,13 @@ func (s *L2Batcher) ActL2BatchSubmitRaw(t Testing, payload []byte, txOpts ...fun
 		if blobFeeCap.Lt(uint256.NewInt(params.GWei)) { // ensure we meet 1 gwei geth tx-pool minimum
 			blobFeeCap = uint256.NewInt(params.GWei)
 		}
-		calldata := submitBlobTxData(t, syncStatus.CurrentL1.Time+s.l2BatcherCfg.L1BlockTime)
+		calldata := submitBlobTxData(t, nextBlockTime)
+		gas, err := s.gasEstimator.EstimateSubmitGas(t.Ctx(), s.BatcherAddr, calldata, nextBlockTime)
+		require.NoError(t, err, "need to estimate submit gas")
 		txData = &types.BlobTx{
 			To:         s.rollupCfg.BatchInboxContractAddress,
 			Data:       calldata,
-			Gas:        30000, // TODO(spire): POC ONLY
+			Gas:        gas,
 			BlobHashes: blobHashes,
 			Sidecar:    sidecar,
 			ChainID:    uint256.MustFromBig(s.rollupCfg.L1ChainID),
@@ -347,27 +352,15 @@ func (s *L2Batcher) ActL2BatchSubmitRaw(t Testing, payload []byte, txOpts ...fun
 }
 
 func submitBlobTxData(t require.TestingT, targetTimestamp uint64) []byte {
-	batchInboxAbi := snapshots.LoadBatchInboxABI()
-	submitBlobMethod, ok := batchInboxAbi.Methods["submitBlob"]
-	require.True(t, ok)
-
-	inputs, err := submitBlobMethod.Inputs.Pack(new(big.Int).SetUint64(targetTimestamp))
+	data, err := derive.SubmitBlobTxData(targetTimestamp)
 	require.NoError(t, err)
-
-	submitSel := submitBlobMethod.ID
-	return append(submitSel[:], inputs...)
+	return data
 }
 
 func submitCalldataTxData(t require.TestingT, targetTimestamp uint64, payload []byte) []byte {
-	batchInboxAbi := snapshots.LoadBatchInboxABI()
-	submitCalldataMethod, ok := batchInboxAbi.Methods["submitCalldata"]
-	require.True(t, ok)
-
-	inputs, err := submitCalldataMethod.Inputs.Pack(new(big.Int).SetUint64(targetTimestamp), payload)
+	data, err := derive.SubmitCalldataTxData(targetTimestamp, payload)
 	require.NoError(t, err)
-
-	submitSel := submitCalldataMethod.ID
-	return append(submitSel[:], inputs...)
+	return data
 }
 
 func (s *L2Batcher) ActL2BatchSubmitMultiBlob(t Testing, numBlobs int) {
@@ -431,11 +424,14 @@ func (s *L2Batcher) ActL2BatchSubmitMultiBlob(t Testing, numBlobs int) {
 		blobFeeCap = uint256.NewInt(params.GWei)
 	}
 
-	calldata := submitBlobTxData(t, syncStatus.CurrentL1.Time+s.l2BatcherCfg.L1BlockTime)
+	targetTimestamp := syncStatus.CurrentL1.Time + s.l2BatcherCfg.L1BlockTime
+	calldata := submitBlobTxData(t, targetTimestamp)
+	gas, err := s.gasEstimator.EstimateSubmitGas(t.Ctx(), s.BatcherAddr, calldata, targetTimestamp)
+	require.NoError(t, err, "need to estimate submit gas")
 	txData := &types.BlobTx{
 		To:         s.rollupCfg.BatchInboxContractAddress,
 		Data:       calldata,
-		Gas:        30000, // TODO(spire): POC ONLY
+		Gas:        gas,
 		BlobHashes: blobHashes,
 		Sidecar:    sidecar,
 		ChainID:    uint256.MustFromBig(s.rollupCfg.L1ChainID),
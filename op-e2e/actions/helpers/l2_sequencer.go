@@ -6,10 +6,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"golang.org/x/time/rate"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/node/electiondb"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/async"
@@ -56,7 +58,7 @@ func NewL2Sequencer(t Testing, log log.Logger, l1 derive.L1Fetcher, blobSrc deri
 	beaconClient election.BeaconClient, altDASrc driver.AltDAIface, eng L2API, l1Client L1API, cfg *rollup.Config, seqConfDepth uint64,
 	interopBackend interop.InteropBackend) *L2Sequencer {
 
-	electionStore := election_client.NewElectionStore(log)
+	electionStore := election_client.NewElectionStore(context.Background(), log, electiondb.Disabled, nil, beaconClient)
 	electionClient := election_client.NewElectionClient(electionStore)
 	ver := NewL2Verifier(t, log, l1, blobSrc, beaconClient, altDASrc, eng, l1Client, cfg, &sync.Config{}, safedb.Disabled, interopBackend, electionStore)
 	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, eng, electionClient)
@@ -69,7 +71,7 @@ func NewL2Sequencer(t Testing, log log.Logger, l1 derive.L1Fetcher, blobSrc deri
 	conduc := &conductor.NoOpConductor{}
 	asyncGossip := async.NoOpGossiper{}
 	seq := sequencing.NewSequencer(t.Ctx(), log, cfg, attrBuilder, l1OriginSelector,
-		seqStateListener, conduc, asyncGossip, electionClient, metr)
+		seqStateListener, conduc, asyncGossip, electionClient, common.Address{}, false, metr)
 	opts := event.DefaultRegisterOpts()
 	opts.Emitter = event.EmitterOpts{
 		Limiting: true,
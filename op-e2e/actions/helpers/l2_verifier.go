@@ -15,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/node"
+	"github.com/ethereum-optimism/optimism/op-node/node/electiondb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/attributes"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
@@ -32,6 +33,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/safego"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/testutils"
 )
 
@@ -75,16 +77,20 @@ type L2Verifier struct {
 
 type L2API interface {
 	engine.Engine
-	Call(ctx context.Context, callMsg map[string]interface{}, blockNumber string) (string, error)
+	Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
 	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
+	InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error)
 	// GetProof returns a proof of the account, it may return a nil result without error if the address was not found.
 	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
 }
 
 type L1API interface {
-	Call(ctx context.Context, callMsg map[string]interface{}, blockNumber string) (string, error)
+	Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error)
+	InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
+	InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error)
 }
 
 type safeDB interface {
@@ -123,8 +129,8 @@ func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher,
 	ec := engine.NewEngineController(eng, log, metrics, cfg, syncCfg,
 		sys.Register("engine-controller", nil, opts))
 
-	elec := election.NewElection(beaconClient, eng, l1Client, log, cfg)
-	sys.Register("election", election.NewElectionDeriver(ctx, beaconClient, elec, log), opts)
+	elec := election.NewElection(beaconClient, eng, l1Client, &election.FakeOperatorResolver{}, log, election.NoopMetrics{}, cfg)
+	sys.Register("election", election.NewElectionDeriver(ctx, beaconClient, elec, l1, eng, electiondb.Disabled, log, election.NoopMetrics{}), opts)
 
 	sys.Register("election-store", electionStore, opts)
 
@@ -255,6 +261,14 @@ func (s *l2VerifierBackend) GetElectionWinners(ctx context.Context, epoch uint64
 	return []eth.ElectionWinner{}, nil
 }
 
+func (s *l2VerifierBackend) GetSequencerLiveness(ctx context.Context, operator common.Address) (*eth.SequencerLiveness, error) {
+	return &eth.SequencerLiveness{Operator: operator}, nil
+}
+
+func (s *l2VerifierBackend) GetMissedSlotProof(ctx context.Context, l1BlockNum uint64) (*eth.MissedSlotProof, error) {
+	return nil, errors.New("missed slot proofs are not supported by the L2Verifier")
+}
+
 func (s *L2Verifier) L2Finalized() eth.L2BlockRef {
 	return s.engine.Finalized()
 }
@@ -1,9 +1,12 @@
 package helpers
 
 import (
+	"context"
+
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-e2e/actions/upgrades/helpers"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/node/electiondb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/election_client"
@@ -54,7 +57,7 @@ func SetupVerifier(t Testing, sd *e2eutils.SetupData, log log.Logger,
 	jwtPath := e2eutils.WriteDefaultJWT(t)
 	engine := NewL2Engine(t, log.New("role", "verifier-engine"), sd.L2Cfg, sd.RollupCfg.Genesis.L1, jwtPath, EngineWithP2P())
 	engCl := engine.EngineClient(t, sd.RollupCfg)
-	electionStore := election_client.NewElectionStore(log)
+	electionStore := election_client.NewElectionStore(context.Background(), log, electiondb.Disabled, nil, beaconClient)
 	verifier := NewL2Verifier(t, log.New("role", "verifier"), l1F, blobSrc, beaconClient, altda.Disabled, engCl, l1Client, sd.RollupCfg, syncCfg, cfg.SafeHeadListener, cfg.InteropBackend, electionStore)
 	return engine, verifier
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
 	hostTypes "github.com/ethereum-optimism/optimism/op-program/host/types"
+	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/sources"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
 	"github.com/ethereum/go-ethereum/common"
@@ -158,16 +159,15 @@ func (env *L2FaultProofEnv) RunFaultProofProgram(t helpers.Testing, l2ClaimBlock
 
 	// Run the fault proof program from the state transition from L2 block l2ClaimBlockNum - 1 -> l2ClaimBlockNum.
 	workDir := t.TempDir()
+	fakeBeacon := fakebeacon.NewBeacon(
+		env.log,
+		env.Miner.BlobStore(),
+		env.Sd.L1Cfg.Timestamp,
+		12,
+	)
+	require.NoError(t, fakeBeacon.Start("127.0.0.1:0"))
+	defer fakeBeacon.Close()
 	if IsKonaConfigured() {
-		fakeBeacon := fakebeacon.NewBeacon(
-			env.log,
-			env.Miner.BlobStore(),
-			env.Sd.L1Cfg.Timestamp,
-			12,
-		)
-		require.NoError(t, fakeBeacon.Start("127.0.0.1:0"))
-		defer fakeBeacon.Close()
-
 		err = RunKonaNative(t, workDir, env, env.Miner.HTTPEndpoint(), fakeBeacon.BeaconAddr(), env.Engine.HTTPEndpoint(), *fixtureInputs)
 		checkResult(t, err)
 	} else {
@@ -180,6 +180,8 @@ func (env *L2FaultProofEnv) RunFaultProofProgram(t helpers.Testing, l2ClaimBlock
 			// Set up in-process L1 sources
 			l1Cl := env.Miner.L1Client(t, env.Sd.RollupCfg)
 			l1BlobFetcher := env.Miner.BlobSource()
+			l1DebugCl := sources.NewDebugClient(env.Miner.RPCClient().CallContext)
+			l1Beacon := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(fakeBeacon.BeaconAddr(), logger))
 
 			// Set up in-process L2 source
 			l2ClCfg := sources.L2ClientDefaultConfig(env.Sd.RollupCfg, true)
@@ -188,7 +190,7 @@ func (env *L2FaultProofEnv) RunFaultProofProgram(t helpers.Testing, l2ClaimBlock
 			require.NoError(t, err, "failed to create L2 client")
 			l2DebugCl := &host.L2Source{L2Client: l2Client, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
 
-			return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l2DebugCl, kv), nil
+			return prefetcher.NewPrefetcher(logger, l1Cl, l1BlobFetcher, l1DebugCl, l1Beacon, l2DebugCl, kv), nil
 		})
 		err = host.FaultProofProgram(t.Ctx(), env.log, programCfg, withInProcessPrefetcher)
 		checkResult(t, err)
@@ -240,6 +242,7 @@ func NewOpProgramCfg(
 	params ...OpProgramCfgParam,
 ) *config.Config {
 	dfault := config.NewConfig(env.Sd.RollupCfg, env.Sd.L2Cfg.Config, fi.L1Head, fi.L2Head, fi.L2OutputRoot, fi.L2Claim, fi.L2BlockNumber)
+	dfault.L1ChainConfig = env.Sd.L1Cfg.Config
 
 	if dumpFixtures {
 		dfault.DataDir = t.TempDir()
@@ -1,6 +1,7 @@
 package sync
 
 import (
+	"context"
 	"errors"
 	"math/big"
 	"math/rand"
@@ -23,6 +24,7 @@ import (
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/node/electiondb"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/election_client"
@@ -827,7 +829,7 @@ func TestELSyncTransitionsToCLSyncAfterNodeRestart(gt *testing.T) {
 
 	PrepareELSyncedNode(t, miner, sequencer, seqEng, verifier, verEng, seqEngCl, batcher, dp)
 
-	electionStore := election_client.NewElectionStore(logger)
+	electionStore := election_client.NewElectionStore(context.Background(), logger, electiondb.Disabled, nil, nil)
 	// Create a new verifier which is essentially a new op-node with the sync mode of ELSync and default geth engine kind.
 	verifier = actionsHelpers.NewL2Verifier(t, captureLog, l1Cl, miner.BlobStore(), miner.BeaconClient(), altda.Disabled, verifier.Eng, l1Cl.EthClient, sd.RollupCfg, &sync.Config{SyncMode: sync.ELSync}, actionsHelpers.DefaultVerifierCfg().SafeHeadListener, nil, electionStore)
 
@@ -872,7 +874,7 @@ func TestForcedELSyncCLAfterNodeRestart(gt *testing.T) {
 
 	PrepareELSyncedNode(t, miner, sequencer, seqEng, verifier, verEng, seqEngCl, batcher, dp)
 
-	electionStore := election_client.NewElectionStore(logger)
+	electionStore := election_client.NewElectionStore(context.Background(), logger, electiondb.Disabled, nil, nil)
 	// Create a new verifier which is essentially a new op-node with the sync mode of ELSync and erigon engine kind.
 	verifier2 := actionsHelpers.NewL2Verifier(t, captureLog, l1Cl, miner.BlobStore(), miner.BeaconClient(), altda.Disabled, verifier.Eng, l1Cl.EthClient, sd.RollupCfg, &sync.Config{SyncMode: sync.ELSync, SupportsPostFinalizationELSync: true}, actionsHelpers.DefaultVerifierCfg().SafeHeadListener, nil, electionStore)
 
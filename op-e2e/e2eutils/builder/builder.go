@@ -0,0 +1,194 @@
+package builder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/batcher"
+)
+
+var errBundlesRejected = errors.New("mock builder rejects bundles")
+
+// pollInterval is how often the mock builder checks the L1 head to forward the bundles of the next block.
+const pollInterval = 100 * time.Millisecond
+
+// MockBuilder presents a block builder in testing, that accepts eth_sendBundle requests.
+// It does not build blocks, but holds the bundles until the block before their target block is built,
+// and then forwards their transactions to the L1 node, to be included in the target block.
+// Bundles that target a block that is already built are rejected, and bundles that could not be forwarded
+// before their target block was built are dropped, as a builder would.
+type MockBuilder struct {
+	log log.Logger
+	l1  *ethclient.Client
+
+	mu      sync.Mutex
+	bundles []batcher.SendBundleArgs
+	held    []batcher.SendBundleArgs
+	reject  bool
+
+	srv      *http.Server
+	listener net.Listener
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewMockBuilder(log log.Logger, l1 *ethclient.Client) *MockBuilder {
+	return &MockBuilder{
+		log: log,
+		l1:  l1,
+	}
+}
+
+func (b *MockBuilder) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to open tcp listener for mock builder: %w", err)
+	}
+	b.listener = listener
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &builderAPI{b: b}); err != nil {
+		return fmt.Errorf("failed to register mock builder API: %w", err)
+	}
+	b.srv = &http.Server{Handler: server}
+	go func() {
+		if err := b.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			b.log.Error("mock builder server error", "err", err)
+		}
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel = cancel
+	b.wg.Add(1)
+	go b.forwardLoop(ctx)
+	return nil
+}
+
+func (b *MockBuilder) forwardLoop(ctx context.Context) {
+	defer b.wg.Done()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			head, err := b.l1.BlockNumber(ctx)
+			if err != nil {
+				b.log.Warn("Failed to fetch L1 head", "err", err)
+				continue
+			}
+			b.forwardBundles(ctx, head)
+		}
+	}
+}
+
+// forwardBundles forwards the transactions of the bundles that target the block after the head.
+func (b *MockBuilder) forwardBundles(ctx context.Context, head uint64) {
+	b.mu.Lock()
+	var next []batcher.SendBundleArgs
+	held := b.held[:0]
+	for _, bundle := range b.held {
+		switch target := uint64(bundle.BlockNumber); {
+		case target <= head:
+			b.log.Warn("Dropping bundle that missed its target block", "block", target, "head", head)
+		case target == head+1:
+			next = append(next, bundle)
+		default:
+			held = append(held, bundle)
+		}
+	}
+	b.held = held
+	b.mu.Unlock()
+
+	for _, bundle := range next {
+		for _, raw := range bundle.Txs {
+			var tx types.Transaction
+			if err := tx.UnmarshalBinary(raw); err != nil {
+				b.log.Error("Invalid bundle tx", "err", err)
+				continue
+			}
+			if err := b.l1.SendTransaction(ctx, &tx); err != nil {
+				b.log.Warn("Failed to forward bundle tx", "tx", tx.Hash(), "err", err)
+			}
+		}
+	}
+}
+
+func (b *MockBuilder) Endpoint() string {
+	return "http://" + b.listener.Addr().String()
+}
+
+// SetRejectBundles makes the builder reject all bundles, to test the fallback to the mempool.
+func (b *MockBuilder) SetRejectBundles(reject bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.reject = reject
+}
+
+// Bundles returns the bundles that the builder accepted.
+func (b *MockBuilder) Bundles() []batcher.SendBundleArgs {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]batcher.SendBundleArgs(nil), b.bundles...)
+}
+
+func (b *MockBuilder) Close() error {
+	if b.srv == nil {
+		return nil
+	}
+	b.cancel()
+	b.wg.Wait()
+	return b.srv.Close()
+}
+
+func (b *MockBuilder) sendBundle(ctx context.Context, args batcher.SendBundleArgs) (*batcher.SendBundleResult, error) {
+	b.mu.Lock()
+	reject := b.reject
+	b.mu.Unlock()
+	if reject {
+		return nil, errBundlesRejected
+	}
+
+	head, err := b.l1.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if uint64(args.BlockNumber) <= head {
+		return nil, fmt.Errorf("bundle targets block %d, but block %d is already built", args.BlockNumber, head)
+	}
+
+	var hashes []byte
+	for _, raw := range args.Txs {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(raw); err != nil {
+			return nil, fmt.Errorf("invalid bundle tx: %w", err)
+		}
+		hashes = append(hashes, tx.Hash().Bytes()...)
+	}
+	b.log.Info("Accepted bundle", "block", uint64(args.BlockNumber), "txs", len(args.Txs))
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bundles = append(b.bundles, args)
+	b.held = append(b.held, args)
+	return &batcher.SendBundleResult{BundleHash: crypto.Keccak256Hash(hashes)}, nil
+}
+
+type builderAPI struct {
+	b *MockBuilder
+}
+
+func (api *builderAPI) SendBundle(ctx context.Context, args batcher.SendBundleArgs) (*batcher.SendBundleResult, error) {
+	return api.b.sendBundle(ctx, args)
+}
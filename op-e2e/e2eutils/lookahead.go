@@ -38,7 +38,7 @@ func (l FakeLookahead) GetLookahead(ctx context.Context, epoch uint64) (eth.APIG
 	copy(pubkey[:], address[:])
 
 	for i := 0; i < int(l.slotsPerEpoch); i++ {
-		out.Data = append(out.Data, &eth.Validator{Pubkey: pubkey, Slot: eth.Uint64String(i)})
+		out.Data = append(out.Data, &eth.Validator{Pubkey: pubkey, Slot: eth.Uint64String(epoch*l.slotsPerEpoch + uint64(i))})
 	}
 
 	return out, nil
@@ -62,3 +62,7 @@ func (l FakeLookahead) GetSlotNumber(ctx context.Context, timestamp uint64) (uin
 func (l FakeLookahead) GetTimeFromSlot(ctx context.Context, slot uint64) (uint64, error) {
 	return l.genesisTimestamp + slot*l.blockTime, nil
 }
+
+func (l FakeLookahead) GetSecondsPerSlot(ctx context.Context) (uint64, error) {
+	return l.blockTime, nil
+}
@@ -47,7 +47,9 @@ type TestParams struct {
 	SequencerWindowSize uint64
 	ChannelTimeout      uint64
 	L1BlockTime         uint64
-	UseAltDA            bool
+	// L2BlockTime defaults to the L1BlockTime. A shorter L2 block time runs multiple L2 blocks per L1 slot.
+	L2BlockTime uint64
+	UseAltDA    bool
 }
 
 func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
@@ -62,6 +64,9 @@ func MakeDeployParams(t require.TestingT, tp *TestParams) *DeployParams {
 	deployConfig.ChannelTimeoutBedrock = tp.ChannelTimeout
 	deployConfig.L1BlockTime = tp.L1BlockTime
 	deployConfig.L2BlockTime = tp.L1BlockTime
+	if tp.L2BlockTime != 0 {
+		deployConfig.L2BlockTime = tp.L2BlockTime
+	}
 	deployConfig.UseAltDA = tp.UseAltDA
 	ApplyDeployConfigForks(deployConfig)
 
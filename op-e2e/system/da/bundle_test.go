@@ -0,0 +1,79 @@
+package da
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	batcherFlags "github.com/ethereum-optimism/optimism/op-batcher/flags"
+	op_e2e "github.com/ethereum-optimism/optimism/op-e2e"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/wait"
+	"github.com/ethereum-optimism/optimism/op-e2e/system/e2esys"
+)
+
+// TestBatcherBundles runs the batcher with a mock builder, and checks that the batches are sent as
+// bundles targeting the block of their slot, and that they fall back to the mempool when the builder rejects them.
+func TestBatcherBundles(t *testing.T) {
+	t.Run("calldata", func(t *testing.T) { testBatcherBundles(t, batcherFlags.CalldataType) })
+	t.Run("blobs", func(t *testing.T) { testBatcherBundles(t, batcherFlags.BlobsType) })
+}
+
+func testBatcherBundles(t *testing.T, daType batcherFlags.DataAvailabilityType) {
+	op_e2e.InitParallel(t)
+
+	cfg := e2esys.EcotoneSystemConfig(t, new(hexutil.Uint64))
+	cfg.DataAvailabilityType = daType
+	cfg.BatcherUseBuilder = true
+
+	sys, err := cfg.Start(t)
+	require.NoError(t, err, "Error starting up system")
+
+	rollupClient := sys.RollupClient("sequencer")
+	l1Client := sys.NodeClient("l1")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+	waitSafeHead := func(num uint64) {
+		require.NoError(t, wait.For(ctx, time.Second, func() (bool, error) {
+			status, err := rollupClient.SyncStatus(ctx)
+			if err != nil {
+				return false, err
+			}
+			return status.SafeL2.Number >= num, nil
+		}))
+	}
+	waitSafeHead(1)
+
+	// The BatchInbox reverts batches outside of their target block, so every included batch landed in its target.
+	bundles := sys.MockBuilder.Bundles()
+	require.NotEmpty(t, bundles, "batches are sent as bundles")
+	var landed int
+	for _, bundle := range bundles {
+		require.Len(t, bundle.Txs, 1)
+		require.Empty(t, bundle.RevertingTxHashes, "batch tx must be revert protected")
+		var tx types.Transaction
+		require.NoError(t, tx.UnmarshalBinary(bundle.Txs[0]))
+		receipt, err := l1Client.TransactionReceipt(ctx, tx.Hash())
+		if err != nil {
+			// replaced by a fee bump, or not included yet
+			continue
+		}
+		require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+		require.Equal(t, uint64(bundle.BlockNumber), receipt.BlockNumber.Uint64(), "bundle landed in its target block")
+		landed++
+	}
+	require.NotZero(t, landed, "bundles land in their target block")
+
+	// Batches still land through the mempool if the builder does not accept them
+	sys.MockBuilder.SetRejectBundles(true)
+	status, err := rollupClient.SyncStatus(ctx)
+	require.NoError(t, err)
+	accepted := len(sys.MockBuilder.Bundles())
+	waitSafeHead(status.UnsafeL2.Number)
+	require.Len(t, sys.MockBuilder.Bundles(), accepted)
+}
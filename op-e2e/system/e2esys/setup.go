@@ -47,6 +47,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-e2e/config"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/batcher"
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/builder"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/fakebeacon"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/geth"
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils/opnode"
@@ -123,6 +124,8 @@ func DefaultSystemConfig(t testing.TB) SystemConfig {
 					VerifierConfDepth:  0,
 					SequencerConfDepth: 0,
 					SequencerEnabled:   true,
+					// No operator registry is deployed, the validators of the fake lookahead resolve to the operators of their pubkeys
+					FakeOperators: true,
 				},
 				// Submitter PrivKey is set in system start for rollup nodes where sequencer = true
 				RPC: rollupNode.RPCConfig{
@@ -140,6 +143,8 @@ func DefaultSystemConfig(t testing.TB) SystemConfig {
 					VerifierConfDepth:  0,
 					SequencerConfDepth: 0,
 					SequencerEnabled:   false,
+					// No operator registry is deployed, the validators of the fake lookahead resolve to the operators of their pubkeys
+					FakeOperators: true,
 				},
 				RPC: rollupNode.RPCConfig{
 					ListenAddr:  "127.0.0.1",
@@ -301,6 +306,9 @@ type SystemConfig struct {
 
 	// SupportL1TimeTravel determines if the L1 node supports quickly skipping forward in time
 	SupportL1TimeTravel bool
+
+	// BatcherUseBuilder makes the batcher send its batch transactions as bundles to a mock builder.
+	BatcherUseBuilder bool
 }
 
 type System struct {
@@ -308,6 +316,7 @@ type System struct {
 
 	RollupConfig *rollup.Config
 
+	L1GenesisCfg *core.Genesis
 	L2GenesisCfg *core.Genesis
 
 	// Connections to running nodes
@@ -317,6 +326,7 @@ type System struct {
 	BatchSubmitter    *bss.BatcherService
 	Mocknet           mocknet.Mocknet
 	FakeAltDAServer   *altda.FakeDAServer
+	MockBuilder       *builder.MockBuilder
 
 	L1BeaconAPIAddr endpoint.RestHTTP
 
@@ -411,6 +421,11 @@ func (sys *System) Close() {
 			combinedErr = errors.Join(combinedErr, fmt.Errorf("stop BatchSubmitter: %w", err))
 		}
 	}
+	if sys.MockBuilder != nil {
+		if err := sys.MockBuilder.Close(); err != nil {
+			combinedErr = errors.Join(combinedErr, fmt.Errorf("stop MockBuilder: %w", err))
+		}
+	}
 
 	for name, node := range sys.RollupNodes {
 		if err := node.Stop(postCtx); err != nil && !errors.Is(err, rollupNode.ErrAlreadyClosed) && !errors.Is(err, postCtx.Err()) {
@@ -524,6 +539,7 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 	if err != nil {
 		return nil, err
 	}
+	sys.L1GenesisCfg = l1Genesis
 	sys.L2GenesisCfg = l2Genesis
 	for addr, amount := range cfg.Premine {
 		if existing, ok := l2Genesis.Alloc[addr]; ok {
@@ -865,6 +881,15 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 			MaxConcurrentRequests: cfg.BatcherMaxConcurrentDARequest,
 		}
 	}
+	var builderEndpoints []string
+	if cfg.BatcherUseBuilder {
+		mockBuilder := builder.NewMockBuilder(sys.Cfg.Loggers["batcher"].New("role", "builder"), sys.NodeClient(RoleL1))
+		if err := mockBuilder.Start("127.0.0.1:0"); err != nil {
+			return nil, fmt.Errorf("failed to start mock builder: %w", err)
+		}
+		sys.MockBuilder = mockBuilder
+		builderEndpoints = []string{mockBuilder.Endpoint()}
+	}
 	batcherCLIConfig := &bss.CLIConfig{
 		L1EthRpc:                 sys.EthInstances[RoleL1].UserRPC().RPC(),
 		L2EthRpc:                 sys.EthInstances[RoleSeq].UserRPC().RPC(),
@@ -888,6 +913,10 @@ func (cfg SystemConfig) Start(t *testing.T, _opts ...SystemConfigOption) (*Syste
 		DataAvailabilityType:  sys.Cfg.DataAvailabilityType,
 		CompressionAlgo:       compressionAlgo,
 		AltDA:                 batcherAltDACLIConfig,
+		BuilderEndpoints:      builderEndpoints,
+		// Batches fall back to the mempool if the mock builder rejects them
+		BuilderMempoolFallback: true,
+		SubmitGasMargin:        20,
 	}
 	// Batch Submitter
 	batcher, err := bss.BatcherServiceFromCLIConfig(context.Background(), "0.0.1", batcherCLIConfig, sys.Cfg.Loggers["batcher"])
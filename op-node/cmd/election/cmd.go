@@ -0,0 +1,209 @@
+package election
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	opnode "github.com/ethereum-optimism/optimism/op-node"
+	"github.com/ethereum-optimism/optimism/op-node/flags"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	opflags "github.com/ethereum-optimism/optimism/op-service/flags"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+)
+
+// operatorCacheSize is the number of resolved validator operators to cache, enough for the proposers of an epoch.
+const operatorCacheSize = 64
+
+var (
+	snapshotFlag = &cli.PathFlag{
+		Name:  "snapshot",
+		Usage: "Path to an election snapshot JSON file to simulate, instead of fetching the election inputs from RPCs",
+	}
+	l1RPCFlag = &cli.StringFlag{
+		Name:  "l1-rpc",
+		Usage: "RPC URL for an Ethereum L1 node, to fetch the election inputs from",
+	}
+	l2RPCFlag = &cli.StringFlag{
+		Name:  "l2-rpc",
+		Usage: "RPC URL for an L2 execution engine, to fetch the ticket accounting from",
+	}
+	l1BeaconFlag = &cli.StringFlag{
+		Name:  "l1-beacon",
+		Usage: "HTTP endpoint of an L1 beacon node, to fetch the lookahead and slot times from",
+	}
+	lookaheadFlag = &cli.PathFlag{
+		Name:  "lookahead",
+		Usage: "Path to a recorded static lookahead schedule, to use instead of the lookahead of the beacon node",
+	}
+	epochFlag = &cli.Uint64Flag{
+		Name:  "epoch",
+		Usage: "L1 epoch to fetch the election inputs of",
+	}
+	l1BlockFlag = &cli.Uint64Flag{
+		Name:  "l1-block",
+		Usage: "L1 block number to read the operators, fallback list and sequencer configs at. Defaults to the L1 head",
+	}
+	l2BlockFlag = &cli.Uint64Flag{
+		Name:  "l2-block",
+		Usage: "L2 block number to read the ticket accounting at. Defaults to the L2 head",
+	}
+	fallbackListFlag = &cli.StringSliceFlag{
+		Name: "fallback-list",
+		Usage: "Candidate fallback list to simulate, as instruction names or numbers, e.g. current-proposer,next-proposer,permissionless. " +
+			"Defaults to the fallback list of the snapshot",
+	}
+	outfileSnapshotFlag = &cli.PathFlag{
+		Name:  "outfile.snapshot",
+		Usage: "Path to write the fetched election snapshot to, to simulate it again without RPCs",
+	}
+	outfileFlag = &cli.PathFlag{
+		Name:  "outfile",
+		Usage: "Path to write the simulation result to, or - for stdout",
+		Value: "-",
+	}
+)
+
+var Subcommands = cli.Commands{
+	{
+		Name:  "simulate",
+		Usage: "Simulates the sequencer election of an epoch offline",
+		Description: "Runs the election instructions of a fallback list over a snapshot of the election inputs of an epoch: " +
+			"the lookahead, the operators of the proposers, their ticket balances and sequencer configs. " +
+			"The snapshot is either loaded from a JSON file, or fetched from L1, L2 and beacon RPCs at the given blocks. " +
+			"The result lists the winner of every slot, the number of empty slots and the tickets burned by every operator, " +
+			"to evaluate fallback list changes before submitting them on L1.",
+		Flags: []cli.Flag{
+			snapshotFlag,
+			l1RPCFlag,
+			l2RPCFlag,
+			l1BeaconFlag,
+			lookaheadFlag,
+			opflags.CLINetworkFlag(flags.EnvVarPrefix, ""),
+			opflags.CLIRollupConfigFlag(flags.EnvVarPrefix, ""),
+			epochFlag,
+			l1BlockFlag,
+			l2BlockFlag,
+			fallbackListFlag,
+			outfileSnapshotFlag,
+			outfileFlag,
+		},
+		Action: func(ctx *cli.Context) error {
+			logger := oplog.NewLogger(ctx.App.ErrWriter, oplog.DefaultCLIConfig())
+
+			var snapshot *Snapshot
+			var err error
+			if path := ctx.Path(snapshotFlag.Name); path != "" {
+				snapshot, err = LoadSnapshot(path)
+			} else {
+				snapshot, err = fetchSnapshot(ctx, logger)
+			}
+			if err != nil {
+				return err
+			}
+			if path := ctx.Path(outfileSnapshotFlag.Name); path != "" {
+				if err := jsonutil.WriteJSON(snapshot, ioutil.ToAtomicFile(path, 0o666)); err != nil {
+					return fmt.Errorf("failed to write election snapshot: %w", err)
+				}
+			}
+
+			fallbackList := snapshot.FallbackList
+			if ctx.IsSet(fallbackListFlag.Name) {
+				fallbackList, err = ParseFallbackList(ctx.StringSlice(fallbackListFlag.Name))
+				if err != nil {
+					return err
+				}
+			}
+			result, err := Simulate(ctx.Context, logger, snapshot, fallbackList)
+			if err != nil {
+				return err
+			}
+			return jsonutil.WriteJSON(result, ioutil.ToStdOutOrFileOrNoop(ctx.Path(outfileFlag.Name), 0o666))
+		},
+	},
+}
+
+func fetchSnapshot(ctx *cli.Context, logger log.Logger) (*Snapshot, error) {
+	for _, flag := range []cli.Flag{l1RPCFlag, l2RPCFlag, l1BeaconFlag, epochFlag} {
+		if !ctx.IsSet(flag.Names()[0]) {
+			return nil, fmt.Errorf("flag %s is required to fetch the election inputs, unless a snapshot is given", flag.Names()[0])
+		}
+	}
+	rollupCfg, err := opnode.NewRollupConfig(logger, ctx.String(opflags.NetworkFlagName), ctx.String(opflags.RollupConfigFlagName))
+	if err != nil {
+		return nil, err
+	}
+
+	l1RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(l1RPCFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1 RPC: %w", err)
+	}
+	defer l1RPC.Close()
+	l1, err := sources.NewL1Client(l1RPC, logger, nil, sources.L1ClientDefaultConfig(rollupCfg, true, sources.RPCKindStandard))
+	if err != nil {
+		return nil, err
+	}
+	l2RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(l2RPCFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L2 RPC: %w", err)
+	}
+	defer l2RPC.Close()
+	l2, err := sources.NewL2Client(l2RPC, logger, nil, sources.L2ClientDefaultConfig(rollupCfg, true))
+	if err != nil {
+		return nil, err
+	}
+
+	var beaconClient sources.BeaconClient = sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(ctx.String(l1BeaconFlag.Name), logger))
+	if path := ctx.Path(lookaheadFlag.Name); path != "" {
+		lookahead, err := sources.NewStaticLookaheadFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		beaconClient = sources.NewBeaconClientWithLookahead(beaconClient, lookahead)
+	}
+	beacon := sources.NewL1BeaconClient(beaconClient, sources.L1BeaconClientConfig{})
+
+	operators, err := election.NewOperatorResolver(l1, rollupCfg, operatorCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	// The contract calls of the election are traced, to record the state they read for the simulation
+	l1State := NewStateRecorder(l1, l1RPC)
+	l2State := NewStateRecorder(l2, l2RPC)
+	elec := election.NewElection(beacon, l2State, l1State, operators, logger, election.NoopMetrics{}, rollupCfg)
+
+	l1Block, err := blockID(ctx.Context, ctx, l1BlockFlag, l1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 block: %w", err)
+	}
+	l2Block, err := blockID(ctx.Context, ctx, l2BlockFlag, l2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L2 block: %w", err)
+	}
+	epoch := ctx.Uint64(epochFlag.Name)
+	logger.Info("Fetching election snapshot", "epoch", epoch, "l1", l1Block, "l2", l2Block)
+	return FetchSnapshot(ctx.Context, beacon, elec, operators, l1State, l2State, rollupCfg.L1SystemConfigAddress, epoch, l1Block, l2Block)
+}
+
+// blockID returns the block of the number of the flag, or the head block if the flag is not set.
+func blockID(ctx context.Context, cliCtx *cli.Context, flag *cli.Uint64Flag, chain election.ChainClient) (eth.BlockID, error) {
+	var info eth.BlockInfo
+	var err error
+	if cliCtx.IsSet(flag.Name) {
+		info, err = chain.InfoByNumber(ctx, cliCtx.Uint64(flag.Name))
+	} else {
+		info, err = chain.InfoByLabel(ctx, eth.Unsafe)
+	}
+	if err != nil {
+		return eth.BlockID{}, err
+	}
+	return eth.ToBlockID(info), nil
+}
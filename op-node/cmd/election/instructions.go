@@ -0,0 +1,81 @@
+package election
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+)
+
+// Instruction is an entry of the election fallback list of the system config.
+// It is encoded by name in the JSON files of the simulation, and can be parsed from its name or number.
+type Instruction uint8
+
+var instructionNames = map[Instruction]string{
+	election.NO_FALLBACK:                  "no-fallback",
+	election.CURRENT_PROPOSER:             "current-proposer",
+	election.CURRENT_PROPOSER_WITH_CONFIG: "current-proposer-with-config",
+	election.NEXT_PROPOSER:                "next-proposer",
+	election.NEXT_PROPOSER_WITH_CONFIG:    "next-proposer-with-config",
+	election.RANDOM_TICKET_HOLDER:         "random-ticket-holder",
+	election.PERMISSIONLESS:               "permissionless",
+}
+
+func (i Instruction) String() string {
+	if name, ok := instructionNames[i]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", uint8(i))
+}
+
+func (i Instruction) MarshalText() ([]byte, error) {
+	if _, ok := instructionNames[i]; !ok {
+		return nil, fmt.Errorf("unknown fallback instruction: %d", uint8(i))
+	}
+	return []byte(i.String()), nil
+}
+
+func (i *Instruction) UnmarshalText(text []byte) error {
+	for instruction, name := range instructionNames {
+		if name == string(text) {
+			*i = instruction
+			return nil
+		}
+	}
+	n, err := strconv.ParseUint(string(text), 0, 8)
+	if err != nil {
+		return fmt.Errorf("unknown fallback instruction: %q", text)
+	}
+	if _, ok := instructionNames[Instruction(n)]; !ok {
+		return fmt.Errorf("unknown fallback instruction: %d", n)
+	}
+	*i = Instruction(n)
+	return nil
+}
+
+// ParseFallbackList parses a fallback list from instruction names or numbers.
+func ParseFallbackList(values []string) ([]Instruction, error) {
+	out := make([]Instruction, len(values))
+	for i, value := range values {
+		if err := out[i].UnmarshalText([]byte(value)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func toInstructions(list []uint8) []Instruction {
+	out := make([]Instruction, len(list))
+	for i, instruction := range list {
+		out[i] = Instruction(instruction)
+	}
+	return out
+}
+
+func fromInstructions(list []Instruction) []uint8 {
+	out := make([]uint8, len(list))
+	for i, instruction := range list {
+		out[i] = uint8(instruction)
+	}
+	return out
+}
@@ -0,0 +1,226 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+var ErrUnsupportedCall = errors.New("call is not served by the election snapshot")
+
+// SlotResult is the outcome of the election of a slot.
+type SlotResult struct {
+	Slot           eth.Uint64String `json:"slot"`
+	Time           uint64           `json:"time"`
+	Operator       common.Address   `json:"operator"`
+	Winner         common.Address   `json:"winner"`
+	Permissionless bool             `json:"permissionless"`
+}
+
+// Result is the outcome of the simulated election of an epoch.
+type Result struct {
+	Epoch        uint64        `json:"epoch"`
+	FallbackList []Instruction `json:"fallback_list"`
+	Slots        []*SlotResult `json:"slots"`
+	// EmptySlots is the number of slots without a winner, that are not open to anyone either.
+	// No batch can be submitted for these slots.
+	EmptySlots uint64 `json:"empty_slots"`
+	// PermissionlessSlots is the number of slots without a winner, that anyone may submit the batch for.
+	PermissionlessSlots uint64 `json:"permissionless_slots"`
+	// TicketBurn is the number of tickets burned by each winner, one per slot won.
+	TicketBurn map[common.Address]uint64 `json:"ticket_burn"`
+}
+
+// Simulate runs the election instructions of the fallback list over the snapshot, without any RPCs.
+// The batch contracts of the instructions are executed in an in-memory EVM, against the state recorded in the snapshot.
+func Simulate(ctx context.Context, log log.Logger, snapshot *Snapshot, fallbackList []Instruction) (*Result, error) {
+	l1 := newSnapshotClient(snapshot.L1State)
+	l2 := newSnapshotClient(snapshot.L2State)
+	// The config is only read for the system config address of the sequencer config checks.
+	cfg := &rollup.Config{L1SystemConfigAddress: snapshot.SystemConfig}
+	elec := election.NewElection(nil, l2, l1, &election.FakeOperatorResolver{}, log, election.NoopMetrics{}, cfg)
+
+	winners := make([]*eth.ElectionWinner, len(snapshot.Lookahead))
+	operators := make([]common.Address, len(snapshot.Lookahead))
+	tickets := make(map[common.Address]*big.Int)
+	for i, proposer := range snapshot.Lookahead {
+		winners[i] = &eth.ElectionWinner{Time: proposer.Time}
+		operators[i] = proposer.Operator
+		// The instructions burn the tickets of the map, copy them to keep the snapshot intact
+		tickets[proposer.Operator] = new(big.Int).SetUint64(snapshot.Tickets[proposer.Operator])
+	}
+
+	winners, err := elec.HandleInstructions(ctx, fromInstructions(fallbackList), winners, operators, tickets,
+		rpcblock.ByHash(snapshot.L2Block.Hash), rpcblock.ByHash(snapshot.L1Block.Hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to run election instructions: %w", err)
+	}
+
+	result := &Result{
+		Epoch:        snapshot.Epoch,
+		FallbackList: fallbackList,
+		TicketBurn:   make(map[common.Address]uint64),
+	}
+	for i, winner := range winners {
+		proposer := snapshot.Lookahead[i]
+		result.Slots = append(result.Slots, &SlotResult{
+			Slot:           proposer.Slot,
+			Time:           winner.Time,
+			Operator:       proposer.Operator,
+			Winner:         winner.Address,
+			Permissionless: winner.Permissionless,
+		})
+		switch {
+		case winner.Address != (common.Address{}):
+			result.TicketBurn[winner.Address]++
+		case winner.Permissionless:
+			result.PermissionlessSlots++
+		default:
+			result.EmptySlots++
+		}
+	}
+	return result, nil
+}
+
+// snapshotChain is an election.StateChain that serves the block and the state of a chain recorded in the snapshot,
+// so that the batch contracts of the election instructions are executed like with eth_call.
+type snapshotChain struct {
+	state *ChainState
+	cfg   *params.ChainConfig
+	// miss is the first read of the last call that the recorded state does not cover
+	miss error
+}
+
+var _ election.StateChain = (*snapshotChain)(nil)
+
+func newSnapshotChain(state *ChainState) *snapshotChain {
+	// The batch contracts are compiled for Cancun, which all forks of the config are active at.
+	cfg := *params.AllDevChainProtocolChanges
+	return &snapshotChain{state: state, cfg: &cfg}
+}
+
+func (c *snapshotChain) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	if hash != c.state.Header.Hash() {
+		return nil, fmt.Errorf("%w: block %s", ErrUnsupportedCall, hash)
+	}
+	return eth.HeaderBlockInfo(c.state.Header), nil
+}
+
+func (c *snapshotChain) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	return nil, fmt.Errorf("%w: block %d", ErrUnsupportedCall, number)
+}
+
+func (c *snapshotChain) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
+	return nil, fmt.Errorf("%w: block %s", ErrUnsupportedCall, label)
+}
+
+// StateAt returns a new in-memory state of the recorded accounts, so that the writes of a call do not affect the next one.
+func (c *snapshotChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	statedb, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	if err != nil {
+		return nil, err
+	}
+	for addr, account := range c.state.Accounts {
+		if account.Balance != nil {
+			balance, overflow := uint256.FromBig(account.Balance.ToInt())
+			if overflow {
+				return nil, fmt.Errorf("balance of %s overflows", addr)
+			}
+			statedb.SetBalance(addr, balance, tracing.BalanceChangeUnspecified)
+		}
+		statedb.SetNonce(addr, account.Nonce)
+		statedb.SetCode(addr, account.Code)
+		for key, value := range account.Storage {
+			statedb.SetState(addr, key, value)
+		}
+	}
+	return statedb, nil
+}
+
+func (c *snapshotChain) Config() *params.ChainConfig {
+	return c.cfg
+}
+
+// VMConfig traces the calls to detect reads of state that the snapshot does not hold. These would silently
+// read zero values, e.g. when the fallback list calls a contract that the recorded calls did not.
+func (c *snapshotChain) VMConfig() vm.Config {
+	return vm.Config{Tracer: &tracing.Hooks{
+		OnEnter: func(depth int, typ byte, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+			if depth == 0 || vm.OpCode(typ) == vm.CREATE || vm.OpCode(typ) == vm.CREATE2 {
+				return
+			}
+			if _, ok := c.state.Accounts[to]; ok {
+				return
+			}
+			if _, ok := vm.PrecompiledContractsCancun[to]; ok {
+				return
+			}
+			c.recordMiss(fmt.Errorf("call to account %s", to))
+		},
+		OnOpcode: func(pc uint64, op byte, gas uint64, cost uint64, scope tracing.OpContext, rData []byte, depth int, err error) {
+			if vm.OpCode(op) != vm.SLOAD {
+				return
+			}
+			stack := scope.StackData()
+			if len(stack) == 0 {
+				return
+			}
+			// Storage of accounts without code, i.e. of the batch contract being created, is not recorded but empty.
+			account, ok := c.state.Accounts[scope.Address()]
+			if !ok || len(account.Code) == 0 {
+				return
+			}
+			key := common.Hash(stack[len(stack)-1].Bytes32())
+			if _, ok := account.Storage[key]; !ok {
+				c.recordMiss(fmt.Errorf("storage slot %s of %s", key, scope.Address()))
+			}
+		},
+	}}
+}
+
+func (c *snapshotChain) recordMiss(err error) {
+	if c.miss == nil {
+		c.miss = err
+	}
+}
+
+// snapshotClient executes the contract calls of the election against the state of a snapshotChain,
+// and fails the calls that read state that the snapshot does not hold.
+type snapshotClient struct {
+	*election.StateClient
+	chain *snapshotChain
+}
+
+func newSnapshotClient(state *ChainState) *snapshotClient {
+	chain := newSnapshotChain(state)
+	return &snapshotClient{StateClient: election.NewStateClient(chain), chain: chain}
+}
+
+func (c *snapshotClient) Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
+	c.chain.miss = nil
+	ret, err := c.StateClient.Call(ctx, callMsg, block)
+	// A read of missing state may fail the call too, report the cause
+	if c.chain.miss != nil {
+		return "", fmt.Errorf("%w: snapshot does not cover the state read by the call: %w", ErrUnsupportedCall, c.chain.miss)
+	}
+	if err != nil {
+		return "", err
+	}
+	return ret, nil
+}
@@ -0,0 +1,207 @@
+package election
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+var (
+	operatorA    = common.Address{0xaa}
+	operatorB    = common.Address{0xbb}
+	operatorC    = common.Address{0xcc}
+	holderD      = common.Address{0xdd}
+	systemConfig = common.Address{0x5c}
+	// stubCode returns the storage slot of the first argument, standing in for the view functions of the
+	// tickets and the system config: slot 0 holds the ticket count, ids hold their owner, and addresses their balance.
+	stubCode = common.FromHex("0x6004355460005260206000f3")
+)
+
+func addressKey(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+func uintValue(v uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(v))
+}
+
+func testSnapshot() *Snapshot {
+	l1Header := &types.Header{
+		Number:     big.NewInt(100),
+		Time:       3800,
+		Difficulty: new(big.Int),
+		GasLimit:   30_000_000,
+		BaseFee:    big.NewInt(1),
+		MixDigest:  common.Hash{0x01},
+		Extra:      []byte{},
+	}
+	l2Header := &types.Header{
+		Number:     big.NewInt(200),
+		Time:       3802,
+		Difficulty: new(big.Int),
+		GasLimit:   30_000_000,
+		BaseFee:    big.NewInt(1),
+		MixDigest:  common.Hash{0x02},
+		Extra:      []byte{},
+	}
+	return &Snapshot{
+		Epoch:   10,
+		L1Block: eth.BlockID{Hash: l1Header.Hash(), Number: 100},
+		L2Block: eth.BlockID{Hash: l2Header.Hash(), Number: 200},
+		Lookahead: []*SlotProposer{
+			{Slot: 320, Time: 3840, Operator: operatorA},
+			{Slot: 321, Time: 3852, Operator: operatorB},
+			{Slot: 322, Time: 3864, Operator: common.Address{}},
+			{Slot: 323, Time: 3876, Operator: operatorC},
+		},
+		Tickets: map[common.Address]uint64{
+			operatorA:        1,
+			operatorB:        0,
+			operatorC:        2,
+			common.Address{}: 0,
+		},
+		SystemConfig: systemConfig,
+		FallbackList: []Instruction{election.CURRENT_PROPOSER},
+		L1State: &ChainState{
+			Header: l1Header,
+			Accounts: map[common.Address]*Account{
+				systemConfig: {Code: stubCode, Storage: map[common.Hash]common.Hash{
+					addressKey(operatorA): uintValue(1),
+					addressKey(operatorB): uintValue(1),
+					addressKey(operatorC): uintValue(0),
+				}},
+			},
+		},
+		L2State: &ChainState{
+			Header: l2Header,
+			Accounts: map[common.Address]*Account{
+				// A single ticket, that every slot draws
+				derive.ElectionTickets: {Code: stubCode, Storage: map[common.Hash]common.Hash{
+					uintValue(0):        uintValue(1),
+					uintValue(1):        addressKey(holderD),
+					addressKey(holderD): uintValue(1),
+				}},
+			},
+		},
+	}
+}
+
+func winnersOf(result *Result) []common.Address {
+	var winners []common.Address
+	for _, slot := range result.Slots {
+		winners = append(winners, slot.Winner)
+	}
+	return winners
+}
+
+func TestSimulate(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	zero := common.Address{}
+
+	t.Run("CurrentProposer", func(t *testing.T) {
+		snapshot := testSnapshot()
+		result, err := Simulate(context.Background(), logger, snapshot, snapshot.FallbackList)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operatorA, zero, zero, operatorC}, winnersOf(result))
+		require.Equal(t, uint64(2), result.EmptySlots)
+		require.Equal(t, map[common.Address]uint64{operatorA: 1, operatorC: 1}, result.TicketBurn)
+		// The snapshot is not modified by the simulation
+		require.Equal(t, testSnapshot(), snapshot)
+	})
+
+	t.Run("NextProposerAndPermissionless", func(t *testing.T) {
+		result, err := Simulate(context.Background(), logger, testSnapshot(), []Instruction{
+			election.CURRENT_PROPOSER, election.NEXT_PROPOSER, election.PERMISSIONLESS,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operatorA, operatorC, zero, operatorC}, winnersOf(result))
+		require.True(t, result.Slots[2].Permissionless)
+		require.Equal(t, uint64(0), result.EmptySlots)
+		require.Equal(t, uint64(1), result.PermissionlessSlots)
+		require.Equal(t, map[common.Address]uint64{operatorA: 1, operatorC: 2}, result.TicketBurn)
+	})
+
+	t.Run("RandomTicketHolder", func(t *testing.T) {
+		result, err := Simulate(context.Background(), logger, testSnapshot(), []Instruction{election.RANDOM_TICKET_HOLDER})
+		require.NoError(t, err)
+		// The later draws of the single ticket of D are missed slots
+		require.Equal(t, []common.Address{holderD, zero, zero, zero}, winnersOf(result))
+		require.Equal(t, uint64(3), result.EmptySlots)
+		require.Equal(t, map[common.Address]uint64{holderD: 1}, result.TicketBurn)
+	})
+
+	t.Run("CurrentProposerThenRandomTicketHolder", func(t *testing.T) {
+		result, err := Simulate(context.Background(), logger, testSnapshot(), []Instruction{
+			election.CURRENT_PROPOSER, election.RANDOM_TICKET_HOLDER,
+		})
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operatorA, holderD, zero, operatorC}, winnersOf(result))
+		require.Equal(t, map[common.Address]uint64{operatorA: 1, holderD: 1, operatorC: 1}, result.TicketBurn)
+	})
+
+	t.Run("StateNotCovered", func(t *testing.T) {
+		snapshot := testSnapshot()
+		delete(snapshot.L2State.Accounts[derive.ElectionTickets].Storage, uintValue(1))
+		_, err := Simulate(context.Background(), logger, snapshot, []Instruction{election.RANDOM_TICKET_HOLDER})
+		require.ErrorIs(t, err, ErrUnsupportedCall)
+		require.ErrorContains(t, err, "does not cover")
+	})
+
+	t.Run("CurrentProposerWithConfig", func(t *testing.T) {
+		result, err := Simulate(context.Background(), logger, testSnapshot(), []Instruction{election.CURRENT_PROPOSER_WITH_CONFIG})
+		require.NoError(t, err)
+		// B passes the config check without tickets, C holds tickets but fails the config check
+		require.Equal(t, []common.Address{operatorA, zero, zero, zero}, winnersOf(result))
+		require.Equal(t, uint64(3), result.EmptySlots)
+	})
+
+	t.Run("NoFallback", func(t *testing.T) {
+		_, err := Simulate(context.Background(), logger, testSnapshot(), []Instruction{election.NO_FALLBACK})
+		require.Error(t, err)
+	})
+}
+
+func TestSnapshotJSON(t *testing.T) {
+	snapshot := testSnapshot()
+	snapshot.FallbackList = []Instruction{election.NEXT_PROPOSER_WITH_CONFIG, election.PERMISSIONLESS}
+	data, err := json.Marshal(snapshot)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `"fallback_list":["next-proposer-with-config","permissionless"]`)
+
+	var decoded Snapshot
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	// The decoded big integers of the headers differ in representation only, compare the encodings
+	reencoded, err := json.Marshal(&decoded)
+	require.NoError(t, err)
+	require.JSONEq(t, string(data), string(reencoded))
+	require.NoError(t, decoded.Check())
+
+	delete(decoded.Tickets, operatorB)
+	require.ErrorContains(t, decoded.Check(), "no ticket balance of operator")
+
+	snapshot.L2State.Header.Time++
+	require.ErrorContains(t, snapshot.Check(), "does not match block")
+}
+
+func TestParseFallbackList(t *testing.T) {
+	list, err := ParseFallbackList([]string{"1", "next-proposer", "0x05", "permissionless"})
+	require.NoError(t, err)
+	require.Equal(t, []Instruction{election.CURRENT_PROPOSER, election.NEXT_PROPOSER, election.RANDOM_TICKET_HOLDER, election.PERMISSIONLESS}, list)
+
+	_, err = ParseFallbackList([]string{"7"})
+	require.Error(t, err)
+	_, err = ParseFallbackList([]string{"previous-proposer"})
+	require.Error(t, err)
+}
@@ -0,0 +1,254 @@
+package election
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+// SlotProposer is the L1 proposer of a slot of the lookahead, with the sequencer operator it delegated to.
+type SlotProposer struct {
+	Slot           eth.Uint64String `json:"slot"`
+	Time           uint64           `json:"time"`
+	Pubkey         eth.Bytes48      `json:"pubkey"`
+	ValidatorIndex eth.Uint64String `json:"validator_index"`
+	Operator       common.Address   `json:"operator"`
+}
+
+// Account is the state of an account that the contract calls of the election read,
+// in the format of the prestate tracer.
+type Account struct {
+	Balance *hexutil.Big                `json:"balance,omitempty"`
+	Nonce   uint64                      `json:"nonce,omitempty"`
+	Code    hexutil.Bytes               `json:"code,omitempty"`
+	Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// ChainState is the header of a block, and the state of the accounts at the block
+// that the contract calls of the election read.
+type ChainState struct {
+	Header   *types.Header               `json:"header"`
+	Accounts map[common.Address]*Account `json:"accounts"`
+}
+
+// Snapshot holds the inputs of the election of an epoch, as read from the beacon chain, L1 and L2 at the recorded blocks.
+// It holds the state that all contract calls of the fallback instructions read, so that the election
+// can be simulated offline with any fallback list, by executing the contracts against the recorded state.
+type Snapshot struct {
+	Epoch uint64 `json:"epoch"`
+	// L1Block and L2Block are the blocks the inputs were read at, by hash
+	L1Block   eth.BlockID     `json:"l1_block"`
+	L2Block   eth.BlockID     `json:"l2_block"`
+	Lookahead []*SlotProposer `json:"lookahead"`
+	// Tickets is the ticket balance of the operators, that the instructions of the proposers account the won slots against.
+	Tickets map[common.Address]uint64 `json:"tickets"`
+	// SystemConfig is the address of the L1 system config that the sequencer config checks are made against.
+	SystemConfig common.Address `json:"system_config"`
+	// FallbackList is the fallback list of the system config.
+	FallbackList []Instruction `json:"fallback_list"`
+	// L1State and L2State are the states that the sequencer config checks and the random ticket draws read.
+	L1State *ChainState `json:"l1_state"`
+	L2State *ChainState `json:"l2_state"`
+}
+
+func (s *Snapshot) Check() error {
+	if len(s.Lookahead) == 0 {
+		return fmt.Errorf("snapshot of epoch %d has an empty lookahead", s.Epoch)
+	}
+	for _, proposer := range s.Lookahead {
+		if _, ok := s.Tickets[proposer.Operator]; !ok {
+			return fmt.Errorf("snapshot has no ticket balance of operator %s of slot %d", proposer.Operator, proposer.Slot)
+		}
+	}
+	if err := checkChainState(s.L1State, s.L1Block); err != nil {
+		return fmt.Errorf("invalid L1 state: %w", err)
+	}
+	if err := checkChainState(s.L2State, s.L2Block); err != nil {
+		return fmt.Errorf("invalid L2 state: %w", err)
+	}
+	return nil
+}
+
+func checkChainState(state *ChainState, block eth.BlockID) error {
+	if state == nil || state.Header == nil {
+		return fmt.Errorf("missing state of block %s", block)
+	}
+	if hash := state.Header.Hash(); hash != block.Hash {
+		return fmt.Errorf("header hash %s does not match block %s", hash, block)
+	}
+	return nil
+}
+
+func LoadSnapshot(path string) (*Snapshot, error) {
+	snapshot, err := jsonutil.LoadJSON[Snapshot](path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load election snapshot: %w", err)
+	}
+	if err := snapshot.Check(); err != nil {
+		return nil, fmt.Errorf("invalid election snapshot: %w", err)
+	}
+	return snapshot, nil
+}
+
+// StateRecorder is an election.ChainClient that records the state that the contract calls read,
+// by tracing every call with the prestate tracer of the RPC.
+type StateRecorder struct {
+	election.ChainClient
+	rpc      client.RPC
+	accounts map[common.Address]*Account
+}
+
+var _ election.ChainClient = (*StateRecorder)(nil)
+
+func NewStateRecorder(chain election.ChainClient, rpc client.RPC) *StateRecorder {
+	return &StateRecorder{
+		ChainClient: chain,
+		rpc:         rpc,
+		accounts:    make(map[common.Address]*Account),
+	}
+}
+
+func (r *StateRecorder) Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
+	ret, err := r.ChainClient.Call(ctx, callMsg, block)
+	if err != nil {
+		return "", err
+	}
+	var prestate map[common.Address]*Account
+	tracerCfg := map[string]interface{}{"tracer": "prestateTracer"}
+	if err := r.rpc.CallContext(ctx, &prestate, "debug_traceCall", callMsg, block.ArgValue(), tracerCfg); err != nil {
+		return "", fmt.Errorf("failed to trace the state of the call: %w", err)
+	}
+	for addr, account := range prestate {
+		recorded, ok := r.accounts[addr]
+		if !ok {
+			r.accounts[addr] = account
+			continue
+		}
+		// All calls are made at the same block, so the slots read by different calls hold the same values.
+		if recorded.Storage == nil {
+			recorded.Storage = make(map[common.Hash]common.Hash)
+		}
+		for key, value := range account.Storage {
+			recorded.Storage[key] = value
+		}
+	}
+	return ret, nil
+}
+
+// State returns the header of the block with the given hash, and the state that the calls so far have read.
+func (r *StateRecorder) State(ctx context.Context, blockHash common.Hash) (*ChainState, error) {
+	var header *types.Header
+	if err := r.rpc.CallContext(ctx, &header, "eth_getBlockByHash", blockHash, false); err != nil {
+		return nil, fmt.Errorf("failed to fetch header of block %s: %w", blockHash, err)
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %s not found", blockHash)
+	}
+	return &ChainState{Header: header, Accounts: r.accounts}, nil
+}
+
+// FetchSnapshot records the inputs of the election of an epoch, with the L1 state read at the given L1 block
+// and the ticket accounting at the given L2 block.
+// The election must make its L1 and L2 contract calls through the given state recorders,
+// which record the state for the simulation.
+// The contract calls are pinned to the blocks by hash, so that a reorg while fetching cannot mix the state of different chains.
+func FetchSnapshot(ctx context.Context, bc election.BeaconClient, elec *election.Election, operators election.OperatorResolver,
+	l1State *StateRecorder, l2State *StateRecorder, systemConfig common.Address, epoch uint64, l1Block eth.BlockID, l2Block eth.BlockID) (*Snapshot, error) {
+	l1BlockRef := rpcblock.ByHash(l1Block.Hash)
+	l2BlockRef := rpcblock.ByHash(l2Block.Hash)
+
+	resp, err := bc.GetLookahead(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lookahead of epoch %d: %w", epoch, err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("empty lookahead for epoch %d", epoch)
+	}
+	operatorAddresses, err := operators.OperatorsOf(ctx, resp.Data, l1BlockRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve validator operators: %w", err)
+	}
+	fallbackList, err := elec.GetElectionFallbackList(ctx, l1BlockRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback list: %w", err)
+	}
+
+	snapshot := &Snapshot{
+		Epoch:        epoch,
+		L1Block:      l1Block,
+		L2Block:      l2Block,
+		Tickets:      make(map[common.Address]uint64),
+		SystemConfig: systemConfig,
+		FallbackList: toInstructions(fallbackList),
+	}
+	owners := make(map[common.Address]struct{})
+	times := make([]uint64, 0, len(resp.Data))
+	for i, validator := range resp.Data {
+		time, err := bc.GetTimeFromSlot(ctx, uint64(validator.Slot))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get time of slot %d: %w", validator.Slot, err)
+		}
+		snapshot.Lookahead = append(snapshot.Lookahead, &SlotProposer{
+			Slot:           validator.Slot,
+			Time:           time,
+			Pubkey:         validator.Pubkey,
+			ValidatorIndex: validator.ValidatorIndex,
+			Operator:       operatorAddresses[i],
+		})
+		owners[operatorAddresses[i]] = struct{}{}
+		times = append(times, time)
+	}
+
+	accounts := make([]common.Address, 0, len(owners))
+	for owner := range owners {
+		accounts = append(accounts, owner)
+	}
+	slices.SortFunc(accounts, func(a, b common.Address) int {
+		return a.Cmp(b)
+	})
+	tickets, err := elec.GetBatchTicketAccounting(ctx, accounts, l2BlockRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket accounting: %w", err)
+	}
+	if len(tickets) != len(accounts) {
+		return nil, fmt.Errorf("ticket accounting returned %d balances for %d accounts", len(tickets), len(accounts))
+	}
+	for i, account := range accounts {
+		snapshot.Tickets[account] = tickets[i].Uint64()
+	}
+
+	// Draw the tickets of all slots, and check the configs of all operators, to record the state that
+	// the instructions read. The draws and checks of any subset of the slots and operators read a subset of it.
+	if _, err := elec.GetBatchRandomTicketInstruction(ctx, times, l2BlockRef); err != nil {
+		return nil, fmt.Errorf("failed to draw random tickets: %w", err)
+	}
+	var configured []common.Address
+	for _, account := range accounts {
+		if account != (common.Address{}) {
+			configured = append(configured, account)
+		}
+	}
+	if len(configured) > 0 {
+		if _, err := elec.GetBatchCheckSeqConfig(ctx, configured, l1BlockRef); err != nil {
+			return nil, fmt.Errorf("failed to check sequencer configs: %w", err)
+		}
+	}
+
+	if snapshot.L1State, err = l1State.State(ctx, l1Block.Hash); err != nil {
+		return nil, fmt.Errorf("failed to record L1 state: %w", err)
+	}
+	if snapshot.L2State, err = l2State.State(ctx, l2Block.Hash); err != nil {
+		return nil, fmt.Errorf("failed to record L2 state: %w", err)
+	}
+	return snapshot, nil
+}
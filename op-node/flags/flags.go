@@ -128,6 +128,29 @@ var (
 		EnvVars:  prefixEnvVars("L1_BEACON_FAKE_VALIDATORS"),
 		Category: SpireCategory,
 	}
+	BeaconFakeOperators = &cli.BoolFlag{
+		Name: "l1.beacon.fake-operators",
+		Usage: "If true, the operators of the lookahead validators are read from the first 20 bytes of their pubkeys, instead of the operator registry of the rollup config. " +
+			"Matches the validators of the fake lookahead. Anyone can pick such a pubkey, for use with devnets only.",
+		Required: false,
+		Value:    false,
+		EnvVars:  prefixEnvVars("L1_BEACON_FAKE_OPERATORS"),
+		Category: SpireCategory,
+	}
+	BeaconLookaheadFile = &cli.StringFlag{
+		Name:     "l1.beacon.lookahead-file",
+		Usage:    "Path to a JSON file with a static proposer schedule to serve the lookahead from, instead of the proposer duties of the beacon node. For use with devnets only.",
+		Required: false,
+		EnvVars:  prefixEnvVars("L1_BEACON_LOOKAHEAD_FILE"),
+		Category: SpireCategory,
+	}
+	BeaconLookaheadVerifyAddr = &cli.StringFlag{
+		Name:     "l1.beacon.lookahead-verify-addr",
+		Usage:    "Address of an L1 Beacon-API endpoint to cross-check the lookahead against. Elections fail when the lookaheads disagree.",
+		Required: false,
+		EnvVars:  prefixEnvVars("L1_BEACON_LOOKAHEAD_VERIFY_ADDR"),
+		Category: SpireCategory,
+	}
 	SyncModeFlag = &cli.GenericFlag{
 		Name:    "syncmode",
 		Usage:   fmt.Sprintf("Blockchain sync mode (options: %s)", openum.EnumString(sync.ModeStrings)),
@@ -246,6 +269,19 @@ var (
 		Value:    0,
 		Category: SequencerCategory,
 	}
+	SequencerElectionAddressFlag = &cli.StringFlag{
+		Name:     "sequencer.election-address",
+		Usage:    "Address the sequencer is elected with. If set, the sequencer only builds blocks in the slots won by this address, and follows the unsafe blocks of the elected sequencer in other slots.",
+		EnvVars:  prefixEnvVars("SEQUENCER_ELECTION_ADDRESS"),
+		Category: SpireCategory,
+	}
+	SequencerPermissionlessFlag = &cli.BoolFlag{
+		Name: "sequencer.permissionless",
+		Usage: "Also build blocks in the permissionless slots of the election. Blocks of these slots are not gossiped on the election topic, " +
+			"and the block of the slot whose batch is first included on L1 is canonical, so the unsafe blocks of permissionless slots may be reorged out.",
+		EnvVars:  prefixEnvVars("SEQUENCER_PERMISSIONLESS"),
+		Category: SpireCategory,
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -333,6 +369,12 @@ var (
 		EnvVars:  prefixEnvVars("SAFEDB_PATH"),
 		Category: OperationsCategory,
 	}
+	ElectionDBPath = &cli.StringFlag{
+		Name:     "electiondb.path",
+		Usage:    "File path used to persist election winners, so they survive restarts and can be checked against L1 reorgs. Disabled if not set.",
+		EnvVars:  prefixEnvVars("ELECTIONDB_PATH"),
+		Category: OperationsCategory,
+	}
 	/* Deprecated Flags */
 	L2EngineSyncEnabled = &cli.BoolFlag{
 		Name:    "l2.engine-sync",
@@ -406,6 +448,9 @@ var optionalFlags = []cli.Flag{
 	BeaconFetchAllSidecars,
 	BeaconFakeLookahead,
 	BeaconFakeValidators,
+	BeaconFakeOperators,
+	BeaconLookaheadFile,
+	BeaconLookaheadVerifyAddr,
 	SyncModeFlag,
 	RPCListenAddr,
 	RPCListenPort,
@@ -419,6 +464,8 @@ var optionalFlags = []cli.Flag{
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerElectionAddressFlag,
+	SequencerPermissionlessFlag,
 	SequencerL1Confs,
 	L1EpochPollIntervalFlag,
 	RuntimeConfigReloadIntervalFlag,
@@ -437,6 +484,7 @@ var optionalFlags = []cli.Flag{
 	ConductorRpcFlag,
 	ConductorRpcTimeoutFlag,
 	SafeDBPath,
+	ElectionDBPath,
 	L2EngineKind,
 }
 
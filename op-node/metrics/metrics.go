@@ -52,6 +52,15 @@ type Metricer interface {
 	RecordL1ReorgDepth(d uint64)
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
+	RecordSequencerHandoff(leader bool)
+	RecordSequencerSlot(submitted bool)
+	RecordElectionWinnerWait(duration time.Duration)
+	RecordElectionLookahead(duration time.Duration)
+	RecordElectionCall(call string, duration time.Duration)
+	RecordElectionInstruction(instruction string, filled int)
+	RecordElection(slots int, empty int, permissionless int)
+	RecordElectionError(stage string)
+	RecordElectionRerun()
 	RecordGossipEvent(evType int32)
 	IncPeerCount()
 	DecPeerCount()
@@ -117,6 +126,18 @@ type Metrics struct {
 
 	SequencerInconsistentL1Origin *metrics.Event
 	SequencerResets               *metrics.Event
+	SequencerHandoffs             metrics.EventVec
+	SequencerElectionLeader       prometheus.Gauge
+	SequencerSlots                metrics.EventVec
+	SequencerElectionWaitSeconds  prometheus.Histogram
+
+	ElectionLookaheadSeconds prometheus.Histogram
+	ElectionCallSeconds      *prometheus.HistogramVec
+	ElectionInstructionFills *prometheus.CounterVec
+	ElectionSlots            *prometheus.CounterVec
+	ElectionEmptySlots       prometheus.Gauge
+	ElectionErrors           metrics.EventVec
+	ElectionReruns           *metrics.Event
 
 	L1RequestDurationSeconds *prometheus.HistogramVec
 
@@ -240,6 +261,54 @@ func NewMetrics(procName string) *Metrics {
 
 		SequencerInconsistentL1Origin: metrics.NewEvent(factory, ns, "", "sequencer_inconsistent_l1_origin", "events when the sequencer selects an inconsistent L1 origin"),
 		SequencerResets:               metrics.NewEvent(factory, ns, "", "sequencer_resets", "sequencer resets"),
+		SequencerHandoffs:             metrics.NewEventVec(factory, ns, "", "sequencer_handoffs", "sequencer hand-offs between building and following", []string{"role"}),
+		SequencerSlots:                metrics.NewEventVec(factory, ns, "", "sequencer_slots", "slots of election winners, by whether the winner submitted a valid batch", []string{"result"}),
+		SequencerElectionLeader: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "sequencer_election_leader",
+			Help:      "1 if the sequencer builds blocks as the elected sequencer, 0 if it follows another winner",
+		}),
+		SequencerElectionWaitSeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "sequencer_election_wait_seconds",
+			Buckets:   []float64{.5, 1, 2, 4, 8, 12, 24, 48, 96},
+			Help:      "Histogram of the time the sequencer waited for the election winners of the next epoch",
+		}),
+
+		ElectionLookaheadSeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "election",
+			Name:      "lookahead_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of lookahead fetch time from the beacon node",
+		}),
+		ElectionCallSeconds: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Subsystem: "election",
+			Name:      "call_seconds",
+			Buckets:   []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10},
+			Help:      "Histogram of the eth_call time of the election contract reads",
+		}, []string{"call"}),
+		ElectionInstructionFills: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "election",
+			Name:      "instruction_fills_total",
+			Help:      "Number of slots filled by each instruction of the fallback list",
+		}, []string{"instruction"}),
+		ElectionSlots: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "election",
+			Name:      "slots_total",
+			Help:      "Number of elected slots, by whether they have a winner, are empty or are permissionless",
+		}, []string{"result"}),
+		ElectionEmptySlots: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: "election",
+			Name:      "empty_slots",
+			Help:      "Number of slots without a winner in the latest election",
+		}),
+		ElectionErrors: metrics.NewEventVec(factory, ns, "election", "errors", "election errors, by stage", []string{"stage"}),
+		ElectionReruns: metrics.NewEvent(factory, ns, "election", "reruns", "elections re-run because their inputs were read at reorged blocks"),
 
 		UnsafePayloadsBufferLen: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
@@ -534,6 +603,59 @@ func (m *Metrics) RecordSequencerReset() {
 	m.SequencerResets.Record()
 }
 
+// RecordSequencerHandoff records the sequencer switching to building blocks as the elected sequencer (leader),
+// or to following the unsafe blocks of another winner.
+func (m *Metrics) RecordSequencerHandoff(leader bool) {
+	if leader {
+		m.SequencerHandoffs.Record("leader")
+		m.SequencerElectionLeader.Set(1)
+	} else {
+		m.SequencerHandoffs.Record("follower")
+		m.SequencerElectionLeader.Set(0)
+	}
+}
+
+// RecordSequencerSlot records whether the election winner of an L1 slot submitted a valid batch in it.
+func (m *Metrics) RecordSequencerSlot(submitted bool) {
+	if submitted {
+		m.SequencerSlots.Record("submitted")
+	} else {
+		m.SequencerSlots.Record("missed")
+	}
+}
+
+// RecordElectionWinnerWait records how long the sequencer waited for the election winners of the next epoch.
+func (m *Metrics) RecordElectionWinnerWait(duration time.Duration) {
+	m.SequencerElectionWaitSeconds.Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordElectionLookahead(duration time.Duration) {
+	m.ElectionLookaheadSeconds.Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordElectionCall(call string, duration time.Duration) {
+	m.ElectionCallSeconds.WithLabelValues(call).Observe(duration.Seconds())
+}
+
+func (m *Metrics) RecordElectionInstruction(instruction string, filled int) {
+	m.ElectionInstructionFills.WithLabelValues(instruction).Add(float64(filled))
+}
+
+func (m *Metrics) RecordElection(slots int, empty int, permissionless int) {
+	m.ElectionSlots.WithLabelValues("won").Add(float64(slots - empty - permissionless))
+	m.ElectionSlots.WithLabelValues("empty").Add(float64(empty))
+	m.ElectionSlots.WithLabelValues("permissionless").Add(float64(permissionless))
+	m.ElectionEmptySlots.Set(float64(empty))
+}
+
+func (m *Metrics) RecordElectionError(stage string) {
+	m.ElectionErrors.Record(stage)
+}
+
+func (m *Metrics) RecordElectionRerun() {
+	m.ElectionReruns.Record()
+}
+
 func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
@@ -737,6 +859,33 @@ func (n *noopMetricer) RecordSequencerInconsistentL1Origin(from eth.BlockID, to
 func (n *noopMetricer) RecordSequencerReset() {
 }
 
+func (n *noopMetricer) RecordSequencerHandoff(leader bool) {
+}
+
+func (n *noopMetricer) RecordSequencerSlot(submitted bool) {
+}
+
+func (n *noopMetricer) RecordElectionWinnerWait(duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordElectionLookahead(duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordElectionCall(call string, duration time.Duration) {
+}
+
+func (n *noopMetricer) RecordElectionInstruction(instruction string, filled int) {
+}
+
+func (n *noopMetricer) RecordElection(slots int, empty int, permissionless int) {
+}
+
+func (n *noopMetricer) RecordElectionError(stage string) {
+}
+
+func (n *noopMetricer) RecordElectionRerun() {
+}
+
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
@@ -35,6 +35,8 @@ type driverClient interface {
 	OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayloadEnvelope) error
 	OverrideLeader(ctx context.Context) error
 	GetElectionWinners(ctx context.Context, epoch uint64) ([]eth.ElectionWinner, error)
+	GetSequencerLiveness(ctx context.Context, operator common.Address) (*eth.SequencerLiveness, error)
+	GetMissedSlotProof(ctx context.Context, l1BlockNum uint64) (*eth.MissedSlotProof, error)
 }
 
 type SafeDBReader interface {
@@ -181,3 +183,20 @@ func (n *nodeAPI) GetElectionWinners(ctx context.Context, epoch uint64) ([]eth.E
 
 	return n.dr.GetElectionWinners(ctx, epoch)
 }
+
+// GetSequencerLiveness returns the liveness record of the operator: the slots it won, and the slots it missed
+// by not submitting a valid batch in the L1 block of the slot.
+func (n *nodeAPI) GetSequencerLiveness(ctx context.Context, operator common.Address) (*eth.SequencerLiveness, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_getSequencerLiveness")
+	defer recordDur()
+
+	return n.dr.GetSequencerLiveness(ctx, operator)
+}
+
+// GetMissedSlotProof returns the evidence that the winner of the slot of the L1 block missed its slot.
+func (n *nodeAPI) GetMissedSlotProof(ctx context.Context, l1BlockNum hexutil.Uint64) (*eth.MissedSlotProof, error) {
+	recordDur := n.m.RecordRPCServerRequest("optimism_getMissedSlotProof")
+	defer recordDur()
+
+	return n.dr.GetMissedSlotProof(ctx, uint64(l1BlockNum))
+}
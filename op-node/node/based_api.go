@@ -0,0 +1,98 @@
+package node
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/metrics"
+)
+
+// BasedNamespaceRPC is the namespace of the RPC methods that expose the sequencer election state.
+const BasedNamespaceRPC = "based"
+
+// electionSubscriptionBuffer is the number of elections buffered per subscriber while notifying it.
+// The election feed drops elections for subscribers that do not keep up.
+const electionSubscriptionBuffer = 16
+
+type basedBackend interface {
+	ElectionWinnerAt(ctx context.Context, time uint64) (eth.ElectionWinner, error)
+	EpochSchedule(ctx context.Context) (*eth.EpochSchedule, error)
+	ElectionFallbackList(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error)
+	ElectionTickets(ctx context.Context) (*eth.ElectionTickets, error)
+	SubscribeElections(ch chan<- *eth.EpochElection) gethevent.Subscription
+}
+
+type basedAPI struct {
+	backend basedBackend
+	log     log.Logger
+	m       metrics.RPCMetricer
+}
+
+func NewBasedAPI(backend basedBackend, m metrics.RPCMetricer, log log.Logger) *basedAPI {
+	return &basedAPI{
+		backend: backend,
+		log:     log,
+		m:       m,
+	}
+}
+
+// GetElectionWinner returns the election winner of the slot that the given L2 timestamp falls in.
+func (b *basedAPI) GetElectionWinner(ctx context.Context, timestamp hexutil.Uint64) (eth.ElectionWinner, error) {
+	recordDur := b.m.RecordRPCServerRequest("based_getElectionWinner")
+	defer recordDur()
+	return b.backend.ElectionWinnerAt(ctx, uint64(timestamp))
+}
+
+// GetEpochSchedule returns the elections of the current and the next epoch.
+func (b *basedAPI) GetEpochSchedule(ctx context.Context) (*eth.EpochSchedule, error) {
+	recordDur := b.m.RecordRPCServerRequest("based_getEpochSchedule")
+	defer recordDur()
+	return b.backend.EpochSchedule(ctx)
+}
+
+// GetFallbackList returns the election fallback list of the system config at the given L1 block.
+func (b *basedAPI) GetFallbackList(ctx context.Context, l1BlockNum hexutil.Uint64) (*eth.ElectionFallbackList, error) {
+	recordDur := b.m.RecordRPCServerRequest("based_getFallbackList")
+	defer recordDur()
+	return b.backend.ElectionFallbackList(ctx, uint64(l1BlockNum))
+}
+
+// GetTicketCounts returns the ticket count of each operator that the last election was computed with.
+func (b *basedAPI) GetTicketCounts(ctx context.Context) (*eth.ElectionTickets, error) {
+	recordDur := b.m.RecordRPCServerRequest("based_getTicketCounts")
+	defer recordDur()
+	return b.backend.ElectionTickets(ctx)
+}
+
+// Elections streams the elections computed by the node, with based_subscribe("elections").
+// Subscriptions are only supported over websocket connections.
+func (b *basedAPI) Elections(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+
+	elections := make(chan *eth.EpochElection, electionSubscriptionBuffer)
+	feedSub := b.backend.SubscribeElections(elections)
+	go func() {
+		defer feedSub.Unsubscribe()
+		for {
+			select {
+			case election := <-elections:
+				if err := notifier.Notify(sub.ID, election); err != nil {
+					b.log.Warn("Failed to notify election subscriber", "id", sub.ID, "err", err)
+					return
+				}
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+	return sub, nil
+}
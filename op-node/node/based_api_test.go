@@ -0,0 +1,160 @@
+package node
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	rpcclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+)
+
+func startBasedRPCServer(t *testing.T, backend *mockBasedBackend) *rpcServer {
+	log := testlog.Logger(t, log.LevelError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, &mockSafeDBReader{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	server.EnableBasedAPI(NewBasedAPI(backend, metrics.NoopMetrics, log))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop(context.Background()))
+	})
+	return server
+}
+
+func TestBasedAPI(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	backend := &mockBasedBackend{}
+	server := startBasedRPCServer(t, backend)
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	rollupClient := sources.NewRollupClient(client)
+
+	winner := eth.ElectionWinner{Address: common.Address{0x1}, Time: 1236}
+	backend.On("ElectionWinnerAt", uint64(1240)).Return(winner, nil)
+	outWinner, err := rollupClient.GetElectionWinner(context.Background(), 1240)
+	require.NoError(t, err)
+	require.Equal(t, winner, outWinner)
+
+	schedule := &eth.EpochSchedule{
+		CurrentEpoch: 10,
+		Current: &eth.EpochElection{
+			Epoch:   10,
+			L1Block: eth.BlockID{Hash: common.Hash{0x10}, Number: 100},
+			L2Block: eth.BlockID{Hash: common.Hash{0x20}, Number: 200},
+			Winners: []*eth.ElectionWinner{&winner},
+			Tickets: map[common.Address]uint64{{0x1}: 3},
+		},
+	}
+	backend.On("EpochSchedule").Return(schedule, nil)
+	outSchedule, err := rollupClient.GetEpochSchedule(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, schedule, outSchedule)
+
+	list := &eth.ElectionFallbackList{
+		L1Block:      eth.BlockID{Hash: common.Hash{0xaa}, Number: 100},
+		Instructions: []hexutil.Uint64{1, 3, 6},
+	}
+	backend.On("ElectionFallbackList", uint64(100)).Return(list, nil)
+	outList, err := rollupClient.GetFallbackList(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, list, outList)
+
+	tickets := &eth.ElectionTickets{
+		Epoch:   10,
+		L2Block: eth.BlockID{Hash: common.Hash{0xbb}, Number: 200},
+		Tickets: map[common.Address]uint64{{0x1}: 3, {0x2}: 0},
+	}
+	backend.On("ElectionTickets").Return(tickets, nil)
+	outTickets, err := rollupClient.GetTicketCounts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, tickets, outTickets)
+
+	// Subscriptions are not served over HTTP
+	_, err = rollupClient.SubscribeElections(context.Background(), make(chan *eth.EpochElection))
+	require.Error(t, err)
+
+	backend.AssertExpectations(t)
+}
+
+func TestBasedAPISubscribeElections(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	backend := &mockBasedBackend{}
+	server := startBasedRPCServer(t, backend)
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "ws://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+	defer client.Close()
+	rollupClient := sources.NewRollupClient(client)
+
+	elections := make(chan *eth.EpochElection, 1)
+	sub, err := rollupClient.SubscribeElections(context.Background(), elections)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	expected := &eth.EpochElection{
+		Epoch:   11,
+		L1Block: eth.BlockID{Hash: common.Hash{0x11}, Number: 110},
+		L2Block: eth.BlockID{Hash: common.Hash{0x22}, Number: 220},
+		Winners: []*eth.ElectionWinner{{Address: common.Address{0x2}, Time: 4224}},
+		Tickets: map[common.Address]uint64{{0x2}: 1},
+	}
+	// The feed only delivers to subscribers that registered, wait for the server to subscribe
+	require.Eventually(t, func() bool {
+		return backend.feed.Send(expected) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	select {
+	case election := <-elections:
+		require.Equal(t, expected, election)
+	case err := <-sub.Err():
+		t.Fatalf("subscription failed: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for election")
+	}
+}
+
+type mockBasedBackend struct {
+	mock.Mock
+	feed gethevent.Feed
+}
+
+func (m *mockBasedBackend) ElectionWinnerAt(ctx context.Context, time uint64) (eth.ElectionWinner, error) {
+	out := m.Mock.Called(time)
+	return out.Get(0).(eth.ElectionWinner), out.Error(1)
+}
+
+func (m *mockBasedBackend) EpochSchedule(ctx context.Context) (*eth.EpochSchedule, error) {
+	out := m.Mock.Called()
+	return out.Get(0).(*eth.EpochSchedule), out.Error(1)
+}
+
+func (m *mockBasedBackend) ElectionFallbackList(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error) {
+	out := m.Mock.Called(l1BlockNum)
+	return out.Get(0).(*eth.ElectionFallbackList), out.Error(1)
+}
+
+func (m *mockBasedBackend) ElectionTickets(ctx context.Context) (*eth.ElectionTickets, error) {
+	out := m.Mock.Called()
+	return out.Get(0).(*eth.ElectionTickets), out.Error(1)
+}
+
+func (m *mockBasedBackend) SubscribeElections(ch chan<- *eth.EpochElection) gethevent.Subscription {
+	return m.feed.Subscribe(ch)
+}
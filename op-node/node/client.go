@@ -179,13 +179,15 @@ func (cfg *PreparedL1Endpoint) Check() error {
 }
 
 type L1BeaconEndpointConfig struct {
-	BeaconAddr             string   // Address of L1 User Beacon-API endpoint to use (beacon namespace required)
-	BeaconHeader           string   // Optional HTTP header for all requests to L1 Beacon
-	BeaconFallbackAddrs    []string // Addresses of L1 Beacon-API fallback endpoints (only for blob sidecars retrieval)
-	BeaconCheckIgnore      bool     // When false, halt startup if the beacon version endpoint fails
-	BeaconFetchAllSidecars bool     // Whether to fetch all blob sidecars and filter locally
-	BeaconFakeLookahead    bool     // Whether to fake the lookahead. For Spire private testnet only.
-	BeaconFakeValidators   []string // Validators for the fake lookahead. For Spire private testnet only.
+	BeaconAddr                string   // Address of L1 User Beacon-API endpoint to use (beacon namespace required)
+	BeaconHeader              string   // Optional HTTP header for all requests to L1 Beacon
+	BeaconFallbackAddrs       []string // Addresses of L1 Beacon-API fallback endpoints (only for blob sidecars retrieval)
+	BeaconCheckIgnore         bool     // When false, halt startup if the beacon version endpoint fails
+	BeaconFetchAllSidecars    bool     // Whether to fetch all blob sidecars and filter locally
+	BeaconFakeLookahead       bool     // Whether to fake the lookahead. For Spire private testnet only.
+	BeaconFakeValidators      []string // Validators for the fake lookahead. For Spire private testnet only.
+	BeaconLookaheadFile       string   // Optional static proposer schedule to serve the lookahead from. For devnets only.
+	BeaconLookaheadVerifyAddr string   // Optional Beacon-API endpoint to cross-check the lookahead against
 }
 
 var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
@@ -206,7 +208,24 @@ func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (c
 	}
 
 	httpClient := client.NewBasicHTTPClient(cfg.BeaconAddr, log, opts...)
-	return cfg.NewBeaconClient(httpClient), fb, nil
+	cl = cfg.NewBeaconClient(httpClient)
+
+	var lookahead sources.LookaheadProvider = cl
+	if cfg.BeaconLookaheadFile != "" {
+		lookahead, err = sources.NewStaticLookaheadFromFile(cfg.BeaconLookaheadFile)
+		if err != nil {
+			return nil, nil, err
+		}
+		log.Warn("Using static lookahead, for devnets only", "file", cfg.BeaconLookaheadFile)
+	}
+	if cfg.BeaconLookaheadVerifyAddr != "" {
+		reference := sources.NewBeaconHTTPClient(client.NewBasicHTTPClient(cfg.BeaconLookaheadVerifyAddr, log))
+		lookahead = sources.NewVerifyingLookahead(log, lookahead, reference)
+	}
+	if lookahead != cl {
+		cl = sources.NewBeaconClientWithLookahead(cl, lookahead)
+	}
+	return cl, fb, nil
 }
 
 func (cfg *L1BeaconEndpointConfig) NewBeaconClient(httpClient client.HTTP) sources.BeaconClient {
@@ -232,6 +251,10 @@ func (cfg *L1BeaconEndpointConfig) Check() error {
 		return errors.New("expected at least one fake validator, but got none")
 	}
 
+	if cfg.BeaconFakeLookahead && cfg.BeaconLookaheadFile != "" {
+		return errors.New("cannot use both a fake lookahead and a static lookahead file")
+	}
+
 	return nil
 }
 
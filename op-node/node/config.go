@@ -49,6 +49,9 @@ type Config struct {
 	// Path to store safe head database. Disabled when set to empty string
 	SafeDBPath string
 
+	// Path to store election winners database. Disabled when set to empty string
+	ElectionDBPath string
+
 	// RuntimeConfigReloadInterval defines the interval between runtime config reloads.
 	// Disabled if <= 0.
 	// Runtime config changes should be picked up from log-events,
@@ -0,0 +1,55 @@
+package electiondb
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+type DisabledDB struct{}
+
+var (
+	Disabled      = &DisabledDB{}
+	ErrNotEnabled = errors.New("election database not enabled")
+)
+
+func (d *DisabledDB) Enabled() bool {
+	return false
+}
+
+func (d *DisabledDB) StoreElection(_ *eth.EpochElection) error {
+	return nil
+}
+
+func (d *DisabledDB) ElectionAtEpoch(_ context.Context, _ uint64) (*eth.EpochElection, error) {
+	return nil, ErrNotEnabled
+}
+
+func (d *DisabledDB) LatestElection(_ context.Context) (*eth.EpochElection, error) {
+	return nil, ErrNotEnabled
+}
+
+func (d *DisabledDB) ElectionWinnerAt(_ context.Context, _ uint64) (eth.ElectionWinner, error) {
+	return eth.ElectionWinner{}, ErrNotEnabled
+}
+
+func (d *DisabledDB) ElectionsSinceL1(_ context.Context, _ uint64) ([]*eth.EpochElection, error) {
+	return nil, nil
+}
+
+func (d *DisabledDB) StoreSlotLiveness(_ eth.SlotLiveness) error {
+	return nil
+}
+
+func (d *DisabledDB) DeleteSlotLiveness(_ uint64, _ uint64) error {
+	return nil
+}
+
+func (d *DisabledDB) LatestSlotLiveness(_ context.Context, _ int) ([]eth.SlotLiveness, error) {
+	return nil, nil
+}
+
+func (d *DisabledDB) Close() error {
+	return nil
+}
@@ -0,0 +1,300 @@
+package electiondb
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrInvalidEntry = errors.New("invalid db entry")
+)
+
+const (
+	// Keys are prefixed with a constant byte to allow us to differentiate different "columns" within the data
+	keyPrefixElectionByEpoch byte = 0
+	keyPrefixWinnerByTime    byte = 1
+	keyPrefixSlotLiveness    byte = 2
+)
+
+var (
+	electionByEpochKey = uint64Key{prefix: keyPrefixElectionByEpoch}
+	winnerByTimeKey    = uint64Key{prefix: keyPrefixWinnerByTime}
+)
+
+// Values are prefixed with the version of their encoding.
+// Election entries hold the L1 and L2 block the election was computed at, followed by the winners of the epoch.
+// Winners are encoded as the address, the time and a flags byte.
+// Winner by time entries hold the address of the winner, the epoch of the election and a flags byte.
+const (
+	valueVersion      byte = 1
+	electionHeaderLen      = 80
+	electionWinnerLen      = 29
+	winnerByTimeLen        = 29
+)
+
+const winnerFlagPermissionless byte = 1 << 0
+
+func winnerFlags(winner *eth.ElectionWinner) byte {
+	var flags byte
+	if winner.Permissionless {
+		flags |= winnerFlagPermissionless
+	}
+	return flags
+}
+
+type uint64Key struct {
+	prefix byte
+}
+
+func (c uint64Key) Of(num uint64) []byte {
+	key := make([]byte, 0, 9)
+	key = append(key, c.prefix)
+	key = binary.BigEndian.AppendUint64(key, num)
+	return key
+}
+func (c uint64Key) Max() []byte {
+	return c.Of(math.MaxUint64)
+}
+
+func (c uint64Key) IterRange() *pebble.IterOptions {
+	return &pebble.IterOptions{
+		LowerBound: c.Of(0),
+		UpperBound: c.Max(),
+	}
+}
+
+// ElectionDB persists the election winners of each epoch, so they survive restarts of the node.
+// Each election is stored along with the L1 and L2 block it was computed at,
+// which allows elections that were computed on a reorged L1 chain to be found and re-run.
+type ElectionDB struct {
+	// m ensures all read iterators are closed before closing the database by preventing concurrent read and write
+	// operations (with close considered a write operation).
+	m   sync.RWMutex
+	log log.Logger
+	db  *pebble.DB
+
+	writeOpts *pebble.WriteOptions
+
+	closed bool
+}
+
+func electionByEpochValue(election *eth.EpochElection) []byte {
+	val := make([]byte, 0, 1+electionHeaderLen+len(election.Winners)*electionWinnerLen)
+	val = append(val, valueVersion)
+	val = append(val, election.L1Block.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, election.L1Block.Number)
+	val = append(val, election.L2Block.Hash.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, election.L2Block.Number)
+	for _, winner := range election.Winners {
+		val = append(val, winner.Address.Bytes()...)
+		val = binary.BigEndian.AppendUint64(val, winner.Time)
+		val = append(val, winnerFlags(winner))
+	}
+	return val
+}
+
+func decodeElectionByEpoch(key []byte, val []byte) (*eth.EpochElection, error) {
+	if len(key) != 9 || key[0] != keyPrefixElectionByEpoch {
+		return nil, ErrInvalidEntry
+	}
+	if len(val) < 1+electionHeaderLen || val[0] != valueVersion || (len(val)-1-electionHeaderLen)%electionWinnerLen != 0 {
+		return nil, ErrInvalidEntry
+	}
+	val = val[1:]
+	election := &eth.EpochElection{Epoch: binary.BigEndian.Uint64(key[1:])}
+	copy(election.L1Block.Hash[:], val[:32])
+	election.L1Block.Number = binary.BigEndian.Uint64(val[32:40])
+	copy(election.L2Block.Hash[:], val[40:72])
+	election.L2Block.Number = binary.BigEndian.Uint64(val[72:80])
+	for offset := electionHeaderLen; offset < len(val); offset += electionWinnerLen {
+		election.Winners = append(election.Winners, &eth.ElectionWinner{
+			Address:        common.BytesToAddress(val[offset : offset+20]),
+			Time:           binary.BigEndian.Uint64(val[offset+20 : offset+28]),
+			Permissionless: val[offset+28]&winnerFlagPermissionless != 0,
+		})
+	}
+	return election, nil
+}
+
+func winnerByTimeValue(winner *eth.ElectionWinner, epoch uint64) []byte {
+	val := make([]byte, 0, 1+winnerByTimeLen)
+	val = append(val, valueVersion)
+	val = append(val, winner.Address.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, epoch)
+	val = append(val, winnerFlags(winner))
+	return val
+}
+
+func decodeWinnerByTime(key []byte, val []byte) (winner eth.ElectionWinner, epoch uint64, err error) {
+	if len(key) != 9 || key[0] != keyPrefixWinnerByTime {
+		err = ErrInvalidEntry
+		return
+	}
+	if len(val) != 1+winnerByTimeLen || val[0] != valueVersion {
+		err = ErrInvalidEntry
+		return
+	}
+	winner.Time = binary.BigEndian.Uint64(key[1:])
+	winner.Address = common.BytesToAddress(val[1:21])
+	epoch = binary.BigEndian.Uint64(val[21:29])
+	winner.Permissionless = val[29]&winnerFlagPermissionless != 0
+	return
+}
+
+func NewElectionDB(logger log.Logger, path string) (*ElectionDB, error) {
+	db, err := pebble.Open(path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return &ElectionDB{
+		log:       logger,
+		db:        db,
+		writeOpts: &pebble.WriteOptions{Sync: true},
+	}, nil
+}
+
+func (d *ElectionDB) Enabled() bool {
+	return true
+}
+
+// StoreElection records the winners of an epoch. An election that was previously stored for the same epoch,
+// e.g. one computed at an L1 block that has since been reorged out, is replaced.
+func (d *ElectionDB) StoreElection(election *eth.EpochElection) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	d.log.Info("Record election", "epoch", election.Epoch, "l1", election.L1Block, "l2", election.L2Block, "winners", len(election.Winners))
+	batch := d.db.NewBatch()
+	defer batch.Close()
+
+	epochKey := electionByEpochKey.Of(election.Epoch)
+	prev, closer, err := d.db.Get(epochKey)
+	if err == nil {
+		prevElection, err := decodeElectionByEpoch(epochKey, prev)
+		closer.Close()
+		if err != nil {
+			return fmt.Errorf("failed to decode previous election of epoch %d: %w", election.Epoch, err)
+		}
+		for _, winner := range prevElection.Winners {
+			if err := batch.Delete(winnerByTimeKey.Of(winner.Time), d.writeOpts); err != nil {
+				return fmt.Errorf("failed to delete previous election winner: %w", err)
+			}
+		}
+	} else if !errors.Is(err, pebble.ErrNotFound) {
+		return fmt.Errorf("failed to read previous election of epoch %d: %w", election.Epoch, err)
+	}
+
+	if err := batch.Set(epochKey, electionByEpochValue(election), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record election: %w", err)
+	}
+	for _, winner := range election.Winners {
+		if err := batch.Set(winnerByTimeKey.Of(winner.Time), winnerByTimeValue(winner, election.Epoch), d.writeOpts); err != nil {
+			return fmt.Errorf("failed to record election winner: %w", err)
+		}
+	}
+	if err := batch.Commit(d.writeOpts); err != nil {
+		return fmt.Errorf("failed to commit election: %w", err)
+	}
+	return nil
+}
+
+// ElectionAtEpoch returns the stored election of the given epoch.
+func (d *ElectionDB) ElectionAtEpoch(_ context.Context, epoch uint64) (*eth.EpochElection, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	key := electionByEpochKey.Of(epoch)
+	val, closer, err := d.db.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+	return decodeElectionByEpoch(key, val)
+}
+
+// LatestElection returns the election of the most recent stored epoch.
+func (d *ElectionDB) LatestElection(ctx context.Context) (*eth.EpochElection, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, electionByEpochKey.IterRange())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	if valid := iter.Last(); !valid {
+		return nil, ErrNotFound
+	}
+	val, err := iter.ValueAndErr()
+	if err != nil {
+		return nil, err
+	}
+	return decodeElectionByEpoch(iter.Key(), val)
+}
+
+// ElectionWinnerAt returns the stored election winner of the slot with the given timestamp.
+func (d *ElectionDB) ElectionWinnerAt(_ context.Context, time uint64) (eth.ElectionWinner, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	key := winnerByTimeKey.Of(time)
+	val, closer, err := d.db.Get(key)
+	if errors.Is(err, pebble.ErrNotFound) {
+		return eth.ElectionWinner{}, ErrNotFound
+	} else if err != nil {
+		return eth.ElectionWinner{}, err
+	}
+	defer closer.Close()
+	winner, _, err := decodeWinnerByTime(key, val)
+	return winner, err
+}
+
+// ElectionsSinceL1 returns the stored elections that were computed at an L1 block with a number of at least l1BlockNum,
+// sorted by epoch. These are the elections that need to be re-checked when the L1 chain reorgs past l1BlockNum.
+func (d *ElectionDB) ElectionsSinceL1(ctx context.Context, l1BlockNum uint64) ([]*eth.EpochElection, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, electionByEpochKey.IterRange())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	// Elections are computed at increasing L1 blocks, so walk back from the latest epoch.
+	var out []*eth.EpochElection
+	for valid := iter.Last(); valid; valid = iter.Prev() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, err
+		}
+		election, err := decodeElectionByEpoch(iter.Key(), val)
+		if err != nil {
+			return nil, err
+		}
+		if election.L1Block.Number < l1BlockNum {
+			break
+		}
+		out = append(out, election)
+	}
+	slices.Reverse(out)
+	return out, nil
+}
+
+func (d *ElectionDB) Close() error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if d.closed {
+		// Already closed
+		return nil
+	}
+	d.closed = true
+	return d.db.Close()
+}
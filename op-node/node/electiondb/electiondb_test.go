@@ -0,0 +1,181 @@
+package electiondb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testElection(epoch uint64, l1Num uint64, l1Hash byte, winnerAddr byte) *eth.EpochElection {
+	startTime := 1000 + epoch*32*12
+	election := &eth.EpochElection{
+		Epoch:   epoch,
+		L1Block: eth.BlockID{Hash: common.Hash{0x01, l1Hash}, Number: l1Num},
+		L2Block: eth.BlockID{Hash: common.Hash{0x02, l1Hash}, Number: l1Num * 2},
+	}
+	for i := uint64(0); i < 3; i++ {
+		election.Winners = append(election.Winners, &eth.ElectionWinner{
+			Address: common.Address{winnerAddr, byte(i)},
+			Time:    startTime + i*12,
+		})
+	}
+	election.Winners = append(election.Winners, &eth.ElectionWinner{
+		Time:           startTime + 3*12,
+		Permissionless: true,
+	})
+	return election
+}
+
+func TestStoreElections(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	dir := t.TempDir()
+	db, err := NewElectionDB(logger, dir)
+	require.NoError(t, err)
+	defer db.Close()
+	a := testElection(10, 100, 0xaa, 0xa0)
+	b := testElection(11, 132, 0xbb, 0xb0)
+	require.NoError(t, db.StoreElection(a))
+	require.NoError(t, db.StoreElection(b))
+
+	verifyElections := func(db *ElectionDB) {
+		_, err := db.ElectionAtEpoch(context.Background(), 9)
+		require.ErrorIs(t, err, ErrNotFound)
+
+		actual, err := db.ElectionAtEpoch(context.Background(), a.Epoch)
+		require.NoError(t, err)
+		require.Equal(t, a, actual)
+
+		actual, err = db.LatestElection(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, b, actual)
+
+		winner, err := db.ElectionWinnerAt(context.Background(), b.Winners[1].Time)
+		require.NoError(t, err)
+		require.Equal(t, *b.Winners[1], winner)
+
+		_, err = db.ElectionWinnerAt(context.Background(), b.Winners[1].Time+1)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	// Verify loading the elections with the already open DB
+	verifyElections(db)
+
+	// Close the DB and open a new instance
+	require.NoError(t, db.Close())
+	newDB, err := NewElectionDB(logger, dir)
+	require.NoError(t, err)
+	defer newDB.Close()
+	// Verify the data is reloaded correctly
+	verifyElections(newDB)
+}
+
+func TestReplaceElection(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewElectionDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	orig := testElection(10, 100, 0xaa, 0xa0)
+	require.NoError(t, db.StoreElection(orig))
+
+	// Re-run of the election on a different L1 chain, with fewer winners.
+	replacement := testElection(10, 100, 0xcc, 0xc0)
+	replacement.Winners = replacement.Winners[:2]
+	require.NoError(t, db.StoreElection(replacement))
+
+	actual, err := db.ElectionAtEpoch(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, replacement, actual)
+
+	winner, err := db.ElectionWinnerAt(context.Background(), orig.Winners[0].Time)
+	require.NoError(t, err)
+	require.Equal(t, *replacement.Winners[0], winner)
+
+	// The winner of the original election that is not part of the replacement is removed.
+	_, err = db.ElectionWinnerAt(context.Background(), orig.Winners[2].Time)
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestElectionsSinceL1(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewElectionDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	elections, err := db.ElectionsSinceL1(context.Background(), 0)
+	require.NoError(t, err)
+	require.Empty(t, elections)
+
+	a := testElection(10, 100, 0xaa, 0xa0)
+	b := testElection(11, 132, 0xbb, 0xb0)
+	c := testElection(12, 164, 0xcc, 0xc0)
+	require.NoError(t, db.StoreElection(a))
+	require.NoError(t, db.StoreElection(b))
+	require.NoError(t, db.StoreElection(c))
+
+	elections, err = db.ElectionsSinceL1(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.EpochElection{a, b, c}, elections)
+
+	elections, err = db.ElectionsSinceL1(context.Background(), 101)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.EpochElection{b, c}, elections)
+
+	elections, err = db.ElectionsSinceL1(context.Background(), 164)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.EpochElection{c}, elections)
+
+	elections, err = db.ElectionsSinceL1(context.Background(), 165)
+	require.NoError(t, err)
+	require.Empty(t, elections)
+}
+
+func TestElectionAtEpoch_EmptyDatabase(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewElectionDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.ElectionAtEpoch(context.Background(), 10)
+	require.ErrorIs(t, err, ErrNotFound)
+	_, err = db.LatestElection(context.Background())
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestSlotLiveness(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	db, err := NewElectionDB(logger, t.TempDir())
+	require.NoError(t, err)
+	defer db.Close()
+
+	slots, err := db.LatestSlotLiveness(context.Background(), 10)
+	require.NoError(t, err)
+	require.Empty(t, slots)
+
+	var stored []eth.SlotLiveness
+	for i := uint64(0); i < 4; i++ {
+		slot := eth.SlotLiveness{
+			Winner:  eth.ElectionWinner{Address: common.Address{0xaa, byte(i)}, Time: 1000 + i*12},
+			L1Block: eth.BlockID{Hash: common.Hash{0x01, byte(i)}, Number: 100 + i},
+		}
+		if i%2 == 0 {
+			slot.Submitted = true
+			slot.BatchTx = common.Hash{0x02, byte(i)}
+		}
+		require.NoError(t, db.StoreSlotLiveness(slot))
+		stored = append(stored, slot)
+	}
+	slots, err = db.LatestSlotLiveness(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, stored[1:], slots)
+
+	// Records of reorged L1 blocks are replaced
+	stored[3].L1Block.Hash = common.Hash{0x03}
+	require.NoError(t, db.StoreSlotLiveness(stored[3]))
+	require.NoError(t, db.DeleteSlotLiveness(0, 101))
+	slots, err = db.LatestSlotLiveness(context.Background(), 10)
+	require.NoError(t, err)
+	require.Equal(t, stored[1:], slots)
+}
@@ -0,0 +1,95 @@
+package electiondb
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Slot liveness entries are keyed by the L1 block number of the slot, and hold the version, the L1 block hash,
+// the address, time and flags of the winner, whether the winner submitted a batch, and the batch transaction hash.
+const slotLivenessLen = 1 + 32 + 20 + 8 + 1 + 1 + 32
+
+var slotLivenessKey = uint64Key{prefix: keyPrefixSlotLiveness}
+
+func slotLivenessValue(slot eth.SlotLiveness) []byte {
+	val := make([]byte, 0, slotLivenessLen)
+	val = append(val, valueVersion)
+	val = append(val, slot.L1Block.Hash.Bytes()...)
+	val = append(val, slot.Winner.Address.Bytes()...)
+	val = binary.BigEndian.AppendUint64(val, slot.Winner.Time)
+	val = append(val, winnerFlags(&slot.Winner))
+	if slot.Submitted {
+		val = append(val, 1)
+	} else {
+		val = append(val, 0)
+	}
+	val = append(val, slot.BatchTx.Bytes()...)
+	return val
+}
+
+func decodeSlotLiveness(key []byte, val []byte) (slot eth.SlotLiveness, err error) {
+	if len(key) != 9 || key[0] != keyPrefixSlotLiveness || len(val) != slotLivenessLen || val[0] != valueVersion {
+		err = ErrInvalidEntry
+		return
+	}
+	slot.L1Block.Number = binary.BigEndian.Uint64(key[1:])
+	slot.L1Block.Hash = common.BytesToHash(val[1:33])
+	slot.Winner.Address = common.BytesToAddress(val[33:53])
+	slot.Winner.Time = binary.BigEndian.Uint64(val[53:61])
+	slot.Winner.Permissionless = val[61]&winnerFlagPermissionless != 0
+	slot.Submitted = val[62] != 0
+	slot.BatchTx = common.BytesToHash(val[63:95])
+	return
+}
+
+// StoreSlotLiveness records whether the winner of the slot of an L1 block submitted a batch in it.
+// The record of a previous L1 block at the same height, e.g. one that was reorged out, is replaced.
+func (d *ElectionDB) StoreSlotLiveness(slot eth.SlotLiveness) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if err := d.db.Set(slotLivenessKey.Of(slot.L1Block.Number), slotLivenessValue(slot), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to record slot liveness: %w", err)
+	}
+	return nil
+}
+
+// DeleteSlotLiveness deletes the liveness records of the L1 blocks from the first up to, excluding, the second number.
+func (d *ElectionDB) DeleteSlotLiveness(from uint64, to uint64) error {
+	d.m.Lock()
+	defer d.m.Unlock()
+	if err := d.db.DeleteRange(slotLivenessKey.Of(from), slotLivenessKey.Of(to), d.writeOpts); err != nil {
+		return fmt.Errorf("failed to delete slot liveness: %w", err)
+	}
+	return nil
+}
+
+// LatestSlotLiveness returns up to count of the latest liveness records, in L1 block order.
+func (d *ElectionDB) LatestSlotLiveness(ctx context.Context, count int) ([]eth.SlotLiveness, error) {
+	d.m.RLock()
+	defer d.m.RUnlock()
+	iter, err := d.db.NewIterWithContext(ctx, slotLivenessKey.IterRange())
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	var out []eth.SlotLiveness
+	for valid := iter.Last(); valid && len(out) < count; valid = iter.Prev() {
+		val, err := iter.ValueAndErr()
+		if err != nil {
+			return nil, err
+		}
+		slot, err := decodeSlotLiveness(iter.Key(), val)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, slot)
+	}
+	slices.Reverse(out)
+	return out, nil
+}
@@ -17,6 +17,7 @@ import (
 
 	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/node/electiondb"
 	"github.com/ethereum-optimism/optimism/op-node/node/safedb"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -41,6 +42,11 @@ type closableSafeDB interface {
 	io.Closer
 }
 
+type closableElectionDB interface {
+	driver.ElectionDB
+	io.Closer
+}
+
 type OpNode struct {
 	// Retain the config to test for active features rather than test for runtime state.
 	cfg        *Config
@@ -66,6 +72,8 @@ type OpNode struct {
 
 	safeDB closableSafeDB
 
+	electionDB closableElectionDB
+
 	rollupHalt string // when to halt the rollup, disabled if empty
 
 	pprofService *oppprof.Service
@@ -424,9 +432,22 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config) error {
 	} else {
 		n.safeDB = safedb.Disabled
 	}
+	if cfg.ElectionDBPath != "" {
+		n.log.Info("Election database enabled", "path", cfg.ElectionDBPath)
+		electionDB, err := electiondb.NewElectionDB(n.log, cfg.ElectionDBPath)
+		if err != nil {
+			return fmt.Errorf("failed to create election database at %v: %w", cfg.ElectionDBPath, err)
+		}
+		n.electionDB = electionDB
+	} else {
+		n.electionDB = electiondb.Disabled
+	}
 
-	n.l2Driver = driver.NewDriver(n.eventSys, n.eventDrain, &cfg.Driver, &cfg.Rollup, n.l2Source, n.l2Source.EthClient, n.l1Source, n.l1Source.EthClient,
-		n.supervisor, n.beacon, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, &cfg.Sync, sequencerConductor, altDA)
+	n.l2Driver, err = driver.NewDriver(n.eventSys, n.eventDrain, &cfg.Driver, &cfg.Rollup, n.l2Source, n.l2Source.EthClient, n.l1Source, n.l1Source.EthClient,
+		n.supervisor, n.beacon, n.beacon, n, n, n.log, n.metrics, cfg.ConfigPersistence, n.safeDB, n.electionDB, &cfg.Sync, sequencerConductor, altDA)
+	if err != nil {
+		return fmt.Errorf("failed to create driver: %w", err)
+	}
 	return nil
 }
 
@@ -438,6 +459,7 @@ func (n *OpNode) initRPCServer(cfg *Config) error {
 	if n.p2pEnabled() {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
 	}
+	server.EnableBasedAPI(NewBasedAPI(n.l2Driver, n.metrics, n.log.New("rpc", BasedNamespaceRPC)))
 	if cfg.RPC.EnableAdmin {
 		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics, n.log))
 		n.log.Info("Admin RPC enabled")
@@ -492,7 +514,7 @@ func (n *OpNode) initP2P(cfg *Config) (err error) {
 	}
 	if n.p2pEnabled() {
 		// TODO(protocol-quest#97): Use EL Sync instead of CL Alt sync for fetching missing blocks in the payload queue.
-		n.p2pNode, err = p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, n.l2Source, n.runCfg, n.metrics, false)
+		n.p2pNode, err = p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, n.l2Source, n.runCfg, n.l2Driver.ElectionClient(), n.metrics, false)
 		if err != nil {
 			return
 		}
@@ -586,12 +608,44 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, envelope *eth.ExecutionPa
 			return fmt.Errorf("node has no p2p signer, payload %s cannot be published", payload.ID())
 		}
 		n.log.Info("Publishing signed execution payload on p2p", "id", payload.ID())
-		return n.p2pNode.GossipOut().PublishL2Payload(ctx, envelope, n.p2pSigner)
+		// The blocks topics only carry blocks of the static p2p sequencer, the publisher skips them for other signers.
+		blocksErr := n.p2pNode.GossipOut().PublishL2Payload(ctx, envelope, n.p2pSigner)
+		if blocksErr != nil {
+			blocksErr = fmt.Errorf("failed to publish execution payload on blocks topic: %w", blocksErr)
+		}
+		// Peers that follow the election accept the block from whichever sequencer won the slot.
+		electionErr := n.publishElectionPayload(ctx, envelope)
+		if errors.Is(electionErr, p2p.ErrElectionGossipDisabled) {
+			electionErr = nil
+		}
+		return errors.Join(blocksErr, electionErr)
 	}
 	// if p2p is not enabled then we just don't publish the payload
 	return nil
 }
 
+// publishElectionPayload publishes the preconfirmations of the block's transactions, and then the block itself,
+// on the election topic.
+func (n *OpNode) publishElectionPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope) error {
+	gossipOut := n.p2pNode.GossipOut()
+	preconfs, err := eth.PayloadPreconfirmations(envelope.ExecutionPayload)
+	if err != nil {
+		n.log.Warn("Failed to compute preconfirmations of execution payload", "id", envelope.ExecutionPayload.ID(), "err", err)
+	}
+	for _, preconf := range preconfs {
+		if err := gossipOut.PublishPreconfirmation(ctx, preconf, n.p2pSigner); err != nil {
+			if errors.Is(err, p2p.ErrElectionGossipDisabled) {
+				return err
+			}
+			n.log.Warn("Failed to publish preconfirmation", "preconf", preconf, "err", err)
+		}
+	}
+	if err := gossipOut.PublishElectionPayload(ctx, envelope, n.p2pSigner); err != nil {
+		return fmt.Errorf("failed to publish execution payload on election topic: %w", err)
+	}
+	return nil
+}
+
 func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *eth.ExecutionPayloadEnvelope) error {
 	// ignore if it's from ourselves
 	if n.p2pEnabled() && from == n.p2pNode.Host().ID() {
@@ -614,6 +668,15 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, envelope *
 	return nil
 }
 
+func (n *OpNode) OnPreconfirmation(ctx context.Context, from peer.ID, preconf *eth.Preconfirmation) error {
+	// ignore if it's from ourselves
+	if n.p2pEnabled() && from == n.p2pNode.Host().ID() {
+		return nil
+	}
+	n.log.Info("Received preconfirmation from p2p", "tx", preconf.TxHash, "block", preconf.BlockNumber, "time", preconf.Time, "peer", from)
+	return nil
+}
+
 func (n *OpNode) RequestL2Range(ctx context.Context, start, end eth.L2BlockRef) error {
 	if n.p2pEnabled() && n.p2pNode.AltSyncEnabled() {
 		if unixTimeStale(start.Time, 12*time.Hour) {
@@ -718,6 +781,12 @@ func (n *OpNode) Stop(ctx context.Context) error {
 		}
 	}
 
+	if n.electionDB != nil {
+		if err := n.electionDB.Close(); err != nil {
+			result = multierror.Append(result, fmt.Errorf("failed to close election db: %w", err))
+		}
+	}
+
 	// Wait for the runtime config loader to be done using the data sources before closing them
 	if n.runtimeConfigReloaderDone != nil {
 		<-n.runtimeConfigReloaderDone
@@ -244,6 +244,50 @@ func TestGetElectionWinners(t *testing.T) {
 	safeReader.Mock.AssertExpectations(t)
 }
 
+func TestGetSequencerLiveness(t *testing.T) {
+	log := testlog.Logger(t, log.LevelError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	safeReader := &mockSafeDBReader{}
+	operator := common.Address{0x1}
+	missed := eth.SlotLiveness{
+		Winner:  eth.ElectionWinner{Address: operator, Time: 1236},
+		L1Block: eth.BlockID{Hash: common.Hash{0xaa}, Number: 100},
+	}
+	expected := &eth.SequencerLiveness{
+		Operator:    operator,
+		FromL1:      eth.BlockID{Hash: common.Hash{0xbb}, Number: 90},
+		ToL1:        missed.L1Block,
+		Won:         3,
+		Missed:      1,
+		MissedSlots: []eth.SlotLiveness{missed},
+	}
+	drClient.On("GetSequencerLiveness", operator).Return(expected, nil)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(rpcCfg, rollupCfg, l2Client, drClient, safeReader, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	defer func() {
+		require.NoError(t, server.Stop(context.Background()))
+	}()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	require.NoError(t, err)
+
+	var out *eth.SequencerLiveness
+	err = client.CallContext(context.Background(), &out, "optimism_getSequencerLiveness", operator)
+	require.NoError(t, err)
+	require.Equal(t, expected, out)
+	drClient.Mock.AssertExpectations(t)
+}
+
 func TestSafeHeadAtL1Block(t *testing.T) {
 	log := testlog.Logger(t, log.LevelError)
 	l2Client := &testutils.MockL2Client{}
@@ -335,6 +379,14 @@ func (c *mockDriverClient) GetElectionWinners(ctx context.Context, epoch uint64)
 	return c.Mock.MethodCalled("GetElectionWinners", epoch).Get(0).([]eth.ElectionWinner), nil
 }
 
+func (c *mockDriverClient) GetSequencerLiveness(ctx context.Context, operator common.Address) (*eth.SequencerLiveness, error) {
+	return c.Mock.MethodCalled("GetSequencerLiveness", operator).Get(0).(*eth.SequencerLiveness), nil
+}
+
+func (c *mockDriverClient) GetMissedSlotProof(ctx context.Context, l1BlockNum uint64) (*eth.MissedSlotProof, error) {
+	return c.Mock.MethodCalled("GetMissedSlotProof", l1BlockNum).Get(0).(*eth.MissedSlotProof), nil
+}
+
 type mockSafeDBReader struct {
 	mock.Mock
 }
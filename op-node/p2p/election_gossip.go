@@ -0,0 +1,264 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ElectionMessageType is the type of a message on the election topic,
+// encoded as the first byte of the signed message.
+type ElectionMessageType byte
+
+const (
+	// The block message types match the eth.BlockVersion of the SSZ encoded block.
+	ElectionMsgBlockV1 ElectionMessageType = ElectionMessageType(eth.BlockV1)
+	ElectionMsgBlockV2 ElectionMessageType = ElectionMessageType(eth.BlockV2)
+	ElectionMsgBlockV3 ElectionMessageType = ElectionMessageType(eth.BlockV3)
+
+	ElectionMsgPreconfirmation ElectionMessageType = 0x10
+)
+
+// ErrElectionGossipDisabled is returned when publishing on the election topic, while the topic is not joined.
+var ErrElectionGossipDisabled = errors.New("election gossip is not enabled")
+
+// ElectionWinners provides the elected sequencer of the slot of an L2 block timestamp.
+// Only winners that are already known are served, so peers cannot make the validator wait on an election.
+type ElectionWinners interface {
+	StoredElectionWinner(time uint64) (eth.ElectionWinner, bool)
+}
+
+func electionTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/0/election", cfg.L2ChainID.String())
+}
+
+// blockVersionAt returns the version of blocks with the given timestamp.
+func blockVersionAt(cfg *rollup.Config, timestamp uint64) eth.BlockVersion {
+	if cfg.IsEcotone(timestamp) {
+		return eth.BlockV3
+	} else if cfg.IsCanyon(timestamp) {
+		return eth.BlockV2
+	}
+	return eth.BlockV1
+}
+
+// BuildElectionValidator builds the validator of the election topic.
+// Messages on the election topic are signed by the elected sequencer of the slot they are for,
+// instead of by the static P2PSequencerAddress, so the unsafe blocks of every elected sequencer propagate.
+func BuildElectionValidator(log log.Logger, cfg *rollup.Config, winners ElectionWinners) pubsub.ValidatorEx {
+	blockHeightLRU := newBlockHeightLRU()
+
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		res := msgBufPool.Get().(*[]byte)
+		defer msgBufPool.Put(res)
+		data, result := decompressGossipMessage(log, id, message, res)
+		if result != pubsub.ValidationAccept {
+			return result
+		}
+
+		// message starts with compact-encoding secp256k1 encoded signature, followed by the message type
+		signatureBytes, msgBytes := data[:65], data[65:]
+
+		// [REJECT] if the signature is not valid
+		signer, result := recoverElectionSigner(log, cfg, id, signatureBytes, msgBytes)
+		if result != pubsub.ValidationAccept {
+			return result
+		}
+
+		msgType, body := ElectionMessageType(msgBytes[0]), msgBytes[1:]
+		switch msgType {
+		case ElectionMsgBlockV1, ElectionMsgBlockV2, ElectionMsgBlockV3:
+			blockVersion := eth.BlockVersion(msgType)
+			envelope, result := decodeBlockPayload(log, id, blockVersion, body)
+			if result != pubsub.ValidationAccept {
+				return result
+			}
+			timestamp := uint64(envelope.ExecutionPayload.Timestamp)
+
+			// [REJECT] if the block version does not match the active fork at the block timestamp
+			if expected := blockVersionAt(cfg, timestamp); expected != blockVersion {
+				log.Warn("unexpected block version", "peer", id, "version", blockVersion, "expected", expected, "timestamp", timestamp)
+				return pubsub.ValidationReject
+			}
+
+			// The signer is checked before the block is marked as seen,
+			// so blocks of other signers cannot prevent the block of the winner from propagating.
+			result = checkElectionWinner(log, winners, id, signer, timestamp)
+			if result != pubsub.ValidationAccept {
+				return result
+			}
+
+			result = checkBlockSeen(log, blockHeightLRU, envelope.ExecutionPayload)
+			if result != pubsub.ValidationAccept {
+				return result
+			}
+
+			// remember the decoded payload for later usage in topic subscriber.
+			message.ValidatorData = envelope
+			return pubsub.ValidationAccept
+		case ElectionMsgPreconfirmation:
+			var preconf eth.Preconfirmation
+			// [REJECT] if the preconfirmation encoding is not valid
+			if err := preconf.UnmarshalBinary(body); err != nil {
+				log.Warn("invalid preconfirmation", "err", err, "peer", id)
+				return pubsub.ValidationReject
+			}
+
+			// [REJECT] if the preconfirmed block is older than 60 seconds in the past
+			if now := uint64(time.Now().Unix()); preconf.Time < now-60 {
+				log.Warn("preconfirmation is too old", "timestamp", preconf.Time)
+				return pubsub.ValidationReject
+			}
+
+			// Preconfirmations are only accepted for slots with a known winner,
+			// which also bounds how far into the future they may be.
+			result = checkElectionWinner(log, winners, id, signer, preconf.Time)
+			if result != pubsub.ValidationAccept {
+				return result
+			}
+
+			message.ValidatorData = &preconf
+			return pubsub.ValidationAccept
+		default:
+			// [REJECT] if the message type is unknown
+			log.Warn("unknown election message type", "peer", id, "type", msgType)
+			return pubsub.ValidationReject
+		}
+	}
+}
+
+func recoverElectionSigner(log log.Logger, cfg *rollup.Config, id peer.ID, signatureBytes []byte, msgBytes []byte) (common.Address, pubsub.ValidationResult) {
+	signingHash, err := ElectionSigningHash(cfg, msgBytes)
+	if err != nil {
+		log.Warn("failed to compute election signing hash", "err", err, "peer", id)
+		return common.Address{}, pubsub.ValidationReject
+	}
+
+	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
+	if err != nil {
+		log.Warn("invalid election message signature", "err", err, "peer", id)
+		return common.Address{}, pubsub.ValidationReject
+	}
+	return crypto.PubkeyToAddress(*pub), pubsub.ValidationAccept
+}
+
+// checkElectionWinner checks the signer is the elected sequencer of the slot of the timestamp.
+func checkElectionWinner(log log.Logger, winners ElectionWinners, id peer.ID, signer common.Address, timestamp uint64) pubsub.ValidationResult {
+	winner, ok := winners.StoredElectionWinner(timestamp)
+	if !ok {
+		// [IGNORE] if the winner of the slot is not known (yet), other peers may be ahead of us
+		log.Warn("unknown election winner, ignoring election message", "peer", id, "addr", signer, "timestamp", timestamp)
+		return pubsub.ValidationIgnore
+	}
+	if winner.Permissionless || winner.Address == (common.Address{}) {
+		// [IGNORE] if nobody was elected for the slot
+		log.Warn("no elected sequencer, ignoring election message", "peer", id, "addr", signer, "timestamp", timestamp)
+		return pubsub.ValidationIgnore
+	}
+	if signer != winner.Address {
+		// [REJECT] if the message is not signed by the winner of the slot
+		log.Warn("unexpected election message author", "peer", id, "addr", signer, "expected", winner.Address, "timestamp", timestamp)
+		return pubsub.ValidationReject
+	}
+	return pubsub.ValidationAccept
+}
+
+// ElectionHandler dispatches the validated messages of the election topic.
+func ElectionHandler(gossipIn GossipIn) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		switch x := msg.(type) {
+		case *eth.ExecutionPayloadEnvelope:
+			return gossipIn.OnUnsafeL2Payload(ctx, from, x)
+		case *eth.Preconfirmation:
+			return gossipIn.OnPreconfirmation(ctx, from, x)
+		default:
+			return fmt.Errorf("expected topic validator to parse and validate data into election message, but got %T", msg)
+		}
+	}
+}
+
+func (p *publisher) ElectionTopicPeers() []peer.ID {
+	if p.election == nil {
+		return nil
+	}
+	return p.election.topic.ListPeers()
+}
+
+// PublishElectionPayload publishes the block on the election topic, signed as the elected sequencer.
+func (p *publisher) PublishElectionPayload(ctx context.Context, envelope *eth.ExecutionPayloadEnvelope, signer Signer) error {
+	msgType := ElectionMessageType(blockVersionAt(p.cfg, uint64(envelope.ExecutionPayload.Timestamp)))
+	return p.publishElectionMessage(ctx, msgType, signer, func(buf *bytes.Buffer) error {
+		if envelope.ParentBeaconBlockRoot != nil {
+			if _, err := envelope.MarshalSSZ(buf); err != nil {
+				return fmt.Errorf("failed to encoded execution payload envelope to publish: %w", err)
+			}
+		} else {
+			if _, err := envelope.ExecutionPayload.MarshalSSZ(buf); err != nil {
+				return fmt.Errorf("failed to encoded execution payload to publish: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// PublishPreconfirmation publishes the preconfirmation on the election topic, signed as the elected sequencer.
+func (p *publisher) PublishPreconfirmation(ctx context.Context, preconf *eth.Preconfirmation, signer Signer) error {
+	return p.publishElectionMessage(ctx, ElectionMsgPreconfirmation, signer, func(buf *bytes.Buffer) error {
+		data, err := preconf.MarshalBinary()
+		if err != nil {
+			return fmt.Errorf("failed to encode preconfirmation to publish: %w", err)
+		}
+		buf.Write(data)
+		return nil
+	})
+}
+
+func (p *publisher) publishElectionMessage(ctx context.Context, msgType ElectionMessageType, signer Signer, encode func(buf *bytes.Buffer) error) error {
+	if p.election == nil {
+		return ErrElectionGossipDisabled
+	}
+	res := msgBufPool.Get().(*[]byte)
+	buf := bytes.NewBuffer((*res)[:0])
+	defer func() {
+		*res = buf.Bytes()
+		defer msgBufPool.Put(res)
+	}()
+
+	buf.Write(make([]byte, 65))
+	buf.WriteByte(byte(msgType))
+	if err := encode(buf); err != nil {
+		return err
+	}
+
+	data := buf.Bytes()
+	msgData := data[65:]
+	sig, err := signer.Sign(ctx, SigningDomainElectionV1, p.cfg.L2ChainID, msgData)
+	if err != nil {
+		return fmt.Errorf("failed to sign election message with signer: %w", err)
+	}
+	copy(data[:65], sig[:])
+
+	// compress the full message
+	// This also copies the data, freeing up the original buffer to go back into the pool
+	out := snappy.Encode(nil, data)
+	return p.election.topic.Publish(ctx, out)
+}
+
+func joinElectionTopic(ctx context.Context, self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, winners ElectionWinners, gossipIn GossipIn) (*blockTopic, error) {
+	electionLogger := log.New("topic", "election")
+	validator := guardGossipValidator(log, logValidationResult(self, "validated election message", electionLogger, BuildElectionValidator(electionLogger, cfg, winners)))
+	return newGossipTopic(ctx, electionTopicV1(cfg), ps, electionLogger, ElectionHandler(gossipIn), validator)
+}
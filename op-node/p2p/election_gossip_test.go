@@ -0,0 +1,154 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	pubsub_pb "github.com/libp2p/go-libp2p-pubsub/pb"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type winnersFn func(time uint64) eth.ElectionWinner
+
+func (fn winnersFn) StoredElectionWinner(time uint64) (eth.ElectionWinner, bool) {
+	winner := fn(time)
+	return winner, winner != (eth.ElectionWinner{})
+}
+
+func createSignedElectionMessage(t *testing.T, msgType ElectionMessageType, body []byte, signer Signer, l2ChainID *big.Int) *pubsub.Message {
+	var buf bytes.Buffer
+	buf.Write(make([]byte, 65))
+	buf.WriteByte(byte(msgType))
+	buf.Write(body)
+	data := buf.Bytes()
+	sig, err := signer.Sign(context.Background(), SigningDomainElectionV1, l2ChainID, data[65:])
+	require.NoError(t, err)
+	copy(data[:65], sig[:])
+	return &pubsub.Message{Message: &pubsub_pb.Message{Data: snappy.Encode(nil, data)}}
+}
+
+func encodeBlockV2(t *testing.T) []byte {
+	payload := createExecutionPayload(types.Withdrawals{}, nil, nil)
+	payload.BlockHash, _ = (&eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}).CheckBlockHash()
+	var buf bytes.Buffer
+	_, err := payload.MarshalSSZ(&buf)
+	require.NoError(t, err)
+	return buf.Bytes()
+}
+
+func TestElectionValidator(t *testing.T) {
+	canyonTime := uint64(0)
+	cfg := &rollup.Config{
+		L2ChainID:  big.NewInt(100),
+		CanyonTime: &canyonTime,
+	}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	winnerSigner := &PreparedSigner{Signer: NewLocalSigner(secrets.SequencerP2P)}
+	otherSigner := &PreparedSigner{Signer: NewLocalSigner(secrets.Alice)}
+	winnerAddr := crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)
+	peerID := peer.ID("foo")
+	logger := testlog.Logger(t, log.LevelCrit)
+
+	elected := winnersFn(func(time uint64) eth.ElectionWinner {
+		return eth.ElectionWinner{Address: winnerAddr, Time: time}
+	})
+
+	t.Run("WinnerBlock", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, elected)
+		msg := createSignedElectionMessage(t, ElectionMsgBlockV2, encodeBlockV2(t), winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationAccept, validator(context.Background(), peerID, msg))
+		require.IsType(t, &eth.ExecutionPayloadEnvelope{}, msg.ValidatorData)
+	})
+
+	t.Run("OtherSignerBlock", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, elected)
+		block := encodeBlockV2(t)
+		msg := createSignedElectionMessage(t, ElectionMsgBlockV2, block, otherSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationReject, validator(context.Background(), peerID, msg))
+		// The rejected copy does not mark the block as seen
+		msg = createSignedElectionMessage(t, ElectionMsgBlockV2, block, winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationAccept, validator(context.Background(), peerID, msg))
+	})
+
+	t.Run("UnknownWinner", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, winnersFn(func(time uint64) eth.ElectionWinner {
+			return eth.ElectionWinner{}
+		}))
+		msg := createSignedElectionMessage(t, ElectionMsgBlockV2, encodeBlockV2(t), winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationIgnore, validator(context.Background(), peerID, msg))
+	})
+
+	t.Run("PermissionlessSlot", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, winnersFn(func(time uint64) eth.ElectionWinner {
+			return eth.ElectionWinner{Time: time, Permissionless: true}
+		}))
+		msg := createSignedElectionMessage(t, ElectionMsgBlockV2, encodeBlockV2(t), winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationIgnore, validator(context.Background(), peerID, msg))
+	})
+
+	t.Run("WrongBlockVersion", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, elected)
+		payload := &eth.ExecutionPayload{Timestamp: eth.Uint64Quantity(time.Now().Unix())}
+		payload.BlockHash, _ = (&eth.ExecutionPayloadEnvelope{ExecutionPayload: payload}).CheckBlockHash()
+		var buf bytes.Buffer
+		_, err := payload.MarshalSSZ(&buf)
+		require.NoError(t, err)
+		msg := createSignedElectionMessage(t, ElectionMsgBlockV1, buf.Bytes(), winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationReject, validator(context.Background(), peerID, msg))
+	})
+
+	t.Run("Preconfirmation", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, elected)
+		preconf := &eth.Preconfirmation{Time: uint64(time.Now().Unix()) + 4, BlockNumber: 10, TxHash: common.Hash{0x01}}
+		body, err := preconf.MarshalBinary()
+		require.NoError(t, err)
+
+		msg := createSignedElectionMessage(t, ElectionMsgPreconfirmation, body, winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationAccept, validator(context.Background(), peerID, msg))
+		require.Equal(t, preconf, msg.ValidatorData)
+
+		msg = createSignedElectionMessage(t, ElectionMsgPreconfirmation, body, otherSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationReject, validator(context.Background(), peerID, msg))
+
+		msg = createSignedElectionMessage(t, ElectionMsgPreconfirmation, body[:40], winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationReject, validator(context.Background(), peerID, msg))
+	})
+
+	t.Run("UnknownMessageType", func(t *testing.T) {
+		validator := BuildElectionValidator(logger, cfg, elected)
+		msg := createSignedElectionMessage(t, ElectionMessageType(0xff), make([]byte, 48), winnerSigner, cfg.L2ChainID)
+		require.Equal(t, pubsub.ValidationReject, validator(context.Background(), peerID, msg))
+	})
+
+	t.Run("BlockSignatureNotValidForElection", func(t *testing.T) {
+		// Signatures of the legacy blocks topic are not valid on the election topic
+		validator := BuildElectionValidator(logger, cfg, elected)
+		var buf bytes.Buffer
+		buf.Write(make([]byte, 65))
+		buf.WriteByte(byte(ElectionMsgBlockV2))
+		buf.Write(encodeBlockV2(t))
+		data := buf.Bytes()
+		sig, err := winnerSigner.Sign(context.Background(), SigningDomainBlocksV1, cfg.L2ChainID, data[65:])
+		require.NoError(t, err)
+		copy(data[:65], sig[:])
+		msg := &pubsub.Message{Message: &pubsub_pb.Message{Data: snappy.Encode(nil, data)}}
+		require.Equal(t, pubsub.ValidationReject, validator(context.Background(), peerID, msg))
+	})
+}
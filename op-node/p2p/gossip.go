@@ -81,7 +81,7 @@ func blocksTopicV3(cfg *rollup.Config) string {
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), blocksTopicV2(cfg), blocksTopicV3(cfg), electionTopicV1(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -250,181 +250,219 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
-func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, blockVersion eth.BlockVersion) pubsub.ValidatorEx {
-
+func newBlockHeightLRU() *lru.Cache[uint64, *seenBlocks] {
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
 	blockHeightLRU, err := lru.New[uint64, *seenBlocks](1000)
 	if err != nil {
 		panic(fmt.Errorf("failed to set up block height LRU cache: %w", err))
 	}
+	return blockHeightLRU
+}
 
-	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
-		// [REJECT] if the compression is not valid
-		outLen, err := snappy.DecodedLen(message.Data)
-		if err != nil {
-			log.Warn("invalid snappy compression length data", "err", err, "peer", id)
-			return pubsub.ValidationReject
-		}
-		if outLen > maxGossipSize {
-			log.Warn("possible snappy zip bomb, decoded length is too large", "decoded_length", outLen, "peer", id)
-			return pubsub.ValidationReject
-		}
-		if outLen < minGossipSize {
-			log.Warn("rejecting undersized gossip payload")
-			return pubsub.ValidationReject
-		}
+func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, blockVersion eth.BlockVersion) pubsub.ValidatorEx {
+	blockHeightLRU := newBlockHeightLRU()
 
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
 		res := msgBufPool.Get().(*[]byte)
 		defer msgBufPool.Put(res)
-		data, err := snappy.Decode((*res)[:cap(*res)], message.Data)
-		if err != nil {
-			log.Warn("invalid snappy compression", "err", err, "peer", id)
-			return pubsub.ValidationReject
-		}
-		// if we ended up growing the slice capacity, fine, keep the larger one.
-		if cap(data) > cap(*res) {
-			*res = data[:cap(data)]
+		data, result := decompressGossipMessage(log, id, message, res)
+		if result != pubsub.ValidationAccept {
+			return result
 		}
 
 		// message starts with compact-encoding secp256k1 encoded signature
 		signatureBytes, payloadBytes := data[:65], data[65:]
 
 		// [REJECT] if the signature by the sequencer is not valid
-		result := verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes)
+		result = verifyBlockSignature(log, cfg, runCfg, id, signatureBytes, payloadBytes)
 		if result != pubsub.ValidationAccept {
 			return result
 		}
 
-		var envelope eth.ExecutionPayloadEnvelope
+		envelope, result := decodeBlockPayload(log, id, blockVersion, payloadBytes)
+		if result != pubsub.ValidationAccept {
+			return result
+		}
 
-		// [REJECT] if the block encoding is not valid
-		if blockVersion == eth.BlockV3 {
-			if err := envelope.UnmarshalSSZ(uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
-				log.Warn("invalid envelope payload", "err", err, "peer", id)
-				return pubsub.ValidationReject
-			}
-		} else {
-			var payload eth.ExecutionPayload
-			if err := payload.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
-				log.Warn("invalid execution payload", "err", err, "peer", id)
-				return pubsub.ValidationReject
-			}
-			envelope = eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}
+		result = checkBlockSeen(log, blockHeightLRU, envelope.ExecutionPayload)
+		if result != pubsub.ValidationAccept {
+			return result
 		}
 
-		payload := envelope.ExecutionPayload
+		// remember the decoded payload for later usage in topic subscriber.
+		message.ValidatorData = envelope
+		return pubsub.ValidationAccept
+	}
+}
 
-		// rounding down to seconds is fine here.
-		now := uint64(time.Now().Unix())
+// decompressGossipMessage decompresses the snappy encoded data of a gossip message into the pooled buffer res.
+func decompressGossipMessage(log log.Logger, id peer.ID, message *pubsub.Message, res *[]byte) ([]byte, pubsub.ValidationResult) {
+	// [REJECT] if the compression is not valid
+	outLen, err := snappy.DecodedLen(message.Data)
+	if err != nil {
+		log.Warn("invalid snappy compression length data", "err", err, "peer", id)
+		return nil, pubsub.ValidationReject
+	}
+	if outLen > maxGossipSize {
+		log.Warn("possible snappy zip bomb, decoded length is too large", "decoded_length", outLen, "peer", id)
+		return nil, pubsub.ValidationReject
+	}
+	if outLen < minGossipSize {
+		log.Warn("rejecting undersized gossip payload")
+		return nil, pubsub.ValidationReject
+	}
 
-		// [REJECT] if the `payload.timestamp` is older than 60 seconds in the past
-		if uint64(payload.Timestamp) < now-60 {
-			log.Warn("payload is too old", "timestamp", uint64(payload.Timestamp))
-			return pubsub.ValidationReject
-		}
+	data, err := snappy.Decode((*res)[:cap(*res)], message.Data)
+	if err != nil {
+		log.Warn("invalid snappy compression", "err", err, "peer", id)
+		return nil, pubsub.ValidationReject
+	}
+	// if we ended up growing the slice capacity, fine, keep the larger one.
+	if cap(data) > cap(*res) {
+		*res = data[:cap(data)]
+	}
+	return data, pubsub.ValidationAccept
+}
 
-		// [REJECT] if the `payload.timestamp` is more than 5 seconds into the future
-		if uint64(payload.Timestamp) > now+5 {
-			log.Warn("payload is too new", "timestamp", uint64(payload.Timestamp))
-			return pubsub.ValidationReject
-		}
+// decodeBlockPayload decodes a gossiped block of the given version, and checks the block is valid for the version.
+func decodeBlockPayload(log log.Logger, id peer.ID, blockVersion eth.BlockVersion, payloadBytes []byte) (*eth.ExecutionPayloadEnvelope, pubsub.ValidationResult) {
+	var envelope eth.ExecutionPayloadEnvelope
 
-		// [REJECT] if the `block_hash` in the `payload` is not valid
-		if actual, ok := envelope.CheckBlockHash(); !ok {
-			log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
-			return pubsub.ValidationReject
+	// [REJECT] if the block encoding is not valid
+	if blockVersion == eth.BlockV3 {
+		if err := envelope.UnmarshalSSZ(uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+			log.Warn("invalid envelope payload", "err", err, "peer", id)
+			return nil, pubsub.ValidationReject
 		}
-
-		// [REJECT] if a V1 Block has withdrawals
-		if !blockVersion.HasWithdrawals() && payload.Withdrawals != nil {
-			log.Warn("payload is on v1 topic, but has withdrawals", "bad_hash", payload.BlockHash.String())
-			return pubsub.ValidationReject
+	} else {
+		var payload eth.ExecutionPayload
+		if err := payload.UnmarshalSSZ(blockVersion, uint32(len(payloadBytes)), bytes.NewReader(payloadBytes)); err != nil {
+			log.Warn("invalid execution payload", "err", err, "peer", id)
+			return nil, pubsub.ValidationReject
 		}
+		envelope = eth.ExecutionPayloadEnvelope{ExecutionPayload: &payload}
+	}
 
-		// [REJECT] if a >= V2 Block does not have withdrawals
-		if blockVersion.HasWithdrawals() && payload.Withdrawals == nil {
-			log.Warn("payload is on v2/v3 topic, but does not have withdrawals", "bad_hash", payload.BlockHash.String())
-			return pubsub.ValidationReject
-		}
+	payload := envelope.ExecutionPayload
 
-		// [REJECT] if a >= V2 Block has non-empty withdrawals
-		if blockVersion.HasWithdrawals() && len(*payload.Withdrawals) != 0 {
-			log.Warn("payload is on v2/v3 topic, but has non-empty withdrawals", "bad_hash", payload.BlockHash.String(), "withdrawal_count", len(*payload.Withdrawals))
-			return pubsub.ValidationReject
-		}
+	// rounding down to seconds is fine here.
+	now := uint64(time.Now().Unix())
 
-		// [REJECT] if the block is on a topic <= V2 and has a blob gas value set
-		if !blockVersion.HasBlobProperties() && payload.BlobGasUsed != nil {
-			log.Warn("payload is on v1/v2 topic, but has blob gas used", "bad_hash", payload.BlockHash.String())
-			return pubsub.ValidationReject
-		}
+	// [REJECT] if the `payload.timestamp` is older than 60 seconds in the past
+	if uint64(payload.Timestamp) < now-60 {
+		log.Warn("payload is too old", "timestamp", uint64(payload.Timestamp))
+		return nil, pubsub.ValidationReject
+	}
 
-		// [REJECT] if the block is on a topic <= V2 and has an excess blob gas value set
-		if !blockVersion.HasBlobProperties() && payload.ExcessBlobGas != nil {
-			log.Warn("payload is on v1/v2 topic, but has excess blob gas", "bad_hash", payload.BlockHash.String())
-			return pubsub.ValidationReject
-		}
+	// [REJECT] if the `payload.timestamp` is more than 5 seconds into the future
+	if uint64(payload.Timestamp) > now+5 {
+		log.Warn("payload is too new", "timestamp", uint64(payload.Timestamp))
+		return nil, pubsub.ValidationReject
+	}
 
-		if blockVersion.HasBlobProperties() {
-			// [REJECT] if the block is on a topic >= V3 and has a blob gas used value that is not zero
-			if payload.BlobGasUsed == nil || *payload.BlobGasUsed != 0 {
-				log.Warn("payload is on v3 topic, but has non-zero blob gas used", "bad_hash", payload.BlockHash.String(), "blob_gas_used", payload.BlobGasUsed)
-				return pubsub.ValidationReject
-			}
+	// [REJECT] if the `block_hash` in the `payload` is not valid
+	if actual, ok := envelope.CheckBlockHash(); !ok {
+		log.Warn("payload has bad block hash", "bad_hash", payload.BlockHash.String(), "actual", actual.String())
+		return nil, pubsub.ValidationReject
+	}
 
-			// [REJECT] if the block is on a topic >= V3 and has an excess blob gas value that is not zero
-			if payload.ExcessBlobGas == nil || *payload.ExcessBlobGas != 0 {
-				log.Warn("payload is on v3 topic, but has non-zero excess blob gas", "bad_hash", payload.BlockHash.String(), "excess_blob_gas", payload.ExcessBlobGas)
-				return pubsub.ValidationReject
-			}
-		}
+	// [REJECT] if a V1 Block has withdrawals
+	if !blockVersion.HasWithdrawals() && payload.Withdrawals != nil {
+		log.Warn("payload is on v1 topic, but has withdrawals", "bad_hash", payload.BlockHash.String())
+		return nil, pubsub.ValidationReject
+	}
 
-		// [REJECT] if the block is on a topic >= V3 and the parent beacon block root is nil
-		if blockVersion.HasParentBeaconBlockRoot() && envelope.ParentBeaconBlockRoot == nil {
-			log.Warn("payload is on v3 topic, but has nil parent beacon block root", "bad_hash", payload.BlockHash.String())
-			return pubsub.ValidationReject
-		}
+	// [REJECT] if a >= V2 Block does not have withdrawals
+	if blockVersion.HasWithdrawals() && payload.Withdrawals == nil {
+		log.Warn("payload is on v2/v3 topic, but does not have withdrawals", "bad_hash", payload.BlockHash.String())
+		return nil, pubsub.ValidationReject
+	}
 
-		seen, ok := blockHeightLRU.Get(uint64(payload.BlockNumber))
-		if !ok {
-			seen = new(seenBlocks)
-			blockHeightLRU.Add(uint64(payload.BlockNumber), seen)
+	// [REJECT] if a >= V2 Block has non-empty withdrawals
+	if blockVersion.HasWithdrawals() && len(*payload.Withdrawals) != 0 {
+		log.Warn("payload is on v2/v3 topic, but has non-empty withdrawals", "bad_hash", payload.BlockHash.String(), "withdrawal_count", len(*payload.Withdrawals))
+		return nil, pubsub.ValidationReject
+	}
+
+	// [REJECT] if the block is on a topic <= V2 and has a blob gas value set
+	if !blockVersion.HasBlobProperties() && payload.BlobGasUsed != nil {
+		log.Warn("payload is on v1/v2 topic, but has blob gas used", "bad_hash", payload.BlockHash.String())
+		return nil, pubsub.ValidationReject
+	}
+
+	// [REJECT] if the block is on a topic <= V2 and has an excess blob gas value set
+	if !blockVersion.HasBlobProperties() && payload.ExcessBlobGas != nil {
+		log.Warn("payload is on v1/v2 topic, but has excess blob gas", "bad_hash", payload.BlockHash.String())
+		return nil, pubsub.ValidationReject
+	}
+
+	if blockVersion.HasBlobProperties() {
+		// [REJECT] if the block is on a topic >= V3 and has a blob gas used value that is not zero
+		if payload.BlobGasUsed == nil || *payload.BlobGasUsed != 0 {
+			log.Warn("payload is on v3 topic, but has non-zero blob gas used", "bad_hash", payload.BlockHash.String(), "blob_gas_used", payload.BlobGasUsed)
+			return nil, pubsub.ValidationReject
 		}
 
-		if count, hasSeen := seen.hasSeen(payload.BlockHash); count > 5 {
-			// [REJECT] if more than 5 blocks have been seen with the same block height
-			log.Warn("seen too many different blocks at same height", "height", payload.BlockNumber)
-			return pubsub.ValidationReject
-		} else if hasSeen {
-			// [IGNORE] if the block has already been seen
-			log.Warn("validated already seen message again")
-			return pubsub.ValidationIgnore
+		// [REJECT] if the block is on a topic >= V3 and has an excess blob gas value that is not zero
+		if payload.ExcessBlobGas == nil || *payload.ExcessBlobGas != 0 {
+			log.Warn("payload is on v3 topic, but has non-zero excess blob gas", "bad_hash", payload.BlockHash.String(), "excess_blob_gas", payload.ExcessBlobGas)
+			return nil, pubsub.ValidationReject
 		}
+	}
 
-		// mark it as seen. (note: with concurrent validation more than 5 blocks may be marked as seen still,
-		// but validator concurrency is limited anyway)
-		seen.markSeen(payload.BlockHash)
+	// [REJECT] if the block is on a topic >= V3 and the parent beacon block root is nil
+	if blockVersion.HasParentBeaconBlockRoot() && envelope.ParentBeaconBlockRoot == nil {
+		log.Warn("payload is on v3 topic, but has nil parent beacon block root", "bad_hash", payload.BlockHash.String())
+		return nil, pubsub.ValidationReject
+	}
 
-		// remember the decoded payload for later usage in topic subscriber.
-		message.ValidatorData = &envelope
-		return pubsub.ValidationAccept
+	return &envelope, pubsub.ValidationAccept
+}
+
+// checkBlockSeen marks the block as seen, and checks it is not a duplicate or one of too many blocks at the same height.
+func checkBlockSeen(log log.Logger, blockHeightLRU *lru.Cache[uint64, *seenBlocks], payload *eth.ExecutionPayload) pubsub.ValidationResult {
+	seen, ok := blockHeightLRU.Get(uint64(payload.BlockNumber))
+	if !ok {
+		seen = new(seenBlocks)
+		blockHeightLRU.Add(uint64(payload.BlockNumber), seen)
 	}
+
+	if count, hasSeen := seen.hasSeen(payload.BlockHash); count > 5 {
+		// [REJECT] if more than 5 blocks have been seen with the same block height
+		log.Warn("seen too many different blocks at same height", "height", payload.BlockNumber)
+		return pubsub.ValidationReject
+	} else if hasSeen {
+		// [IGNORE] if the block has already been seen
+		log.Warn("validated already seen message again")
+		return pubsub.ValidationIgnore
+	}
+
+	// mark it as seen. (note: with concurrent validation more than 5 blocks may be marked as seen still,
+	// but validator concurrency is limited anyway)
+	seen.markSeen(payload.BlockHash)
+	return pubsub.ValidationAccept
 }
 
-func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte) pubsub.ValidationResult {
+// blockSigner recovers the address that signed the block on the blocks topics.
+func blockSigner(cfg *rollup.Config, signatureBytes []byte, payloadBytes []byte) (common.Address, error) {
 	signingHash, err := BlockSigningHash(cfg, payloadBytes)
 	if err != nil {
-		log.Warn("failed to compute block signing hash", "err", err, "peer", id)
-		return pubsub.ValidationReject
+		return common.Address{}, fmt.Errorf("failed to compute block signing hash: %w", err)
 	}
-
 	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
 	if err != nil {
-		log.Warn("invalid block signature", "err", err, "peer", id)
+		return common.Address{}, fmt.Errorf("invalid block signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, id peer.ID, signatureBytes []byte, payloadBytes []byte) pubsub.ValidationResult {
+	addr, err := blockSigner(cfg, signatureBytes, payloadBytes)
+	if err != nil {
+		log.Warn("failed to recover block signer", "err", err, "peer", id)
 		return pubsub.ValidationReject
 	}
-	addr := crypto.PubkeyToAddress(*pub)
 
 	// In the future we may load & validate block metadata before checking the signature.
 	// And then check the signer based on the metadata, to support e.g. multiple p2p signers at the same time.
@@ -443,6 +481,7 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayloadEnvelope) error
+	OnPreconfirmation(ctx context.Context, from peer.ID, msg *eth.Preconfirmation) error
 }
 
 type GossipTopicInfo interface {
@@ -450,11 +489,16 @@ type GossipTopicInfo interface {
 	BlocksTopicV1Peers() []peer.ID
 	BlocksTopicV2Peers() []peer.ID
 	BlocksTopicV3Peers() []peer.ID
+	ElectionTopicPeers() []peer.ID
 }
 
 type GossipOut interface {
 	GossipTopicInfo
 	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayloadEnvelope, signer Signer) error
+	// PublishElectionPayload publishes the block on the election topic, for the elected sequencer of its slot.
+	PublishElectionPayload(ctx context.Context, msg *eth.ExecutionPayloadEnvelope, signer Signer) error
+	// PublishPreconfirmation publishes a preconfirmation on the election topic, for the elected sequencer of its slot.
+	PublishPreconfirmation(ctx context.Context, msg *eth.Preconfirmation, signer Signer) error
 	Close() error
 }
 
@@ -485,6 +529,8 @@ type publisher struct {
 	blocksV1 *blockTopic
 	blocksV2 *blockTopic
 	blocksV3 *blockTopic
+	// election topic, nil if there is no election to validate the messages against.
+	election *blockTopic
 
 	runCfg GossipRuntimeConfig
 }
@@ -548,6 +594,15 @@ func (p *publisher) PublishL2Payload(ctx context.Context, envelope *eth.Executio
 	if err != nil {
 		return fmt.Errorf("failed to sign execution payload with signer: %w", err)
 	}
+	// Peers only accept blocks of the static p2p sequencer on the blocks topics, and pubsub validates
+	// our own messages too. Other elected sequencers only publish on the election topic.
+	if addr, err := blockSigner(p.cfg, sig[:], payloadData); err != nil {
+		return err
+	} else if addr != p.runCfg.P2PSequencerAddress() {
+		p.log.Debug("Not publishing block on blocks topic, signer is not the p2p sequencer",
+			"id", envelope.ExecutionPayload.ID(), "signer", addr)
+		return nil
+	}
 	copy(data[:65], sig[:])
 
 	// compress the full message
@@ -567,10 +622,16 @@ func (p *publisher) Close() error {
 	p.p2pCancel()
 	e1 := p.blocksV1.Close()
 	e2 := p.blocksV2.Close()
-	return errors.Join(e1, e2)
+	e3 := p.blocksV3.Close()
+	var e4 error
+	if p.election != nil {
+		e4 = p.election.Close()
+	}
+	return errors.Join(e1, e2, e3, e4)
 }
 
-func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
+// JoinGossip joins the gossip topics. The election topic is only joined if winners is not nil.
+func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, winners ElectionWinners, gossipIn GossipIn) (GossipOut, error) {
 	p2pCtx, p2pCancel := context.WithCancel(context.Background())
 
 	v1Logger := log.New("topic", "blocksV1")
@@ -597,6 +658,15 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 		return nil, fmt.Errorf("failed to setup blocks v3 p2p: %w", err)
 	}
 
+	var election *blockTopic
+	if winners != nil {
+		election, err = joinElectionTopic(p2pCtx, self, ps, log, cfg, winners, gossipIn)
+		if err != nil {
+			p2pCancel()
+			return nil, fmt.Errorf("failed to setup election p2p: %w", err)
+		}
+	}
+
 	return &publisher{
 		log:       log,
 		cfg:       cfg,
@@ -604,11 +674,16 @@ func JoinGossip(self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Con
 		blocksV1:  blocksV1,
 		blocksV2:  blocksV2,
 		blocksV3:  blocksV3,
+		election:  election,
 		runCfg:    runCfg,
 	}, nil
 }
 
 func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, gossipIn GossipIn, validator pubsub.ValidatorEx) (*blockTopic, error) {
+	return newGossipTopic(ctx, topicId, ps, log, BlocksHandler(gossipIn.OnUnsafeL2Payload), validator)
+}
+
+func newGossipTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log log.Logger, handler MessageHandler, validator pubsub.ValidatorEx) (*blockTopic, error) {
 	err := ps.RegisterTopicValidator(topicId,
 		validator,
 		pubsub.WithValidatorTimeout(3*time.Second),
@@ -636,7 +711,7 @@ func newBlockTopic(ctx context.Context, topicId string, ps *pubsub.PubSub, log l
 		return nil, fmt.Errorf("failed to subscribe to blocks gossip topic: %w", err)
 	}
 
-	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
+	subscriber := MakeSubscriber(log, handler)
 	go subscriber(ctx, subscription)
 
 	return &blockTopic{
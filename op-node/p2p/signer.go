@@ -15,6 +15,9 @@ import (
 
 var SigningDomainBlocksV1 = [32]byte{}
 
+// SigningDomainElectionV1 is the domain of messages signed by the elected sequencer of a slot.
+var SigningDomainElectionV1 = [32]byte{31: 1}
+
 type Signer interface {
 	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
 	io.Closer
@@ -39,6 +42,10 @@ func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, err
 	return SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
 }
 
+func ElectionSigningHash(cfg *rollup.Config, msgBytes []byte) (common.Hash, error) {
+	return SigningHash(SigningDomainElectionV1, cfg.L2ChainID, msgBytes)
+}
+
 // LocalSigner is suitable for testing
 type LocalSigner struct {
 	priv   *ecdsa.PrivateKey
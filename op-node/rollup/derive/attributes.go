@@ -17,21 +17,28 @@ import (
 type L1ReceiptsFetcher interface {
 	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
 	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
+	InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error)
 }
 
 type SystemConfigL2Fetcher interface {
 	SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error)
 }
 
+// AttributesL2Fetcher fetches the L2 inputs for the payload attributes derivation (the system config and forced-inclusion state)
+type AttributesL2Fetcher interface {
+	SystemConfigL2Fetcher
+	ForcedInclusionL2Fetcher
+}
+
 // FetchingAttributesBuilder fetches inputs for the building of L2 payload attributes on the fly.
 type FetchingAttributesBuilder struct {
 	rollupCfg      *rollup.Config
 	l1             L1ReceiptsFetcher
-	l2             SystemConfigL2Fetcher
+	l2             AttributesL2Fetcher
 	electionClient ElectionWinnersProvider
 }
 
-func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 SystemConfigL2Fetcher, electionClient ElectionWinnersProvider) *FetchingAttributesBuilder {
+func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher, l2 AttributesL2Fetcher, electionClient ElectionWinnersProvider) *FetchingAttributesBuilder {
 	return &FetchingAttributesBuilder{
 		rollupCfg:      rollupCfg,
 		l1:             l1,
@@ -48,6 +55,7 @@ func NewFetchingAttributesBuilder(rollupCfg *rollup.Config, l1 L1ReceiptsFetcher
 func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Context, l2Parent eth.L2BlockRef, epoch eth.BlockID) (attrs *eth.PayloadAttributes, err error) {
 	var l1Info eth.BlockInfo
 	var depositTxs []hexutil.Bytes
+	var forcedTxs []*types.Transaction
 	var seqNumber uint64
 
 	sysConfig, err := ba.l2.SystemConfigByL2Hash(ctx, l2Parent.Hash)
@@ -79,6 +87,20 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 			return nil, NewCriticalError(fmt.Errorf("failed to apply derived L1 sysCfg updates: %w", err))
 		}
 
+		// L2 transactions broadcast through the BasedInbox are force-included in the first block of the epoch, like deposits.
+		nextL2Time := l2Parent.Time + ba.rollupCfg.BlockTime
+		if ba.rollupCfg.IsForcedInclusion(nextL2Time) && !ba.rollupCfg.IsEcotoneActivationBlock(nextL2Time) &&
+			len(ForcedInclusionTxIndices(receipts, ba.rollupCfg.BasedInboxContractAddress, ba.rollupCfg.L2ChainID)) > 0 {
+			_, l1Txs, err := ba.l1.InfoAndTxsByHash(ctx, epoch.Hash)
+			if err != nil {
+				return nil, NewTemporaryError(fmt.Errorf("failed to fetch L1 block transactions: %w", err))
+			}
+			forcedTxs, err = ForcedTxs(l1Txs, receipts, ba.rollupCfg.BasedInboxContractAddress, ba.rollupCfg.L2ChainID)
+			if err != nil {
+				return nil, NewCriticalError(fmt.Errorf("failed to derive forced transactions: %w", err))
+			}
+		}
+
 		l1Info = info
 		depositTxs = deposits
 		seqNumber = 0
@@ -118,7 +140,10 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 		upgradeTxs = append(upgradeTxs, fjord...)
 	}
 
-	electionWinner := ba.electionClient.GetElectionWinner(nextL2Time)
+	electionWinner, err := ba.electionClient.ResolveElectionWinner(nextL2Time)
+	if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to resolve election winner: %w", err))
+	}
 	l1InfoTx, err := L1InfoDepositBytes(ba.rollupCfg, sysConfig, seqNumber, l1Info, nextL2Time, electionWinner.Address)
 	if err != nil {
 		return nil, NewCriticalError(fmt.Errorf("failed to create l1InfoTx: %w", err))
@@ -156,6 +181,13 @@ func (ba *FetchingAttributesBuilder) PreparePayloadAttributes(ctx context.Contex
 	txs = append(txs, afterForceIncludeTxs...)
 	txs = append(txs, upgradeTxs...)
 
+	// Forced transactions go after all deposits, so they can only use the gas that is left by the deposits.
+	forced, err := SelectForcedTxs(ctx, ba.rollupCfg, ba.l2, l2Parent, l1Info, sysConfig, txs, forcedTxs)
+	if err != nil {
+		return nil, err
+	}
+	txs = append(txs, forced...)
+
 	var withdrawals *types.Withdrawals
 	if ba.rollupCfg.IsCanyon(nextL2Time) {
 		withdrawals = &types.Withdrawals{}
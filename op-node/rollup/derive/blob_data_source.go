@@ -77,6 +77,10 @@ func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
 // transactions are found. It returns ResetError if it cannot find the referenced block or a
 // referenced blob, or TemporaryError for any other failure to fetch a block or blob.
 func (ds *BlobDataSource) open(ctx context.Context) ([]blobOrCalldata, error) {
+	electionWinner, err := ds.electionClient.ResolveElectionWinner(ds.ref.Time)
+	if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to resolve election winner: %w", err))
+	}
 	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash)
 	if err != nil {
 		if errors.Is(err, ethereum.NotFound) {
@@ -93,7 +97,7 @@ func (ds *BlobDataSource) open(ctx context.Context) ([]blobOrCalldata, error) {
 		txsWithReceipts[i] = TxWithReceipt{tx: tx, receipt: receipts[i]}
 	}
 
-	data, hashes := ds.dataAndHashesFromTxs(txsWithReceipts, &ds.dsCfg, ds.log)
+	data, hashes := ds.dataAndHashesFromTxs(txsWithReceipts, &ds.dsCfg, electionWinner, ds.log)
 
 	if len(hashes) == 0 {
 		// there are no blobs to fetch so we can return immediately
@@ -127,25 +131,24 @@ type TxWithReceipt struct {
 // dataAndHashesFromTxs extracts calldata and datahashes from the input transactions and returns them. It
 // creates a placeholder blobOrCalldata element for each returned blob hash that must be populated
 // by fillBlobPointers after blob bodies are retrieved.
-func (ds *BlobDataSource) dataAndHashesFromTxs(txs []TxWithReceipt, config *DataSourceConfig, logger log.Logger) ([]blobOrCalldata, []eth.IndexedBlobHash) {
+func (ds *BlobDataSource) dataAndHashesFromTxs(txs []TxWithReceipt, config *DataSourceConfig, electionWinner eth.ElectionWinner, logger log.Logger) ([]blobOrCalldata, []eth.IndexedBlobHash) {
 	data := []blobOrCalldata{}
 	var hashes []eth.IndexedBlobHash
 	blobIndex := 0 // index of each blob in the block's blob sidecar
-	blockTime := ds.ref.Time
-	electionWinner := ds.electionClient.GetElectionWinner(blockTime)
 
 	if electionWinner == (eth.ElectionWinner{}) {
-		ds.log.Warn("No election winner found for block", "blockTime", blockTime)
+		ds.log.Warn("No election winner found for block", "blockTime", ds.ref.Time)
 		return data, hashes
 	}
 
 	// 3. pass into isvalidbatchtx
 	for _, tx := range txs {
 		// skip any non-batcher transactions
-		if !isValidBatchTx(tx.receipt, electionWinner.Address, config, logger) {
+		if !isValidBatchTx(tx.receipt, electionWinner, config, logger) {
 			blobIndex += len(tx.tx.BlobHashes())
 			continue
 		}
+		electionWinner = claimSlot(tx.receipt, electionWinner, config, logger)
 
 		// handle non-blob batcher transactions by extracting their calldata
 		if tx.tx.Type() != types.BlobTxType {
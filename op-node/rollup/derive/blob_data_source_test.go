@@ -21,11 +21,11 @@ type MockElectionWinnersProvider struct {
 	electionWinner common.Address
 }
 
-func (m *MockElectionWinnersProvider) GetElectionWinner(_timestamp uint64) eth.ElectionWinner {
+func (m *MockElectionWinnersProvider) ResolveElectionWinner(_timestamp uint64) (eth.ElectionWinner, error) {
 	return eth.ElectionWinner{
 		Address: m.electionWinner,
 		Time:    0x499602D2,
-	}
+	}, nil
 }
 
 func TestDataAndHashesFromTxs(t *testing.T) {
@@ -46,16 +46,15 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 		batchInboxAddress: batchInboxAddr,
 	}
 
-	mockElectionProvider := &MockElectionWinnersProvider{electionWinner: electionWinnerAddr}
+	electionWinner := eth.ElectionWinner{Address: electionWinnerAddr, Time: 0x499602D2}
 
 	// create an instance of the blob data source for testing w/o calling a function. Just create the struct
 	ds := BlobDataSource{
-		ref:            eth.L1BlockRef{Time: 0x499602D2},
-		dsCfg:          config,
-		fetcher:        nil,
-		log:            logger,
-		blobsFetcher:   nil,
-		electionClient: mockElectionProvider,
+		ref:          eth.L1BlockRef{Time: 0x499602D2},
+		dsCfg:        config,
+		fetcher:      nil,
+		log:          logger,
+		blobsFetcher: nil,
 	}
 
 	// create a valid non-blob batcher transaction and make sure it's picked up
@@ -78,7 +77,7 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 		}},
 	}
 	txs := []TxWithReceipt{{tx: calldataTx, receipt: calldataReceipt}}
-	data, blobHashes := ds.dataAndHashesFromTxs(txs, &config, logger)
+	data, blobHashes := ds.dataAndHashesFromTxs(txs, &config, electionWinner, logger)
 	require.Equal(t, 1, len(data))
 	require.Equal(t, 0, len(blobHashes))
 
@@ -98,14 +97,14 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 		}},
 	}
 	txs = []TxWithReceipt{{tx: blobTx, receipt: blobReceipt}}
-	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, logger)
+	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, electionWinner, logger)
 	require.Equal(t, 1, len(data))
 	require.Equal(t, 1, len(blobHashes))
 	require.Nil(t, data[0].calldata)
 
 	// try again with both the blob & calldata transactions and make sure both are picked up
 	txs = []TxWithReceipt{{tx: blobTx, receipt: blobReceipt}, {tx: calldataTx, receipt: calldataReceipt}}
-	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, logger)
+	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, electionWinner, logger)
 	require.Equal(t, 2, len(data))
 	require.Equal(t, 1, len(blobHashes))
 	require.NotNil(t, data[1].calldata)
@@ -120,7 +119,7 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 			Topics:  []common.Hash{batchSubmittedEventTopic, padAddress(randomAddr)},
 		}},
 	}}}
-	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, logger)
+	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, electionWinner, logger)
 	require.Equal(t, 0, len(data))
 	require.Equal(t, 0, len(blobHashes))
 
@@ -128,7 +127,7 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 	blobTxData.Data = testutils.RandomData(rng, rng.Intn(1000))
 	blobTx, _ = types.SignNewTx(privateKey, signer, blobTxData)
 	txs = []TxWithReceipt{{tx: blobTx, receipt: &types.Receipt{}}}
-	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, logger)
+	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, electionWinner, logger)
 	require.Equal(t, 0, len(data))
 	require.Equal(t, 0, len(blobHashes))
 
@@ -137,7 +136,7 @@ func TestDataAndHashesFromTxs(t *testing.T) {
 	blobTxData.To = testutils.RandomAddress(rng)
 	blobTx, _ = types.SignNewTx(privateKey, signer, blobTxData)
 	txs = []TxWithReceipt{{tx: blobTx, receipt: &types.Receipt{}}}
-	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, logger)
+	data, blobHashes = ds.dataAndHashesFromTxs(txs, &config, electionWinner, logger)
 	require.Equal(t, 0, len(data))
 	require.Equal(t, 0, len(blobHashes))
 }
@@ -227,7 +226,7 @@ func TestIsValidBatchTx(t *testing.T) {
 				Topics:  []common.Hash{batchSubmittedEventTopic, electionWinnerTopic},
 			}},
 		}
-		valid := isValidBatchTx(receipt, electionWinnerAddr, cfg, logger)
+		valid := isValidBatchTx(receipt, eth.ElectionWinner{Address: electionWinnerAddr}, cfg, logger)
 		require.True(t, valid, "Expected transaction and winner to be valid")
 	})
 
@@ -246,10 +245,27 @@ func TestIsValidBatchTx(t *testing.T) {
 				Topics:  []common.Hash{batchSubmittedEventTopic, padAddress(randomAddr)},
 			}},
 		}
-		valid := isValidBatchTx(receipt, batchInboxAddr, cfg, logger)
+		valid := isValidBatchTx(receipt, eth.ElectionWinner{Address: batchInboxAddr}, cfg, logger)
 		require.False(t, valid, "Expected transaction to be invalid due to incorrect election winner")
 	})
 
+	t.Run("Blob batch transaction of any sender for permissionless slot", func(t *testing.T) {
+		receipt := &types.Receipt{
+			Type: types.BlobTxType,
+			Logs: []*types.Log{{
+				Address: batchInboxAddr,
+				Topics:  []common.Hash{batchSubmittedEventTopic, padAddress(randomAddr)},
+			}},
+		}
+		winner := eth.ElectionWinner{Permissionless: true}
+		require.True(t, isValidBatchTx(receipt, winner, cfg, logger), "Expected transaction to be valid for permissionless slot")
+
+		claimed := claimSlot(receipt, winner, cfg, logger)
+		require.Equal(t, eth.ElectionWinner{Address: randomAddr}, claimed)
+		receipt.Logs[0].Topics[1] = electionWinnerTopic
+		require.False(t, isValidBatchTx(receipt, claimed, cfg, logger), "Expected claimed slot to reject other senders")
+	})
+
 	t.Run("Invalid receipt type", func(t *testing.T) {
 		receipt := &types.Receipt{
 			Type: types.LegacyTxType,
@@ -259,7 +275,7 @@ func TestIsValidBatchTx(t *testing.T) {
 			}},
 		}
 
-		valid := isValidBatchTx(receipt, electionWinnerAddr, cfg, logger)
+		valid := isValidBatchTx(receipt, eth.ElectionWinner{Address: electionWinnerAddr}, cfg, logger)
 		require.False(t, valid, "Expected transaction to be invalid due to receipt type")
 	})
 
@@ -272,7 +288,7 @@ func TestIsValidBatchTx(t *testing.T) {
 			}},
 		}
 
-		valid := isValidBatchTx(receipt, electionWinnerAddr, cfg, logger)
+		valid := isValidBatchTx(receipt, eth.ElectionWinner{Address: electionWinnerAddr}, cfg, logger)
 		require.False(t, valid, "Expected transaction to be invalid due to incorrect log topic")
 	})
 
@@ -282,7 +298,7 @@ func TestIsValidBatchTx(t *testing.T) {
 			Logs: []*types.Log{},
 		}
 
-		valid := isValidBatchTx(receipt, electionWinnerAddr, cfg, logger)
+		valid := isValidBatchTx(receipt, eth.ElectionWinner{Address: electionWinnerAddr}, cfg, logger)
 		require.False(t, valid, "Expected transaction to be invalid due to missing logs")
 	})
 }
@@ -1,10 +1,12 @@
 package derive
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -15,6 +17,10 @@ import (
 	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
 )
 
+// ErrNotSubmitCall is returned when the call data of a batch transaction is not a call of the expected
+// submit method of the BatchInbox contract.
+var ErrNotSubmitCall = errors.New("not a BatchInbox submit call")
+
 // CalldataSource is a fault tolerant approach to fetching data.
 // The constructor will never fail & it will instead re-attempt the fetcher
 // at a later point.
@@ -46,8 +52,11 @@ func NewCalldataSource(ctx context.Context, log log.Logger, dsCfg DataSourceConf
 // will attempt to reinitialize itself. If it cannot find the block it returns a ResetError
 // otherwise it returns a temporary error if fetching the block returns an error.
 func (ds *CalldataSource) Next(ctx context.Context) (eth.Data, error) {
-	electionWinner := ds.electionClient.GetElectionWinner(ds.ref.Time)
-	if electionWinner.Address == (common.Address{}) {
+	electionWinner, err := ds.electionClient.ResolveElectionWinner(ds.ref.Time)
+	if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to resolve election winner: %w", err))
+	}
+	if electionWinner.Address == (common.Address{}) && !electionWinner.Permissionless {
 		ds.log.Warn("No election winner found for block", "time", ds.ref.Time)
 		return nil, io.EOF
 	}
@@ -69,7 +78,7 @@ func (ds *CalldataSource) Next(ctx context.Context) (eth.Data, error) {
 		txsWithReceipts[i] = TxWithReceipt{tx: tx, receipt: receipts[i]}
 	}
 
-	ds.data = DataFromEVMTransactions(ds.dsCfg, electionWinner.Address, txsWithReceipts, ds.log)
+	ds.data = DataFromEVMTransactions(ds.dsCfg, electionWinner, txsWithReceipts, ds.log)
 
 	if len(ds.data) == 0 {
 		return nil, io.EOF
@@ -83,7 +92,7 @@ func (ds *CalldataSource) Next(ctx context.Context) (eth.Data, error) {
 // DataFromEVMTransactions filters all of the transactions and returns the calldata from transactions
 // that are sent to the batch inbox address from the batch sender address.
 // This will return an empty array if no valid transactions are found.
-func DataFromEVMTransactions(dsCfg DataSourceConfig, electionWinner common.Address, txs []TxWithReceipt, log log.Logger) []eth.Data {
+func DataFromEVMTransactions(dsCfg DataSourceConfig, electionWinner eth.ElectionWinner, txs []TxWithReceipt, log log.Logger) []eth.Data {
 	out := []eth.Data{}
 
 	for _, tx := range txs {
@@ -91,6 +100,7 @@ func DataFromEVMTransactions(dsCfg DataSourceConfig, electionWinner common.Addre
 			continue
 		}
 		if isValidBatchTx(tx.receipt, electionWinner, &dsCfg, log) {
+			electionWinner = claimSlot(tx.receipt, electionWinner, &dsCfg, log)
 			payload, err := ExtractPayload(tx.tx)
 			if err != nil {
 				log.Error("Could not extract payload", "err", err, "tx", tx.tx)
@@ -102,18 +112,67 @@ func DataFromEVMTransactions(dsCfg DataSourceConfig, electionWinner common.Addre
 	return out
 }
 
+// ExtractPayload returns the batcher data of a submitCalldata transaction to the BatchInbox contract.
 func ExtractPayload(tx *types.Transaction) (eth.Data, error) {
-	data := tx.Data()
+	return UnpackSubmitCalldata(tx.Data())
+}
 
-	// We need to skip past the selector and first argument (uint256 _targetTimestamp)
-	batchInboxAbi := snapshots.LoadBatchInboxABI()
-	method := batchInboxAbi.Methods["submitCalldata"]
+// SubmitCalldataTxData ABI-encodes the batcher data as a submitCalldata call to the BatchInbox contract,
+// for the slot of the target L1 block timestamp.
+func SubmitCalldataTxData(targetTimestamp uint64, data []byte) ([]byte, error) {
+	method := snapshots.LoadBatchInboxABI().Methods["submitCalldata"]
+	inputs, err := method.Inputs.Pack(new(big.Int).SetUint64(targetTimestamp), data)
+	if err != nil {
+		return nil, fmt.Errorf("packing submitCalldata inputs: %w", err)
+	}
+	return append(method.ID, inputs...), nil
+}
 
-	// Unpack the method inputs
-	inputs, err := method.Inputs.Unpack(data[4:])
+// SubmitBlobTxData ABI-encodes a submitBlob call to the BatchInbox contract,
+// for the slot of the target L1 block timestamp.
+func SubmitBlobTxData(targetTimestamp uint64) ([]byte, error) {
+	method := snapshots.LoadBatchInboxABI().Methods["submitBlob"]
+	inputs, err := method.Inputs.Pack(new(big.Int).SetUint64(targetTimestamp))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("packing submitBlob inputs: %w", err)
 	}
+	return append(method.ID, inputs...), nil
+}
 
+// UnpackSubmitCalldata ABI-decodes the batcher data from the call data of a submitCalldata call.
+func UnpackSubmitCalldata(data []byte) ([]byte, error) {
+	method := snapshots.LoadBatchInboxABI().Methods["submitCalldata"]
+	if len(data) < 4 || !bytes.Equal(data[:4], method.ID) {
+		return nil, ErrNotSubmitCall
+	}
+	// Skip past the selector, the first argument is the uint256 _targetTimestamp
+	inputs, err := method.Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("unpacking submitCalldata inputs: %w", err)
+	}
 	return inputs[1].([]byte), nil
 }
+
+// SubmitTargetTimestamp returns the target L1 block timestamp of a submitBlob or submitCalldata call.
+func SubmitTargetTimestamp(data []byte) (uint64, error) {
+	if len(data) < 4 {
+		return 0, ErrNotSubmitCall
+	}
+	batchInboxAbi := snapshots.LoadBatchInboxABI()
+	for _, name := range []string{"submitBlob", "submitCalldata"} {
+		method := batchInboxAbi.Methods[name]
+		if !bytes.Equal(data[:4], method.ID) {
+			continue
+		}
+		inputs, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return 0, fmt.Errorf("unpacking %s inputs: %w", name, err)
+		}
+		targetTimestamp := inputs[0].(*big.Int)
+		if !targetTimestamp.IsUint64() {
+			return 0, fmt.Errorf("target timestamp %v out of range", targetTimestamp)
+		}
+		return targetTimestamp.Uint64(), nil
+	}
+	return 0, ErrNotSubmitCall
+}
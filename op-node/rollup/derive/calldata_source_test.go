@@ -26,12 +26,17 @@ type testTx struct {
 	good    bool
 	value   int
 	rawData []byte
+	// unwrapped sends the data as is, instead of in a submitCalldata call
+	unwrapped bool
 }
 
 func (tx *testTx) Create(t *testing.T, signer types.Signer, rng *rand.Rand) TxWithReceipt {
 	t.Helper()
 	tx.rawData = testutils.RandomData(rng, tx.dataLen)
 	calldata := submitCalldataTxData(t, tx.rawData)
+	if tx.unwrapped {
+		calldata = tx.rawData
+	}
 	outTx, err := types.SignNewTx(tx.author, signer, &types.DynamicFeeTx{
 		ChainID:   signer.ChainID(),
 		Nonce:     0,
@@ -62,8 +67,9 @@ func (tx *testTx) Create(t *testing.T, signer types.Signer, rng *rand.Rand) TxWi
 }
 
 type calldataTest struct {
-	name string
-	txs  []testTx
+	name           string
+	txs            []testTx
+	permissionless bool
 }
 
 // TestDataFromEVMTransactions creates some transactions from a specified template and asserts
@@ -110,6 +116,9 @@ func TestDataFromEVMTransactions(t *testing.T) {
 		{
 			name: "empty block", txs: []testTx{},
 		},
+		{
+			name: "unwrapped calldata",
+			txs:  []testTx{{to: &cfg.BatchInboxContractAddress, dataLen: 1234, author: electionWinnerPriv, unwrapped: true, good: false}}},
 		{
 			name: "mixed txs",
 			txs: []testTx{
@@ -119,6 +128,21 @@ func TestDataFromEVMTransactions(t *testing.T) {
 				{to: &altInbox, dataLen: 2020, value: 12, author: electionWinnerPriv, good: false},
 			},
 		},
+		{
+			name:           "permissionless slot",
+			permissionless: true,
+			txs:            []testTx{{to: &cfg.BatchInboxContractAddress, dataLen: 1234, author: altAuthor, good: true}},
+		},
+		{
+			name:           "permissionless slot first submitter wins",
+			permissionless: true,
+			txs: []testTx{
+				{to: &altInbox, dataLen: 2020, value: 12, author: electionWinnerPriv, good: false},
+				{to: &cfg.BatchInboxContractAddress, dataLen: 1234, author: altAuthor, good: true},
+				{to: &cfg.BatchInboxContractAddress, dataLen: 3333, author: electionWinnerPriv, good: false},
+				{to: &cfg.BatchInboxContractAddress, dataLen: 2000, author: altAuthor, good: true},
+			},
+		},
 		// TODO: test with different batcher key, i.e. when it's changed from initial config value by L1 contract
 	}
 
@@ -135,7 +159,11 @@ func TestDataFromEVMTransactions(t *testing.T) {
 			}
 		}
 
-		out := DataFromEVMTransactions(DataSourceConfig{cfg.L1Signer(), cfg.BatchInboxContractAddress, false}, electionWinnerAddr, txs, testlog.Logger(t, log.LevelCrit))
+		electionWinner := eth.ElectionWinner{Address: electionWinnerAddr}
+		if tc.permissionless {
+			electionWinner = eth.ElectionWinner{Permissionless: true}
+		}
+		out := DataFromEVMTransactions(DataSourceConfig{cfg.L1Signer(), cfg.BatchInboxContractAddress, false}, electionWinner, txs, testlog.Logger(t, log.LevelCrit))
 		require.ElementsMatch(t, expectedData, out)
 	}
 
@@ -152,3 +180,33 @@ func submitCalldataTxData(t require.TestingT, payload []byte) []byte {
 	submitSel := submitCalldataMethod.ID
 	return append(submitSel[:], inputs...)
 }
+
+func TestSubmitCalldataTxData(t *testing.T) {
+	payload := testutils.RandomData(rand.New(rand.NewSource(1234)), 1000)
+	data, err := SubmitCalldataTxData(1234, payload)
+	require.NoError(t, err)
+	require.Equal(t, submitCalldataTxData(t, payload)[4+32:], data[4+32:], "only the target timestamp differs")
+
+	out, err := UnpackSubmitCalldata(data)
+	require.NoError(t, err)
+	require.Equal(t, payload, out)
+
+	_, err = UnpackSubmitCalldata(payload)
+	require.ErrorIs(t, err, ErrNotSubmitCall)
+	_, err = UnpackSubmitCalldata(data[:3])
+	require.ErrorIs(t, err, ErrNotSubmitCall)
+	blobData, err := SubmitBlobTxData(1234)
+	require.NoError(t, err)
+	_, err = UnpackSubmitCalldata(blobData)
+	require.ErrorIs(t, err, ErrNotSubmitCall)
+	_, err = UnpackSubmitCalldata(data[:len(data)-40])
+	require.Error(t, err)
+
+	for _, d := range [][]byte{data, blobData} {
+		targetTimestamp, err := SubmitTargetTimestamp(d)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1234), targetTimestamp)
+	}
+	_, err = SubmitTargetTimestamp(payload)
+	require.ErrorIs(t, err, ErrNotSubmitCall)
+}
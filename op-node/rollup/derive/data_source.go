@@ -39,7 +39,9 @@ type AltDAInputFetcher interface {
 }
 
 type ElectionWinnersProvider interface {
-	GetElectionWinner(time uint64) eth.ElectionWinner
+	// ResolveElectionWinner returns the election winner of the slot of the given time. A zero winner means the slot
+	// has no winner. An error means the election of the slot is not known yet, and the slot must be derived later.
+	ResolveElectionWinner(time uint64) (eth.ElectionWinner, error)
 }
 
 // DataSourceFactory reads raw transactions from a given block & then filters for
@@ -101,8 +103,23 @@ type DataSourceConfig struct {
 	altDAEnabled      bool
 }
 
-func isValidBatchTx(receipt *types.Receipt, electionWinnerAddr common.Address, cfg *DataSourceConfig, logger log.Logger) bool {
+// isValidBatchTx returns true if the receipt has a BatchSubmitted event of the BatchInbox, submitted by the election winner.
+// Batches for permissionless slots may be submitted by anyone, see claimSlot.
+func isValidBatchTx(receipt *types.Receipt, electionWinner eth.ElectionWinner, cfg *DataSourceConfig, logger log.Logger) bool {
+	_, ok := validBatchSubmitter(receipt, electionWinner, cfg, logger)
+	return ok
+}
+
+// IsValidBatchTx is isValidBatchTx for use outside of the data sources,
+// e.g. to check whether the election winner of an L1 block submitted its batch.
+func IsValidBatchTx(cfg *rollup.Config, receipt *types.Receipt, electionWinner eth.ElectionWinner, logger log.Logger) bool {
+	dsCfg := DataSourceConfig{batchInboxAddress: cfg.BatchInboxContractAddress}
+	return isValidBatchTx(receipt, electionWinner, &dsCfg, logger)
+}
 
+// validBatchSubmitter returns the submitter of the first BatchSubmitted event of the BatchInbox in the receipt
+// that was submitted by the election winner, or by anyone for permissionless slots.
+func validBatchSubmitter(receipt *types.Receipt, electionWinner eth.ElectionWinner, cfg *DataSourceConfig, logger log.Logger) (common.Address, bool) {
 	batchInboxAbi := snapshots.LoadBatchInboxABI()
 	topic0 := batchInboxAbi.Events["BatchSubmitted"].ID
 	for _, log := range receipt.Logs {
@@ -115,16 +132,31 @@ func isValidBatchTx(receipt *types.Receipt, electionWinnerAddr common.Address, c
 			continue
 		}
 		senderAddr := addressFromTopic(log.Topics[1])
-		if senderAddr != electionWinnerAddr {
-			logger.Warn("Invalid batch sender", "expected", electionWinnerAddr, "got", senderAddr)
+		if !electionWinner.Permissionless && senderAddr != electionWinner.Address {
+			logger.Warn("Invalid batch sender", "expected", electionWinner.Address, "got", senderAddr)
 			continue
 		}
 
 		logger.Debug("Valid tx in inbox", "tx", receipt.TxHash)
-		return true
+		return senderAddr, true
 	}
 
-	return false
+	return common.Address{}, false
+}
+
+// claimSlot returns the election winner that the remaining transactions of the L1 block are validated against,
+// after the given valid batch transaction. The first valid batch transaction of a permissionless slot
+// claims the slot for its submitter, so batches of competing batchers are not mixed.
+func claimSlot(receipt *types.Receipt, electionWinner eth.ElectionWinner, cfg *DataSourceConfig, logger log.Logger) eth.ElectionWinner {
+	if !electionWinner.Permissionless {
+		return electionWinner
+	}
+	submitter, ok := validBatchSubmitter(receipt, electionWinner, cfg, logger)
+	if !ok {
+		return electionWinner
+	}
+	logger.Info("Permissionless slot claimed", "time", electionWinner.Time, "submitter", submitter, "tx", receipt.TxHash)
+	return eth.ElectionWinner{Address: submitter, Time: electionWinner.Time}
 }
 
 func addressFromTopic(topic common.Hash) common.Address {
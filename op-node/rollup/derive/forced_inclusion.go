@@ -0,0 +1,217 @@
+package derive
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// ForcedInclusionL2Fetcher fetches the L2 parent block and account state that forced-inclusion transactions are checked against.
+type ForcedInclusionL2Fetcher interface {
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayloadEnvelope, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// ForcedInclusionTxIndices returns the indices of the L1 transactions that broadcast an L2 transaction
+// to the chain with the given chain ID through the BasedInbox.
+func ForcedInclusionTxIndices(receipts []*types.Receipt, basedInboxAddr common.Address, l2ChainID *big.Int) []int {
+	var out []int
+	for i, rec := range receipts {
+		if rec.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+		for _, log := range rec.Logs {
+			if isForcedInclusionLog(log, basedInboxAddr, l2ChainID) {
+				out = append(out, i)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// ForcedTxs decodes the L2 transactions broadcast through the BasedInbox in an L1 block, in L1 transaction order.
+// Anyone can call the BasedInbox, so broadcasts that fail to decode are skipped rather than treated as an error.
+func ForcedTxs(l1Txs types.Transactions, receipts []*types.Receipt, basedInboxAddr common.Address, l2ChainID *big.Int) ([]*types.Transaction, error) {
+	if len(l1Txs) != len(receipts) {
+		return nil, fmt.Errorf("got %d L1 transactions but %d receipts", len(l1Txs), len(receipts))
+	}
+	var out []*types.Transaction
+	for _, i := range ForcedInclusionTxIndices(receipts, basedInboxAddr, l2ChainID) {
+		tx, err := UnmarshalForcedInclusionTx(l1Txs[i], basedInboxAddr, l2ChainID)
+		if err != nil {
+			continue
+		}
+		out = append(out, tx)
+	}
+	return out, nil
+}
+
+// forcedAccount tracks the state of a forced-inclusion sender while the forced transactions of a block are selected.
+type forcedAccount struct {
+	contract bool
+	nonce    uint64
+	balance  *big.Int
+}
+
+// SelectForcedTxs filters the forced transactions down to the ones the execution engine is guaranteed to accept
+// after the deposits of the block, so that a broadcast transaction can never make the L2 block itself invalid.
+//
+// The checks mirror the pre-checks of the state-transition, against the state of the L2 parent block:
+//   - the sender is an EOA and the nonce follows the sender nonce, including nonce increments by deposits of the sender.
+//   - the transaction gas covers the intrinsic gas, and all forced transactions fit in the gas left by the deposits.
+//   - the fee cap is at least twice the parent base fee. The base fee of the block itself depends on the EIP-1559 parameters
+//     of the L2 chain, but can not double within a single block with an EIP-1559 denominator no smaller than the elasticity.
+//   - the sender balance covers the maximum gas cost, the value and the L1 data fee, after value transfers by deposits.
+//
+// Forced transactions are included in the first L2 block of the epoch of the L1 block they were broadcast in,
+// so the inclusion delay is bounded by the sequencing window, like for deposits.
+func SelectForcedTxs(ctx context.Context, rollupCfg *rollup.Config, l2 ForcedInclusionL2Fetcher, l2Parent eth.L2BlockRef,
+	l1Info eth.BlockInfo, sysConfig eth.SystemConfig, deposits []hexutil.Bytes, candidates []*types.Transaction) ([]hexutil.Bytes, error) {
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	signer := types.LatestSignerForChainID(rollupCfg.L2ChainID)
+
+	// Account for the gas, nonce increments and value transfers of the deposits that execute before the forced transactions.
+	gasLeft := sysConfig.GasLimit
+	depositNonces := make(map[common.Address]uint64)
+	depositValues := make(map[common.Address]*big.Int)
+	for i, opaqueTx := range deposits {
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(opaqueTx); err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to decode deposit %d: %w", i, err))
+		}
+		if tx.Gas() > gasLeft {
+			gasLeft = 0
+		} else {
+			gasLeft -= tx.Gas()
+		}
+		from, err := types.Sender(signer, &tx)
+		if err != nil {
+			return nil, NewCriticalError(fmt.Errorf("failed to get sender of deposit %d: %w", i, err))
+		}
+		depositNonces[from] += 1
+		if value, ok := depositValues[from]; ok {
+			value.Add(value, tx.Value())
+		} else {
+			depositValues[from] = new(big.Int).Set(tx.Value())
+		}
+	}
+
+	parent, err := l2.PayloadByHash(ctx, l2Parent.Hash)
+	if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch L2 parent block %s: %w", l2Parent, err))
+	}
+	minFeeCap := new(big.Int).Mul((*uint256.Int)(&parent.ExecutionPayload.BaseFeePerGas).ToBig(), big.NewInt(2))
+
+	scalars, err := sysConfig.EcotoneScalars()
+	if err != nil {
+		return nil, NewCriticalError(fmt.Errorf("failed to read L1 fee scalars: %w", err))
+	}
+	blobBaseFee := l1Info.BlobBaseFee()
+	if blobBaseFee == nil {
+		blobBaseFee = big.NewInt(1)
+	}
+	l1CostFn := types.NewL1CostFuncFjord(l1Info.BaseFee(), blobBaseFee,
+		new(big.Int).SetUint64(uint64(scalars.BaseFeeScalar)), new(big.Int).SetUint64(uint64(scalars.BlobBaseFeeScalar)))
+
+	accounts := make(map[common.Address]*forcedAccount)
+	var out []hexutil.Bytes
+	for _, tx := range candidates {
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		acc, ok := accounts[from]
+		if !ok {
+			res, err := l2.GetProof(ctx, from, nil, l2Parent.Hash.String())
+			if err != nil {
+				return nil, NewTemporaryError(fmt.Errorf("failed to fetch L2 account %s at %s: %w", from, l2Parent, err))
+			}
+			acc = &forcedAccount{balance: new(big.Int)}
+			if res != nil {
+				// Transactions of senders with deployed code are rejected by the engine (EIP-3607)
+				acc.contract = res.CodeHash != (common.Hash{}) && res.CodeHash != types.EmptyCodeHash
+				acc.nonce = uint64(res.Nonce)
+				if res.Balance != nil {
+					acc.balance.Set(res.Balance.ToInt())
+				}
+			}
+			acc.nonce += depositNonces[from]
+			if value, ok := depositValues[from]; ok {
+				acc.balance.Sub(acc.balance, value)
+			}
+			accounts[from] = acc
+		}
+		if acc.contract || tx.Nonce() != acc.nonce || tx.Nonce() == ^uint64(0) {
+			continue
+		}
+		if tx.Gas() > gasLeft {
+			continue
+		}
+		if tx.To() == nil && len(tx.Data()) > params.MaxInitCodeSize {
+			continue
+		}
+		intrinsicGas, err := core.IntrinsicGas(tx.Data(), tx.AccessList(), tx.To() == nil, true, true, true)
+		if err != nil || tx.Gas() < intrinsicGas {
+			continue
+		}
+		if tx.GasFeeCap().BitLen() > 256 || tx.GasTipCap().BitLen() > 256 || tx.GasFeeCapIntCmp(tx.GasTipCap()) < 0 {
+			continue
+		}
+		if tx.GasFeeCap().Cmp(minFeeCap) < 0 {
+			continue
+		}
+		cost := new(big.Int).Mul(new(big.Int).SetUint64(tx.Gas()), tx.GasFeeCap())
+		cost.Add(cost, tx.Value())
+		l1Cost, _ := l1CostFn(tx.RollupCostData())
+		cost.Add(cost, l1Cost)
+		if acc.balance.Cmp(cost) < 0 {
+			continue
+		}
+		opaqueTx, err := tx.MarshalBinary()
+		if err != nil {
+			continue
+		}
+
+		acc.nonce += 1
+		acc.balance.Sub(acc.balance, cost)
+		gasLeft -= tx.Gas()
+		out = append(out, opaqueTx)
+	}
+	return out, nil
+}
+
+// trimForcedTxs drops the copies of the forced transactions of the attributes from the start of the batch transactions.
+// The sequencer builds the forced transactions into the block, and the batcher then submits them like any other
+// transaction of the block, while the verifier already includes them in the attributes derived from L1.
+func trimForcedTxs(attrsTxs []hexutil.Bytes, batchTxs []hexutil.Bytes) []hexutil.Bytes {
+	forced := 0
+	for _, tx := range attrsTxs {
+		if len(tx) > 0 && tx[0] != types.DepositTxType {
+			forced += 1
+		}
+	}
+	if forced == 0 || len(batchTxs) < forced {
+		return batchTxs
+	}
+	forcedTxs := attrsTxs[len(attrsTxs)-forced:]
+	for i, tx := range forcedTxs {
+		if string(tx) != string(batchTxs[i]) {
+			return batchTxs
+		}
+	}
+	return batchTxs[forced:]
+}
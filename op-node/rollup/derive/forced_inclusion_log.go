@@ -0,0 +1,82 @@
+package derive
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+var (
+	ForcedInclusionEventABI     = "TransactionBroadcasted(uint256,uint256)"
+	ForcedInclusionEventABIHash = crypto.Keccak256Hash([]byte(ForcedInclusionEventABI))
+	BroadcastTxSignature        = "broadcastTx(bytes,uint256)"
+	BroadcastTxSelector         = crypto.Keccak256([]byte(BroadcastTxSignature))[:4]
+)
+
+// MaxForcedTxSize is the maximum encoded size of a forced-inclusion transaction,
+// matching the size limit the execution engine applies to transactions in its tx-pool.
+const MaxForcedTxSize = 128 * 1024
+
+// isForcedInclusionLog returns true if the log is a TransactionBroadcasted event of the BasedInbox,
+// emitted for the L2 chain with the given chain ID.
+//
+//	event TransactionBroadcasted(
+//	    uint256 indexed _chainId,
+//	    uint256 _count
+//	);
+func isForcedInclusionLog(ev *types.Log, basedInboxAddr common.Address, l2ChainID *big.Int) bool {
+	return ev.Address == basedInboxAddr &&
+		len(ev.Topics) == 2 &&
+		ev.Topics[0] == ForcedInclusionEventABIHash &&
+		ev.Topics[1] == common.BigToHash(l2ChainID)
+}
+
+// UnmarshalForcedInclusionTx decodes the L2 transaction that an L1 transaction broadcast through the BasedInbox.
+//
+// The TransactionBroadcasted event does not carry the transaction itself,
+// so it is read from the calldata of the L1 transaction instead:
+//
+//	function broadcastTx(bytes calldata _tx, uint256 _chainId) external;
+//
+// Only direct calls to the BasedInbox can be decoded. Broadcasts made by other contracts are not force-included.
+func UnmarshalForcedInclusionTx(l1Tx *types.Transaction, basedInboxAddr common.Address, l2ChainID *big.Int) (*types.Transaction, error) {
+	if to := l1Tx.To(); to == nil || *to != basedInboxAddr {
+		return nil, fmt.Errorf("L1 transaction %s is not a direct call to the BasedInbox", l1Tx.Hash())
+	}
+	data := l1Tx.Data()
+	if len(data) < 4 || !bytes.Equal(data[:4], BroadcastTxSelector) {
+		return nil, fmt.Errorf("L1 transaction %s does not call %s", l1Tx.Hash(), BroadcastTxSignature)
+	}
+	inputs, err := snapshots.LoadBasedInboxABI().Methods["broadcastTx"].Inputs.Unpack(data[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack broadcastTx calldata: %w", err)
+	}
+	opaqueTx := inputs[0].([]byte)
+	if chainID := inputs[1].(*big.Int); chainID.Cmp(l2ChainID) != 0 {
+		return nil, fmt.Errorf("broadcast is for chain %d, expected %d", chainID, l2ChainID)
+	}
+	if len(opaqueTx) > MaxForcedTxSize {
+		return nil, fmt.Errorf("forced transaction of %d bytes exceeds the maximum size of %d bytes", len(opaqueTx), MaxForcedTxSize)
+	}
+
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(opaqueTx); err != nil {
+		return nil, fmt.Errorf("failed to decode forced transaction: %w", err)
+	}
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType, types.DynamicFeeTxType:
+	default:
+		return nil, fmt.Errorf("unsupported forced transaction type %d", tx.Type())
+	}
+	// Unprotected legacy transactions report a zero chain ID, and are rejected here as well.
+	if tx.ChainId().Cmp(l2ChainID) != 0 {
+		return nil, fmt.Errorf("forced transaction is signed for chain %d, expected %d", tx.ChainId(), l2ChainID)
+	}
+	return &tx, nil
+}
@@ -0,0 +1,230 @@
+package derive
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+var (
+	mockBasedInboxAddr = common.HexToAddress("0xba5ed1b0c000000000000000000000000000b0c5")
+	forcedTestChainID  = big.NewInt(901)
+)
+
+func makeForcedTx(t *testing.T, key *ecdsa.PrivateKey, nonce uint64, gasFeeCap *big.Int) *types.Transaction {
+	to := common.Address{0xaa}
+	return types.MustSignNewTx(key, types.LatestSignerForChainID(forcedTestChainID), &types.DynamicFeeTx{
+		ChainID:   forcedTestChainID,
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: gasFeeCap,
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(1),
+	})
+}
+
+func makeBroadcastTx(t *testing.T, rng *rand.Rand, to common.Address, l2Tx *types.Transaction, chainID *big.Int) *types.Transaction {
+	opaqueTx, err := l2Tx.MarshalBinary()
+	require.NoError(t, err)
+	data, err := snapshots.LoadBasedInboxABI().Pack("broadcastTx", opaqueTx, chainID)
+	require.NoError(t, err)
+	return types.MustSignNewTx(testutils.InsecureRandomKey(rng), types.LatestSignerForChainID(big.NewInt(900)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(900),
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Gas:       100_000,
+		To:        &to,
+		Data:      data,
+	})
+}
+
+func makeBroadcastReceipt(status uint64, chainID *big.Int) *types.Receipt {
+	return &types.Receipt{
+		Status: status,
+		Logs: []*types.Log{{
+			Address: mockBasedInboxAddr,
+			Topics:  []common.Hash{ForcedInclusionEventABIHash, common.BigToHash(chainID)},
+			Data:    common.BigToHash(big.NewInt(1)).Bytes(),
+		}},
+	}
+}
+
+func TestUnmarshalForcedInclusionTx(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l2Tx := makeForcedTx(t, testutils.InsecureRandomKey(rng), 0, big.NewInt(100))
+
+	t.Run("valid", func(t *testing.T) {
+		l1Tx := makeBroadcastTx(t, rng, mockBasedInboxAddr, l2Tx, forcedTestChainID)
+		tx, err := UnmarshalForcedInclusionTx(l1Tx, mockBasedInboxAddr, forcedTestChainID)
+		require.NoError(t, err)
+		require.Equal(t, l2Tx.Hash(), tx.Hash())
+	})
+	t.Run("not a direct call", func(t *testing.T) {
+		l1Tx := makeBroadcastTx(t, rng, common.Address{0x01}, l2Tx, forcedTestChainID)
+		_, err := UnmarshalForcedInclusionTx(l1Tx, mockBasedInboxAddr, forcedTestChainID)
+		require.ErrorContains(t, err, "not a direct call")
+	})
+	t.Run("other chain", func(t *testing.T) {
+		l1Tx := makeBroadcastTx(t, rng, mockBasedInboxAddr, l2Tx, big.NewInt(902))
+		_, err := UnmarshalForcedInclusionTx(l1Tx, mockBasedInboxAddr, forcedTestChainID)
+		require.ErrorContains(t, err, "broadcast is for chain")
+	})
+	t.Run("unprotected legacy tx", func(t *testing.T) {
+		l1Tx := makeBroadcastTx(t, rng, mockBasedInboxAddr, testutils.RandomLegacyTxNotProtected(rng), forcedTestChainID)
+		_, err := UnmarshalForcedInclusionTx(l1Tx, mockBasedInboxAddr, forcedTestChainID)
+		require.ErrorContains(t, err, "signed for chain")
+	})
+	t.Run("invalid tx bytes", func(t *testing.T) {
+		data, err := snapshots.LoadBasedInboxABI().Pack("broadcastTx", []byte{0x02, 0xff}, forcedTestChainID)
+		require.NoError(t, err)
+		l1Tx := types.NewTx(&types.LegacyTx{To: &mockBasedInboxAddr, Data: data})
+		_, err = UnmarshalForcedInclusionTx(l1Tx, mockBasedInboxAddr, forcedTestChainID)
+		require.ErrorContains(t, err, "failed to decode forced transaction")
+	})
+}
+
+func TestForcedTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	key := testutils.InsecureRandomKey(rng)
+	a := makeForcedTx(t, key, 0, big.NewInt(100))
+	b := makeForcedTx(t, key, 1, big.NewInt(100))
+
+	l1Txs := types.Transactions{
+		makeBroadcastTx(t, rng, mockBasedInboxAddr, a, forcedTestChainID),
+		testutils.RandomDynamicFeeTx(rng, types.LatestSignerForChainID(big.NewInt(900))),
+		makeBroadcastTx(t, rng, mockBasedInboxAddr, a, forcedTestChainID),
+		makeBroadcastTx(t, rng, mockBasedInboxAddr, b, big.NewInt(902)),
+		makeBroadcastTx(t, rng, mockBasedInboxAddr, b, forcedTestChainID),
+	}
+	receipts := []*types.Receipt{
+		makeBroadcastReceipt(types.ReceiptStatusSuccessful, forcedTestChainID),
+		{Status: types.ReceiptStatusSuccessful},
+		makeBroadcastReceipt(types.ReceiptStatusFailed, forcedTestChainID),
+		makeBroadcastReceipt(types.ReceiptStatusSuccessful, big.NewInt(902)),
+		makeBroadcastReceipt(types.ReceiptStatusSuccessful, forcedTestChainID),
+	}
+	require.Equal(t, []int{0, 4}, ForcedInclusionTxIndices(receipts, mockBasedInboxAddr, forcedTestChainID))
+
+	txs, err := ForcedTxs(l1Txs, receipts, mockBasedInboxAddr, forcedTestChainID)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	require.Equal(t, a.Hash(), txs[0].Hash())
+	require.Equal(t, b.Hash(), txs[1].Hash())
+
+	_, err = ForcedTxs(l1Txs[:1], receipts, mockBasedInboxAddr, forcedTestChainID)
+	require.Error(t, err)
+}
+
+func TestSelectForcedTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{L2ChainID: forcedTestChainID}
+	l2Parent := testutils.RandomL2BlockRef(rng)
+	l1Info := &testutils.MockBlockInfo{InfoBaseFee: big.NewInt(1_000_000_000), InfoBlobBaseFee: big.NewInt(1)}
+	sysCfg := eth.SystemConfig{
+		Scalar:   eth.EncodeScalar(eth.EcotoneScalars{BaseFeeScalar: 1_000_000, BlobBaseFeeScalar: 1}),
+		GasLimit: 100_000,
+	}
+	parentBaseFee := uint64(10)
+
+	key := testutils.InsecureRandomKey(rng)
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	setup := func(balance int64, nonce uint64) *testutils.MockL2Client {
+		l2 := &testutils.MockL2Client{}
+		payload := &eth.ExecutionPayloadEnvelope{ExecutionPayload: &eth.ExecutionPayload{}}
+		(*uint256.Int)(&payload.ExecutionPayload.BaseFeePerGas).SetUint64(parentBaseFee)
+		l2.ExpectPayloadByHash(l2Parent.Hash, payload, nil)
+		l2.ExpectGetProof(sender, []common.Hash(nil), l2Parent.Hash.String(), &eth.AccountResult{
+			Address:  sender,
+			Balance:  (*hexutil.Big)(big.NewInt(balance)),
+			CodeHash: types.EmptyCodeHash,
+			Nonce:    hexutil.Uint64(nonce),
+		}, nil)
+		return l2
+	}
+
+	t.Run("no candidates", func(t *testing.T) {
+		l2 := &testutils.MockL2Client{}
+		out, err := SelectForcedTxs(context.Background(), cfg, l2, l2Parent, l1Info, sysCfg, nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, out)
+		l2.AssertExpectations(t)
+	})
+	t.Run("sequential nonces", func(t *testing.T) {
+		l2 := setup(1e18, 3)
+		candidates := []*types.Transaction{
+			makeForcedTx(t, key, 3, big.NewInt(20)),
+			makeForcedTx(t, key, 5, big.NewInt(20)), // nonce gap
+			makeForcedTx(t, key, 4, big.NewInt(20)),
+		}
+		out, err := SelectForcedTxs(context.Background(), cfg, l2, l2Parent, l1Info, sysCfg, nil, candidates)
+		require.NoError(t, err)
+		require.Len(t, out, 2)
+		txA, _ := candidates[0].MarshalBinary()
+		txC, _ := candidates[2].MarshalBinary()
+		require.Equal(t, []hexutil.Bytes{txA, txC}, out)
+		l2.AssertExpectations(t)
+	})
+	t.Run("deposits bump nonce and use gas", func(t *testing.T) {
+		l2 := setup(1e18, 0)
+		dep, err := types.NewTx(&types.DepositTx{From: sender, Value: big.NewInt(0), Gas: 60_000}).MarshalBinary()
+		require.NoError(t, err)
+		candidates := []*types.Transaction{
+			makeForcedTx(t, key, 1, big.NewInt(20)),
+			makeForcedTx(t, key, 2, big.NewInt(20)), // exceeds the gas left by the deposit
+		}
+		out, err := SelectForcedTxs(context.Background(), cfg, l2, l2Parent, l1Info, sysCfg, []hexutil.Bytes{dep}, candidates)
+		require.NoError(t, err)
+		require.Len(t, out, 1)
+		l2.AssertExpectations(t)
+	})
+	t.Run("fee cap too low", func(t *testing.T) {
+		l2 := setup(1e18, 0)
+		candidates := []*types.Transaction{makeForcedTx(t, key, 0, big.NewInt(19))}
+		out, err := SelectForcedTxs(context.Background(), cfg, l2, l2Parent, l1Info, sysCfg, nil, candidates)
+		require.NoError(t, err)
+		require.Empty(t, out)
+		l2.AssertExpectations(t)
+	})
+	t.Run("insufficient balance", func(t *testing.T) {
+		// covers the gas and value, but not the L1 data fee
+		l2 := setup(21000*20+1, 0)
+		candidates := []*types.Transaction{makeForcedTx(t, key, 0, big.NewInt(20))}
+		out, err := SelectForcedTxs(context.Background(), cfg, l2, l2Parent, l1Info, sysCfg, nil, candidates)
+		require.NoError(t, err)
+		require.Empty(t, out)
+		l2.AssertExpectations(t)
+	})
+}
+
+func TestTrimForcedTxs(t *testing.T) {
+	deposit := hexutil.Bytes{types.DepositTxType, 0x01}
+	forcedA := hexutil.Bytes{types.DynamicFeeTxType, 0x01}
+	forcedB := hexutil.Bytes{types.DynamicFeeTxType, 0x02}
+	seqTx := hexutil.Bytes{types.DynamicFeeTxType, 0x03}
+
+	require.Equal(t, []hexutil.Bytes{seqTx},
+		trimForcedTxs([]hexutil.Bytes{deposit}, []hexutil.Bytes{seqTx}))
+	require.Equal(t, []hexutil.Bytes{seqTx},
+		trimForcedTxs([]hexutil.Bytes{deposit, forcedA, forcedB}, []hexutil.Bytes{forcedA, forcedB, seqTx}))
+	require.Equal(t, []hexutil.Bytes{forcedB, forcedA, seqTx},
+		trimForcedTxs([]hexutil.Bytes{deposit, forcedA, forcedB}, []hexutil.Bytes{forcedB, forcedA, seqTx}))
+	require.Equal(t, []hexutil.Bytes{forcedA},
+		trimForcedTxs([]hexutil.Bytes{deposit, forcedA, forcedB}, []hexutil.Bytes{forcedA}))
+}
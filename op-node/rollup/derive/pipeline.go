@@ -44,12 +44,12 @@ type L2Source interface {
 	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
 	L2BlockRefByHash(ctx context.Context, l2Hash common.Hash) (eth.L2BlockRef, error)
 	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
-	SystemConfigL2Fetcher
+	AttributesL2Fetcher
 }
 
 type ElectionClient interface {
 	GetLastWinnerInCurrentEpoch() eth.ElectionWinner
-	GetElectionWinner(time uint64) eth.ElectionWinner
+	ElectionWinnersProvider
 }
 
 // DerivationPipeline is updated with new L1 data, and the Step() function can be iterated on to generate attributes
@@ -1,5 +1,7 @@
 package driver
 
+import "github.com/ethereum/go-ethereum/common"
+
 type Config struct {
 	// VerifierConfDepth is the distance to keep from the L1 head when reading L1 data for L2 derivation.
 	VerifierConfDepth uint64 `json:"verifier_conf_depth"`
@@ -20,4 +22,19 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerElectionAddress is the address the sequencer is elected with.
+	// If set, the sequencer only builds blocks in the slots won by this address,
+	// and follows the unsafe blocks of the elected sequencer in other slots.
+	// If zero, the sequencer builds all blocks.
+	SequencerElectionAddress common.Address `json:"sequencer_election_address"`
+
+	// SequencerPermissionless is true when the sequencer should also build blocks in the permissionless slots.
+	// Any sequencer may build these, and the block of the slot whose batch is first included on L1 is canonical,
+	// so the unsafe blocks of a permissionless slot may be reorged out.
+	SequencerPermissionless bool `json:"sequencer_permissionless"`
+
+	// FakeOperators is true when the operators of the lookahead validators are read from their pubkeys,
+	// instead of the operator registry of the rollup config. For devnets with a fake lookahead only.
+	FakeOperators bool `json:"fake_operators"`
 }
@@ -2,6 +2,8 @@ package driver
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -20,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/interop"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/liveness"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
@@ -33,6 +36,9 @@ var (
 	ErrSequencerAlreadyStopped = sequencing.ErrSequencerAlreadyStopped
 )
 
+// operatorCacheSize is the number of resolved validator operators to cache, enough for the proposers of a few epochs.
+const operatorCacheSize = 1024
+
 type Metrics interface {
 	RecordPipelineReset()
 	RecordPublishingError()
@@ -59,6 +65,8 @@ type Metrics interface {
 	L1FetcherMetrics
 	event.Metrics
 	sequencing.Metrics
+	election.Metrics
+	liveness.Metrics
 }
 
 type L1Chain interface {
@@ -153,6 +161,14 @@ type SequencerStateListener interface {
 	SequencerStopped() error
 }
 
+// ElectionDB persists the election winners and the slot liveness records,
+// and provides the past elections to check against L1 reorgs.
+type ElectionDB interface {
+	election_client.ElectionDB
+	election.ElectionHistory
+	liveness.DB
+}
+
 type Drain interface {
 	Drain() error
 }
@@ -176,10 +192,11 @@ func NewDriver(
 	metrics Metrics,
 	sequencerStateListener sequencing.SequencerStateListener,
 	safeHeadListener rollup.SafeHeadListener,
+	electionDB ElectionDB,
 	syncCfg *sync.Config,
 	sequencerConductor conductor.SequencerConductor,
 	altDA AltDAIface,
-) *Driver {
+) (*Driver, error) {
 	driverCtx, driverCancel := context.WithCancel(context.Background())
 
 	opts := event.DefaultRegisterOpts()
@@ -201,13 +218,28 @@ func NewDriver(
 	l1 = NewMeteredL1Fetcher(l1Tracker, metrics)
 	verifConfDepth := confdepth.NewConfDepth(driverCfg.VerifierConfDepth, statusTracker.L1Head, l1)
 
-	elec := election.NewElection(beaconClient, l2Client, l1Client, log, cfg)
-	electionDeriver := election.NewElectionDeriver(driverCtx, beaconClient, elec, log)
+	var operators election.OperatorResolver = &election.FakeOperatorResolver{}
+	if driverCfg.FakeOperators {
+		log.Warn("Reading validator operators from validator pubkeys, for devnets only")
+	} else {
+		registry, err := election.NewOperatorResolver(l1Client, cfg, operatorCacheSize)
+		if err != nil {
+			driverCancel()
+			return nil, fmt.Errorf("cannot derive election winners: %w", err)
+		}
+		operators = registry
+	}
+	elec := election.NewElection(beaconClient, l2Client, l1Client, operators, log, metrics, cfg)
+	elec.SetTracer(election.NewLogTracer(log, slog.LevelDebug))
+	electionDeriver := election.NewElectionDeriver(driverCtx, beaconClient, elec, l1, l2, electionDB, log, metrics)
 	sys.Register("election", electionDeriver, opts)
 
-	electionStore := election_client.NewElectionStore(log)
+	electionStore := election_client.NewElectionStore(driverCtx, log, electionDB, elec, beaconClient)
 	sys.Register("election-store", electionStore, opts)
 
+	electionFeed := election_client.NewElectionFeed(log)
+	sys.Register("election-feed", electionFeed, opts)
+
 	ec := engine.NewEngineController(l2, log, metrics, cfg, syncCfg,
 		sys.Register("engine-controller", nil, opts))
 
@@ -229,6 +261,8 @@ func NewDriver(
 		attributes.NewAttributesHandler(log, cfg, driverCtx, l2), opts)
 
 	electionClient := election_client.NewElectionClient(electionStore)
+	livenessTracker := liveness.NewTracker(driverCtx, log, cfg, l1, electionClient, electionDB, metrics)
+	sys.Register("liveness", livenessTracker, opts)
 	derivationPipeline := derive.NewDerivationPipeline(log, cfg, verifConfDepth, l1Blobs, altDA, l2, electionClient, metrics)
 
 	sys.Register("pipeline",
@@ -262,7 +296,7 @@ func NewDriver(
 		sequencerConfDepth := confdepth.NewConfDepth(driverCfg.SequencerConfDepth, statusTracker.L1Head, l1)
 		findL1Origin := sequencing.NewL1OriginSelector(log, cfg, sequencerConfDepth)
 		sequencer = sequencing.NewSequencer(driverCtx, log, cfg, attrBuilder, findL1Origin,
-			sequencerStateListener, sequencerConductor, asyncGossiper, electionClient, metrics)
+			sequencerStateListener, sequencerConductor, asyncGossiper, electionClient, driverCfg.SequencerElectionAddress, driverCfg.SequencerPermissionless, metrics)
 		sys.Register("sequencer", sequencer, opts)
 	} else {
 		sequencer = sequencing.DisabledSequencer{}
@@ -271,6 +305,9 @@ func NewDriver(
 	driverEmitter := sys.Register("driver", nil, opts)
 	driver := &Driver{
 		election:         electionDeriver,
+		electionClient:   electionClient,
+		electionFeed:     electionFeed,
+		liveness:         livenessTracker,
 		statusTracker:    statusTracker,
 		SyncDeriver:      syncDeriver,
 		sched:            schedDeriv,
@@ -292,5 +329,5 @@ func NewDriver(
 		altSync:          altSync,
 	}
 
-	return driver
+	return driver, nil
 }
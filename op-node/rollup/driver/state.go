@@ -8,15 +8,18 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	gethevent "github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/clsync"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election_client"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/liveness"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sequencing"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/sync"
@@ -28,11 +31,17 @@ type SyncStatus = eth.SyncStatus
 
 type ElectionTracker interface {
 	GetElectionWinners(ctx context.Context, epoch uint64) ([]eth.ElectionWinner, error)
+	EpochSchedule(ctx context.Context) (*eth.EpochSchedule, error)
+	FallbackListAt(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error)
+	LatestTickets(ctx context.Context) (*eth.ElectionTickets, error)
 }
 
 type Driver struct {
-	statusTracker SyncStatusTracker
-	election      ElectionTracker
+	statusTracker  SyncStatusTracker
+	election       ElectionTracker
+	electionClient *election_client.ElectionClient
+	electionFeed   *election_client.ElectionFeed
+	liveness       *liveness.Tracker
 
 	*SyncDeriver
 
@@ -152,6 +161,47 @@ func (s *Driver) GetElectionWinners(ctx context.Context, epoch uint64) ([]eth.El
 	return s.election.GetElectionWinners(ctx, epoch)
 }
 
+// ElectionWinnerAt returns the election winner of the slot that the given L2 timestamp falls in.
+// Only the winners kept in memory or stored in the election database are served: the lookup is exposed
+// to untrusted RPC callers, and must not trigger recomputing the election of an arbitrary epoch.
+func (s *Driver) ElectionWinnerAt(ctx context.Context, time uint64) (eth.ElectionWinner, error) {
+	winner, ok := s.electionClient.PersistedElectionWinner(time)
+	if !ok {
+		return eth.ElectionWinner{}, fmt.Errorf("election winner at time %d is not known", time)
+	}
+	return winner, nil
+}
+
+func (s *Driver) EpochSchedule(ctx context.Context) (*eth.EpochSchedule, error) {
+	return s.election.EpochSchedule(ctx)
+}
+
+func (s *Driver) ElectionFallbackList(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error) {
+	return s.election.FallbackListAt(ctx, l1BlockNum)
+}
+
+func (s *Driver) ElectionTickets(ctx context.Context) (*eth.ElectionTickets, error) {
+	return s.election.LatestTickets(ctx)
+}
+
+// SubscribeElections streams the elections the node computes to the channel.
+func (s *Driver) SubscribeElections(ch chan<- *eth.EpochElection) gethevent.Subscription {
+	return s.electionFeed.Subscribe(ch)
+}
+
+func (s *Driver) GetSequencerLiveness(ctx context.Context, operator common.Address) (*eth.SequencerLiveness, error) {
+	return s.liveness.SequencerLiveness(operator), nil
+}
+
+func (s *Driver) GetMissedSlotProof(ctx context.Context, l1BlockNum uint64) (*eth.MissedSlotProof, error) {
+	return s.liveness.MissedSlotProof(ctx, l1BlockNum)
+}
+
+// ElectionClient returns the client to look up the elected sequencer of a slot.
+func (s *Driver) ElectionClient() *election_client.ElectionClient {
+	return s.electionClient
+}
+
 // the eventLoop responds to L1 changes and internal timers to produce L2 blocks.
 func (s *Driver) eventLoop() {
 	defer s.wg.Done()
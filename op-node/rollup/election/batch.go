@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/batch-contracts/bindings/BatchCheckSeqConfig"
 	"github.com/ethereum-optimism/optimism/op-node/batch-contracts/bindings/BatchRandomTicketInstruction"
 	BatchTicketAccounting "github.com/ethereum-optimism/optimism/op-node/batch-contracts/bindings/BatchTicketAccounting"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -21,7 +22,7 @@ type RandomTicketInstructionRetdata struct {
 	Winner    common.Address
 }
 
-func (e *Election) GetBatchTicketAccounting(ctx context.Context, lookaheadAddresses []common.Address, blockNumber string) ([]*big.Int, error) {
+func (e *Election) GetBatchTicketAccounting(ctx context.Context, lookaheadAddresses []common.Address, block rpcblock.Block) ([]*big.Int, error) {
 	bin := BatchTicketAccounting.BatchTicketAccountingMetaData.Bin
 	abiJson := BatchTicketAccounting.BatchTicketAccountingMetaData.ABI
 
@@ -43,7 +44,7 @@ func (e *Election) GetBatchTicketAccounting(ctx context.Context, lookaheadAddres
 	creationCode := "0x" + hex.EncodeToString(append(common.FromHex(bin), constructorArgs...))
 
 	// NOTE: Should we be using latest here?
-	encodedReturnData, err := e.l2.Call(ctx, toBatchCallMsg(common.Address{}, creationCode), blockNumber)
+	encodedReturnData, err := e.call(ctx, e.l2, "ticket_accounting", toBatchCallMsg(common.Address{}, creationCode), block)
 
 	if err != nil {
 		// If we cant determine ticket accounting, we cant determine the winners
@@ -86,7 +87,7 @@ func (e *Election) GetBatchTicketAccounting(ctx context.Context, lookaheadAddres
 	return ticketCountPerValidator, nil
 }
 
-func (e *Election) GetBatchRandomTicketInstruction(ctx context.Context, timestamps []uint64, blockNumber string) ([]RandomTicketInstructionRetdata, error) {
+func (e *Election) GetBatchRandomTicketInstruction(ctx context.Context, timestamps []uint64, block rpcblock.Block) ([]RandomTicketInstructionRetdata, error) {
 	bin := BatchRandomTicketInstruction.BatchRandomTicketInstructionMetaData.Bin
 	abiJson := BatchRandomTicketInstruction.BatchRandomTicketInstructionMetaData.ABI
 
@@ -109,7 +110,7 @@ func (e *Election) GetBatchRandomTicketInstruction(ctx context.Context, timestam
 
 	creationCode := "0x" + hex.EncodeToString(append(common.FromHex(bin), constructorArgs...))
 
-	encodedReturnData, err := e.l2.Call(ctx, toBatchCallMsg(common.Address{}, creationCode), blockNumber)
+	encodedReturnData, err := e.call(ctx, e.l2, "random_ticket", toBatchCallMsg(common.Address{}, creationCode), block)
 
 	if err != nil {
 		return []RandomTicketInstructionRetdata{}, err
@@ -184,7 +185,7 @@ func (e *Election) GetBatchRandomTicketInstruction(ctx context.Context, timestam
 
 }
 
-func (e *Election) GetBatchCheckSeqConfig(ctx context.Context, potentialWinners []common.Address, blockNumber string) ([]bool, error) {
+func (e *Election) GetBatchCheckSeqConfig(ctx context.Context, potentialWinners []common.Address, block rpcblock.Block) ([]bool, error) {
 	bin := BatchCheckSeqConfig.BatchCheckSeqConfigMetaData.Bin
 	abiJson := BatchCheckSeqConfig.BatchCheckSeqConfigMetaData.ABI
 
@@ -206,7 +207,7 @@ func (e *Election) GetBatchCheckSeqConfig(ctx context.Context, potentialWinners
 	creationCode := "0x" + hex.EncodeToString(append(common.FromHex(bin), constructorArgs...))
 
 	// NOTE: Should we be using latest here?
-	encodedReturnData, err := e.l1.Call(ctx, toBatchCallMsg(common.Address{}, creationCode), blockNumber)
+	encodedReturnData, err := e.call(ctx, e.l1, "check_seq_config", toBatchCallMsg(common.Address{}, creationCode), block)
 
 	if err != nil {
 		// If we cant determien the config results, we cant determine the winners
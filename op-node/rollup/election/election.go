@@ -1,16 +1,23 @@
 package election
 
 import (
+	"cmp"
 	"context"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math/big"
+	"slices"
 	"strings"
+	"time"
 
 	// is there a better place to put this? making it its own package is difficult because of go modules
 	// and us being a private fork
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -39,54 +46,253 @@ type BeaconClient interface {
 	GetEpochNumber(ctx context.Context, timestamp uint64) (uint64, error)
 	GetSlotNumber(ctx context.Context, timestamp uint64) (uint64, error)
 	GetTimeFromSlot(ctx context.Context, slot uint64) (uint64, error)
+	GetSecondsPerSlot(ctx context.Context) (uint64, error)
 }
 
 type RpcClient interface {
-	Call(ctx context.Context, callMsg map[string]interface{}, blockNumber string) (string, error)
+	Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error)
+}
+
+// ChainClient reads the contract state and the blocks of the chain that election inputs are read from.
+type ChainClient interface {
+	RpcClient
+	InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
+	InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error)
 }
 
 type Election struct {
 	bc BeaconClient
-	l2 RpcClient
-	l1 RpcClient
+	l2 ChainClient
+	l1 ChainClient
+
+	operators OperatorResolver
 
-	log log.Logger
+	log     log.Logger
+	metrics Metrics
+	tracer  Tracer
 
 	cfg *rollup.Config
 }
 
-func NewElection(bc BeaconClient, l2 RpcClient, l1 RpcClient, log log.Logger, cfg *rollup.Config) *Election {
+// NewElection creates an Election. A nil metrics records no metrics.
+func NewElection(bc BeaconClient, l2 ChainClient, l1 ChainClient, operators OperatorResolver, log log.Logger, metrics Metrics, cfg *rollup.Config) *Election {
+	if metrics == nil {
+		metrics = NoopMetrics{}
+	}
 	return &Election{
-		bc:  bc,
-		l2:  l2,
-		l1:  l1,
-		log: log,
-		cfg: cfg,
+		bc:        bc,
+		l2:        l2,
+		l1:        l1,
+		operators: operators,
+		log:       log,
+		metrics:   metrics,
+		tracer:    NoopTracer{},
+		cfg:       cfg,
 	}
 }
 
-// l2UnsafeBlock is passed in as a hexadecimal string
-func (e *Election) GetWinnersAtEpoch(ctx context.Context, epoch uint64, l2UnsafeBlock string, l2UnsafeParentTime uint64, l1UnsafeBlock string) ([]*eth.ElectionWinner, error) {
-	var operatorAddresses []common.Address
+// SetTracer sets the tracer of the steps of the elections.
+func (e *Election) SetTracer(tracer Tracer) {
+	e.tracer = tracer
+}
 
+// lookahead fetches the lookahead of the epoch from the beacon node.
+func (e *Election) lookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	start := time.Now()
 	resp, err := e.bc.GetLookahead(ctx, epoch)
+	e.metrics.RecordElectionLookahead(time.Since(start))
+	e.tracer.OnElectionStep(StageLookahead, time.Since(start), err)
+	if errors.Is(err, sources.ErrLookaheadMismatch) {
+		e.log.Error("Lookahead failed verification", "epoch", epoch, "err", err)
+		e.metrics.RecordElectionError(StageLookaheadMismatch)
+	} else if err != nil {
+		e.metrics.RecordElectionError(StageLookahead)
+	}
+	return resp, err
+}
 
+// call makes an eth_call of the election on the chain, and records its duration under the given name.
+func (e *Election) call(ctx context.Context, chain RpcClient, name string, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
+	start := time.Now()
+	res, err := chain.Call(ctx, callMsg, block)
+	e.metrics.RecordElectionCall(name, time.Since(start))
+	e.tracer.OnElectionStep(name, time.Since(start), err)
 	if err != nil {
-		return []*eth.ElectionWinner{}, err
+		e.metrics.RecordElectionError(StageCall)
 	}
+	return res, err
+}
 
-	validators := resp.Data
+// l2UnsafeBlock is passed in as a hexadecimal string
+// Along with the winners, the ticket count of each operator of the lookahead the election was computed with is returned.
+func (e *Election) GetWinnersAtEpoch(ctx context.Context, epoch uint64, l2UnsafeBlock rpcblock.Block, l2UnsafeParentTime uint64, l1UnsafeBlock rpcblock.Block) (winners []*eth.ElectionWinner, tickets map[common.Address]uint64, err error) {
+	start := time.Now()
+	defer func() {
+		e.tracer.OnElection(epoch, time.Since(start), len(winners), err)
+	}()
+	resp, err := e.lookahead(ctx, epoch)
 
-	// TODO(spire): get operation addresses for each validator somehow
-	for _, validator := range validators {
-		address := common.BytesToAddress(validator.Pubkey[:20])
-		operatorAddresses = append(operatorAddresses, address)
+	if err != nil {
+		return []*eth.ElectionWinner{}, nil, err
+	}
+
+	return e.winnersFromLookahead(ctx, resp.Data, l2UnsafeBlock, l1UnsafeBlock)
+}
+
+// EpochAt returns the epoch of the L1 slot at the given timestamp.
+func (e *Election) EpochAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	return e.bc.GetEpochNumber(ctx, timestamp)
+}
+
+// RecomputeElection computes the election of an epoch from L1 and L2 state alone, for epochs that the node
+// did not observe live, e.g. when syncing from genesis or after EL sync.
+//
+// The election inputs are read at pinned blocks, derived from the start time of the epoch:
+//   - the lookahead, fallback list and sequencer configs are read at the last L1 block at or before the last slot
+//     of the previous epoch.
+//   - the ticket accounting is read at the last L2 block at or before the last slot of the previous epoch.
+//
+// These are the same blocks the ElectionDeriver computes the election at when following the tip of the chain,
+// so every node computes the same winners, regardless of when it started.
+func (e *Election) RecomputeElection(ctx context.Context, epoch uint64) (election *eth.EpochElection, err error) {
+	start := time.Now()
+	defer func() {
+		var winners int
+		if election != nil {
+			winners = len(election.Winners)
+		}
+		e.tracer.OnElection(epoch, time.Since(start), winners, err)
+	}()
+	resp, err := e.lookahead(ctx, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lookahead of epoch %d: %w", epoch, err)
+	}
+	if len(resp.Data) == 0 {
+		return nil, fmt.Errorf("empty lookahead for epoch %d", epoch)
+	}
+	firstSlot := slices.MinFunc(resp.Data, func(a, b *eth.Validator) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	}).Slot
+	epochStart, err := e.bc.GetTimeFromSlot(ctx, uint64(firstSlot))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get start time of epoch %d: %w", epoch, err)
+	}
+	slotTime, err := e.bc.GetSecondsPerSlot(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get L1 slot time: %w", err)
+	}
+	if epochStart < slotTime {
+		return nil, fmt.Errorf("epoch %d starts at genesis, no previous slot to read election inputs at", epoch)
+	}
+	pinnedTime := epochStart - slotTime
+
+	l1Block, err := e.l1BlockAtTime(ctx, pinnedTime, slotTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find L1 block of epoch %d election: %w", epoch, err)
+	}
+	l2Block, err := e.l2BlockAtTime(ctx, pinnedTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find L2 block of epoch %d election: %w", epoch, err)
+	}
+
+	e.log.Info("Recomputing election", "epoch", epoch, "l1", l1Block, "l2", l2Block)
+	winners, tickets, err := e.winnersFromLookahead(ctx, resp.Data, rpcblock.ByHash(l2Block.Hash), rpcblock.ByHash(l1Block.Hash))
+	if err != nil {
+		return nil, err
+	}
+	return &eth.EpochElection{
+		Epoch:   epoch,
+		L1Block: l1Block,
+		L2Block: l2Block,
+		Winners: winners,
+		Tickets: tickets,
+	}, nil
+}
+
+// l1BlockAtTime finds the last L1 block with a timestamp at or before the given time.
+// L1 blocks are at least one slot apart, which bounds the range of block numbers to search.
+// The search is anchored at the L1 head rather than at genesis, so only blocks between the given time and the head
+// are fetched. The fault proof program can only look up L1 blocks by walking back from its L1 head.
+func (e *Election) l1BlockAtTime(ctx context.Context, timestamp uint64, slotTime uint64) (eth.BlockID, error) {
+	head, err := e.l1.InfoByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head.Time() < timestamp {
+		return eth.BlockID{}, fmt.Errorf("L1 head %d at time %d has not reached time %d yet", head.NumberU64(), head.Time(), timestamp)
+	}
+
+	// Every slot between the time and the head holds at most one block, so this many blocks back is at or before the time.
+	lo := e.cfg.Genesis.L1.Number
+	if back := (head.Time() - timestamp + slotTime - 1) / slotTime; head.NumberU64()-lo > back {
+		lo = head.NumberU64() - back
+	}
+	found, err := e.l1.InfoByNumber(ctx, lo)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to fetch L1 block %d: %w", lo, err)
+	}
+	if found.Time() > timestamp {
+		// Only the genesis block can be after the time, there is no earlier block to use.
+		return eth.InfoToL1BlockRef(found).ID(), nil
+	}
+	// Every missed slot after the found block moves the last block at or before the time one block closer to it.
+	hi := min(head.NumberU64(), lo+(timestamp-found.Time())/slotTime) + 1
+	// Binary search for the last block at or before the time, with lo at or before the time and hi after it.
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		info, err := e.l1.InfoByNumber(ctx, mid)
+		if err != nil {
+			return eth.BlockID{}, fmt.Errorf("failed to fetch L1 block %d: %w", mid, err)
+		}
+		if info.Time() <= timestamp {
+			lo, found = mid, info
+		} else {
+			hi = mid
+		}
+	}
+	return eth.InfoToL1BlockRef(found).ID(), nil
+}
+
+// l2BlockAtTime returns the last L2 block with a timestamp at or before the given time.
+// With L2 blocks shorter than the L1 slot, this is the first L2 block of the slot starting at the given time.
+func (e *Election) l2BlockAtTime(ctx context.Context, timestamp uint64) (eth.BlockID, error) {
+	num := e.cfg.Genesis.L2.Number
+	if timestamp > e.cfg.Genesis.L2Time {
+		target, err := e.cfg.TargetBlockNumber(timestamp)
+		if err != nil {
+			return eth.BlockID{}, err
+		}
+		num = target
+	}
+	info, err := e.l2.InfoByNumber(ctx, num)
+	if err != nil {
+		return eth.BlockID{}, fmt.Errorf("failed to fetch L2 block %d: %w", num, err)
+	}
+	return eth.BlockID{Hash: info.Hash(), Number: info.NumberU64()}, nil
+}
+
+// winnersFromLookahead runs the election for the proposers of the lookahead,
+// with the ticket accounting read at the given L2 block and the fallback list at the given L1 block.
+// It returns the winners, and the ticket count of each operator before the election burned any.
+func (e *Election) winnersFromLookahead(ctx context.Context, validators []*eth.Validator, l2UnsafeBlock rpcblock.Block, l1UnsafeBlock rpcblock.Block) ([]*eth.ElectionWinner, map[common.Address]uint64, error) {
+	start := time.Now()
+	operatorAddresses, err := e.operators.OperatorsOf(ctx, validators, l1UnsafeBlock)
+	e.metrics.RecordElectionCall("operators_of", time.Since(start))
+	e.tracer.OnElectionStep(StageOperators, time.Since(start), err)
+	if err != nil {
+		e.metrics.RecordElectionError(StageOperators)
+		return []*eth.ElectionWinner{}, nil, fmt.Errorf("failed to resolve validator operators: %w", err)
 	}
 
 	e.log.Info("Checking ticket count per validator at L2 unsafe block", "l2UnsafeBlock", l2UnsafeBlock)
 	ticketCountPerValidator, err := e.GetBatchTicketAccounting(ctx, operatorAddresses, l2UnsafeBlock)
+	if err != nil {
+		log.Error("Failed to get ticket count per validator", "err", err)
+		return []*eth.ElectionWinner{}, nil, err
+	}
 
 	tickets := make(map[common.Address]*big.Int)
+	ticketCounts := make(map[common.Address]uint64)
 
 	for i, operatorAddress := range operatorAddresses {
 		// Already set
@@ -95,19 +301,14 @@ func (e *Election) GetWinnersAtEpoch(ctx context.Context, epoch uint64, l2Unsafe
 		}
 
 		tickets[operatorAddress] = ticketCountPerValidator[i]
+		ticketCounts[operatorAddress] = ticketCountPerValidator[i].Uint64()
 	}
 
 	e.log.Info("Ticket count per validator operator", "ticketCountPerValidator", tickets)
 
-	if err != nil {
-		log.Error("Failed to get ticket count per validator", "err", err)
-		return []*eth.ElectionWinner{}, err
-	}
-
 	fallbacklist, err := e.GetElectionFallbackList(ctx, l1UnsafeBlock)
 	if err != nil {
-		log.Crit("Failed to get fallback list", "err", err)
-		return []*eth.ElectionWinner{}, err
+		return []*eth.ElectionWinner{}, nil, fmt.Errorf("failed to get fallback list: %w", err)
 	}
 
 	e.log.Info("Fallback list", "fallbacklist", fallbacklist)
@@ -118,7 +319,7 @@ func (e *Election) GetWinnersAtEpoch(ctx context.Context, epoch uint64, l2Unsafe
 	for _, validator := range validators {
 		time, err := e.bc.GetTimeFromSlot(ctx, uint64(validator.Slot))
 		if err != nil {
-			return []*eth.ElectionWinner{}, err
+			return []*eth.ElectionWinner{}, nil, err
 		}
 
 		winner := eth.ElectionWinner{
@@ -131,10 +332,18 @@ func (e *Election) GetWinnersAtEpoch(ctx context.Context, epoch uint64, l2Unsafe
 		electionWinners = append(electionWinners, &winner)
 	}
 
-	return e.HandleInstructions(ctx, fallbacklist, electionWinners, operatorAddresses, tickets, l2UnsafeBlock, l1UnsafeBlock)
+	// The instructions burn the tickets of the map, the ticket counts are kept as they were before the election
+	start = time.Now()
+	electionWinners, err = e.HandleInstructions(ctx, fallbacklist, electionWinners, operatorAddresses, tickets, l2UnsafeBlock, l1UnsafeBlock)
+	e.tracer.OnElectionStep(StageInstruction, time.Since(start), err)
+	if err != nil {
+		e.metrics.RecordElectionError(StageInstruction)
+		return []*eth.ElectionWinner{}, nil, err
+	}
+	return electionWinners, ticketCounts, nil
 }
 
-func (e *Election) GetElectionFallbackList(ctx context.Context, blockNumber string) ([]uint8, error) {
+func (e *Election) GetElectionFallbackList(ctx context.Context, block rpcblock.Block) ([]uint8, error) {
 	sysConfig := e.cfg.L1SystemConfigAddress
 
 	parsedSysConfigABI, err := abi.JSON(strings.NewReader(SYSTEM_CONFIG_ABI))
@@ -150,7 +359,7 @@ func (e *Election) GetElectionFallbackList(ctx context.Context, blockNumber stri
 
 	calldata := "0x" + hex.EncodeToString(calldataBytes)
 
-	encodedReturnData, err := e.l1.Call(ctx, toCallMsg(sysConfig, calldata), blockNumber)
+	encodedReturnData, err := e.call(ctx, e.l1, "fallback_list", toCallMsg(sysConfig, calldata), block)
 	if err != nil {
 		return nil, err
 	}
@@ -2,51 +2,130 @@ package election
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/engine"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/finality"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/status"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 )
 
 type ElectionWinners struct {
 	winners []*eth.ElectionWinner
 	epoch   uint64
+	// The L1 and L2 blocks the election was computed at
+	// The election inputs are read at these blocks by hash, so that a reorg between the head event
+	// and the contract calls cannot mix the state of different chains into one election.
+	l1Block eth.BlockID
+	l2Block eth.BlockID
+	// The ticket count of each operator the election was computed with
+	tickets map[common.Address]uint64
+}
+
+func (w *ElectionWinners) election() *eth.EpochElection {
+	return &eth.EpochElection{
+		Epoch:   w.epoch,
+		L1Block: w.l1Block,
+		L2Block: w.l2Block,
+		Winners: w.winners,
+		Tickets: w.tickets,
+	}
+}
+
+// L1Fetcher fetches the canonical L1 blocks that past elections are checked against.
+type L1Fetcher interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+}
+
+// L2Fetcher fetches the canonical L2 blocks that the ticket accounting of past elections is checked against.
+type L2Fetcher interface {
+	L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error)
+}
+
+// ElectionHistory provides the persisted elections of past epochs.
+type ElectionHistory interface {
+	ElectionAtEpoch(ctx context.Context, epoch uint64) (*eth.EpochElection, error)
+	ElectionsSinceL1(ctx context.Context, l1BlockNum uint64) ([]*eth.EpochElection, error)
 }
 
 type ElectionDeriver struct {
 	client   BeaconClient
 	election *Election
+	l1       L1Fetcher
+	l2       L2Fetcher
+	history  ElectionHistory
 	log      log.Logger
+	metrics  Metrics
 	emitter  event.Emitter
 	ctx      context.Context
 
 	l2Finalized eth.L2BlockRef
 	l2Unsafe    eth.L2BlockRef
+	// The latest L2 unsafe head, also past the block the election is computed at, to detect L2 reorgs
+	l2Head      eth.L2BlockRef
 	l1Unsafe    eth.L1BlockRef
+	l1Finalized eth.L1BlockRef
 
 	electionWinners []ElectionWinners
 
+	// Whether the elections since the finalized L1 block were checked against the canonical L1 and L2 chains.
+	// The check runs at startup, to detect reorgs that happened while the node was offline, and on every L1 or L2 reorg.
+	reorgChecked bool
+	// reorgCheck requests a reorg check from the background worker. Requests made while a check is pending are merged.
+	reorgCheck chan struct{}
+
 	// The timestamp of last slot in current epoch
 	lastSlotTime uint64
 
 	mu sync.Mutex
 }
 
-// TODO(spire): add this to network config or remove entirely.
-const L1BlockTime = 12
+// NewElectionDeriver creates an ElectionDeriver, that checks the past elections for reorgs in the background until ctx is done.
+func NewElectionDeriver(ctx context.Context, client BeaconClient, election *Election, l1 L1Fetcher, l2 L2Fetcher, history ElectionHistory, log log.Logger, metrics Metrics) *ElectionDeriver {
+	ed := &ElectionDeriver{
+		client:     client,
+		election:   election,
+		l1:         l1,
+		l2:         l2,
+		history:    history,
+		log:        log,
+		metrics:    metrics,
+		ctx:        ctx,
+		reorgCheck: make(chan struct{}, 1),
+	}
+	go ed.run()
+	return ed
+}
 
-func NewElectionDeriver(ctx context.Context, client BeaconClient, election *Election, log log.Logger) *ElectionDeriver {
+// run runs the requested reorg checks, so that the L1 and L2 block lookups and the re-run elections
+// do not block the event loop.
+func (ed *ElectionDeriver) run() {
+	for {
+		select {
+		case <-ed.ctx.Done():
+			return
+		case <-ed.reorgCheck:
+			ed.checkReorgedElections()
+		}
+	}
+}
 
-	return &ElectionDeriver{
-		client:   client,
-		election: election,
-		log:      log,
-		ctx:      ctx,
+// requestReorgCheck requests a check of the past elections for reorgs. The caller must hold the lock.
+func (ed *ElectionDeriver) requestReorgCheck() {
+	select {
+	case ed.reorgCheck <- struct{}{}:
+	default:
+		// A check is pending already, and runs with the latest state.
 	}
 }
 
@@ -60,10 +139,32 @@ func (ed *ElectionDeriver) OnEvent(ev event.Event) bool {
 
 	switch x := ev.(type) {
 	case status.L1UnsafeEvent:
+		prev := ed.l1Unsafe
 		ed.l1Unsafe = x.L1Unsafe
+		if prev != (eth.L1BlockRef{}) && x.L1Unsafe.Hash != prev.Hash && x.L1Unsafe.ParentHash != prev.Hash {
+			ed.log.Info("L1 head does not build on previous head, checking elections for reorgs", "prev", prev, "head", x.L1Unsafe)
+			ed.reorgChecked = false
+		}
+		if !ed.reorgChecked {
+			ed.requestReorgCheck()
+		}
 		ed.ProcessNewBlock()
+	case finality.FinalizeL1Event:
+		ed.l1Finalized = x.FinalizedL1
 	case engine.PendingSafeUpdateEvent:
-		ed.l2Unsafe = x.Unsafe
+		prev := ed.l2Head
+		ed.l2Head = x.Unsafe
+		if prev != (eth.L2BlockRef{}) && !extendsL2(prev, x.Unsafe) {
+			ed.log.Info("L2 unsafe head does not build on previous head, checking elections for reorgs", "prev", prev, "head", x.Unsafe)
+			ed.reorgChecked = false
+			ed.requestReorgCheck()
+		}
+		// With L2 blocks shorter than the L1 slot, the L2 chain moves past the start of the last slot of the epoch
+		// before the L1 block of that slot is seen. The election is computed at the first L2 block of the last slot,
+		// so later blocks of the slot are not tracked.
+		if ed.lastSlotTime == 0 || x.Unsafe.Time <= ed.lastSlotTime {
+			ed.l2Unsafe = x.Unsafe
+		}
 		ed.ProcessNewBlock()
 	case engine.ForkchoiceUpdateEvent:
 		// optimization: only emit event if the finalized L2 head has changed
@@ -108,8 +209,16 @@ func (ed *ElectionDeriver) ProcessNewBlock() {
 
 	ed.log.Debug("Processing", "l1Unsafe", ed.l1Unsafe.Number, "time", ed.l1Unsafe.Time, "l2Unsafe", ed.l2Unsafe.Number, "time", ed.l2Unsafe.Time, "lastSlotTime", ed.lastSlotTime)
 
+	slotTime, err := ed.client.GetSecondsPerSlot(ed.ctx)
+	if err != nil {
+		ed.log.Warn("Failed to get L1 slot time", "err", err)
+		ed.metrics.RecordElectionError(StageSlotTime)
+		ed.emitter.Emit(rollup.ElectionErrorEvent{Err: err})
+		return
+	}
+
 	lastBlockNumberInEpoch := ed.l1Unsafe.Number
-	nextEpochTime := ed.l1Unsafe.Time + L1BlockTime
+	nextEpochTime := ed.l1Unsafe.Time + slotTime
 	if ed.lastSlotTime != 0 && ed.l1Unsafe.Time != ed.lastSlotTime {
 		ed.log.Warn("Slot times mismatch, L1 missed slot detected", "ed.l1Unsafe", ed.l1Unsafe, "time", ed.l1Unsafe.Time, "lastSlotTime", ed.lastSlotTime)
 		lastBlockNumberInEpoch = ed.l1Unsafe.Number - 1
@@ -119,20 +228,39 @@ func (ed *ElectionDeriver) ProcessNewBlock() {
 	newEpoch, err := ed.client.GetEpochNumber(ed.ctx, nextEpochTime)
 	if err != nil {
 		ed.log.Warn("Failed to get epoch number", "err", err)
+		ed.metrics.RecordElectionError(StageEpoch)
 		ed.emitter.Emit(rollup.ElectionErrorEvent{Err: err})
 		return
 	}
 
-	electionWinners, err := ed.election.GetWinnersAtEpoch(ed.ctx, newEpoch, fmt.Sprintf("0x%x", ed.l2Unsafe.Number), ed.l2Unsafe.Time, fmt.Sprintf("0x%x", lastBlockNumberInEpoch))
+	l1Block := ed.l1Unsafe.ID()
+	if lastBlockNumberInEpoch != ed.l1Unsafe.Number {
+		l1Block = eth.BlockID{Hash: ed.l1Unsafe.ParentHash, Number: lastBlockNumberInEpoch}
+	}
+	l2Block := ed.l2Unsafe.ID()
 
+	electionWinners, tickets, err := ed.election.GetWinnersAtEpoch(ed.ctx, newEpoch, rpcblock.ByHash(l2Block.Hash), ed.l2Unsafe.Time, rpcblock.ByHash(l1Block.Hash))
 	if err != nil {
 		ed.log.Error("Failed to get election winner", "epoch", newEpoch, "err", err)
 		ed.emitter.Emit(rollup.ElectionErrorEvent{Err: err})
 	} else {
-		ed.log.Info("Election winners", "epoch", newEpoch, "electionWinners", electionWinners)
-		ed.emitter.Emit(rollup.ElectionWinnerEvent{ElectionWinners: electionWinners})
-
-		ed.electionWinners = append(ed.electionWinners, ElectionWinners{winners: electionWinners, epoch: newEpoch})
+		ed.log.Info("Election winners", "epoch", newEpoch, "electionWinners", electionWinners, "l1", l1Block, "l2", l2Block)
+		ed.emitter.Emit(rollup.ElectionWinnerEvent{
+			ElectionWinners: electionWinners,
+			Epoch:           newEpoch,
+			L1Block:         l1Block,
+			L2Block:         l2Block,
+			Tickets:         tickets,
+		})
+
+		ed.electionWinners = append(ed.electionWinners, ElectionWinners{
+			winners: electionWinners,
+			epoch:   newEpoch,
+			l1Block: l1Block,
+			l2Block: l2Block,
+			tickets: tickets,
+		})
+		ed.recordElection(electionWinners)
 
 		// Clear old election winners
 		start := 0
@@ -163,6 +291,12 @@ func (ed *ElectionDeriver) GetElectionWinners(ctx context.Context, epoch uint64)
 		}
 	}
 
+	if winners == nil {
+		if stored, err := ed.history.ElectionAtEpoch(ctx, epoch); err == nil {
+			winners = stored.Winners
+		}
+	}
+
 	if winners == nil {
 		return []eth.ElectionWinner{}, fmt.Errorf("no stored election winners for requested epoch, requested epoch: %d, stored_epochs: %v", epoch, storedEpochs)
 	}
@@ -174,3 +308,218 @@ func (ed *ElectionDeriver) GetElectionWinners(ctx context.Context, epoch uint64)
 
 	return out, nil
 }
+
+// recordElection records the outcome of the election of a new epoch. Elections that are re-run after a reorg
+// or recomputed on lookup are not recorded again, so that the slots of each epoch are counted once.
+func (ed *ElectionDeriver) recordElection(winners []*eth.ElectionWinner) {
+	permissionless := 0
+	for _, winner := range winners {
+		if winner.Permissionless {
+			permissionless++
+		}
+	}
+	ed.metrics.RecordElection(len(winners), unfilledSlots(winners), permissionless)
+}
+
+// storedElection returns the election of the epoch that the deriver computed, or nil. The caller must hold the lock.
+func (ed *ElectionDeriver) storedElection(epoch uint64) *eth.EpochElection {
+	for i := range ed.electionWinners {
+		if ed.electionWinners[i].epoch == epoch {
+			return ed.electionWinners[i].election()
+		}
+	}
+	return nil
+}
+
+// knownElection returns the election of the epoch from the elections the deriver computed or the election history,
+// or nil if the election of the epoch is not known.
+func (ed *ElectionDeriver) knownElection(ctx context.Context, epoch uint64) *eth.EpochElection {
+	ed.mu.Lock()
+	stored := ed.storedElection(epoch)
+	ed.mu.Unlock()
+	if stored != nil {
+		return stored
+	}
+	if stored, err := ed.history.ElectionAtEpoch(ctx, epoch); err == nil {
+		return stored
+	}
+	return nil
+}
+
+// EpochSchedule returns the election of the epoch of the L1 head, and the election of the next epoch
+// if it was computed already. Elections are never recomputed, the schedule is served to untrusted RPC callers.
+func (ed *ElectionDeriver) EpochSchedule(ctx context.Context) (*eth.EpochSchedule, error) {
+	ed.mu.Lock()
+	l1Unsafe := ed.l1Unsafe
+	ed.mu.Unlock()
+	if l1Unsafe == (eth.L1BlockRef{}) {
+		return nil, errors.New("no L1 head yet")
+	}
+	epoch, err := ed.client.GetEpochNumber(ctx, l1Unsafe.Time)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get epoch of L1 head %s: %w", l1Unsafe, err)
+	}
+	current := ed.knownElection(ctx, epoch)
+	if current == nil {
+		return nil, fmt.Errorf("election of epoch %d is not known", epoch)
+	}
+	return &eth.EpochSchedule{
+		CurrentEpoch: epoch,
+		Current:      current,
+		Next:         ed.knownElection(ctx, epoch+1),
+	}, nil
+}
+
+// FallbackListAt returns the fallback list of the system config at the canonical L1 block of the given number.
+func (ed *ElectionDeriver) FallbackListAt(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error) {
+	ref, err := ed.l1.L1BlockRefByNumber(ctx, l1BlockNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 block %d: %w", l1BlockNum, err)
+	}
+	fallbackList, err := ed.election.GetElectionFallbackList(ctx, rpcblock.ByHash(ref.Hash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fallback list at L1 block %s: %w", ref, err)
+	}
+	out := &eth.ElectionFallbackList{
+		L1Block:      ref.ID(),
+		Instructions: make([]hexutil.Uint64, len(fallbackList)),
+	}
+	for i, instruction := range fallbackList {
+		out.Instructions[i] = hexutil.Uint64(instruction)
+	}
+	return out, nil
+}
+
+// LatestTickets returns the ticket count of each operator that the latest computed election was computed with.
+func (ed *ElectionDeriver) LatestTickets(ctx context.Context) (*eth.ElectionTickets, error) {
+	ed.mu.Lock()
+	defer ed.mu.Unlock()
+
+	if len(ed.electionWinners) == 0 {
+		return nil, errors.New("no election computed yet")
+	}
+	latest := ed.electionWinners[len(ed.electionWinners)-1]
+	return &eth.ElectionTickets{
+		Epoch:   latest.epoch,
+		L2Block: latest.l2Block,
+		Tickets: latest.tickets,
+	}, nil
+}
+
+// extendsL2 returns whether the L2 head moved forward from the previous head without a reorg.
+// Heads that skip blocks, e.g. during sync, are treated as reorgs, which only costs a reorg check.
+func extendsL2(prev eth.L2BlockRef, head eth.L2BlockRef) bool {
+	return head.Hash == prev.Hash || head.ParentHash == prev.Hash
+}
+
+// checkReorgedElections re-runs the elections since the finalized L1 block whose inputs were read at an L1 or L2 block
+// that is no longer canonical. The election inputs are read at the canonical L1 and L2 blocks of the same height,
+// and the new winners replace the winners of the reorged election. Batches may have been accepted or rejected
+// with the winners of the reorged election, so the derivation pipeline is reset after any election is re-run.
+// It runs on the background worker, and only holds the lock to read and update the state of the deriver.
+func (ed *ElectionDeriver) checkReorgedElections() {
+	ed.mu.Lock()
+	l1Finalized := ed.l1Finalized
+	// A reorg signalled from here on requests another check
+	ed.reorgChecked = true
+	ed.mu.Unlock()
+
+	// failed marks the check as incomplete, so that it is retried on the next L1 block.
+	failed := func() {
+		ed.mu.Lock()
+		ed.reorgChecked = false
+		ed.mu.Unlock()
+	}
+
+	if l1Finalized == (eth.L1BlockRef{}) {
+		finalized, err := ed.l1.L1BlockRefByLabel(ed.ctx, eth.Finalized)
+		if err != nil {
+			ed.log.Warn("Failed to fetch finalized L1 block, retrying election reorg check on next L1 block", "err", err)
+			ed.metrics.RecordElectionError(StageReorgCheck)
+			failed()
+			return
+		}
+		l1Finalized = finalized
+		ed.mu.Lock()
+		if ed.l1Finalized == (eth.L1BlockRef{}) {
+			ed.l1Finalized = finalized
+		}
+		ed.mu.Unlock()
+	}
+
+	elections, err := ed.history.ElectionsSinceL1(ed.ctx, l1Finalized.Number+1)
+	if err != nil {
+		ed.log.Warn("Failed to read stored elections, retrying election reorg check on next L1 block", "err", err)
+		ed.metrics.RecordElectionError(StageReorgCheck)
+		failed()
+		return
+	}
+	// The latest election is kept in memory even if the election history is not persisted.
+	ed.mu.Lock()
+	for _, stored := range ed.electionWinners {
+		if stored.l1Block.Number <= l1Finalized.Number {
+			continue
+		}
+		if slices.ContainsFunc(elections, func(e *eth.EpochElection) bool { return e.Epoch == stored.epoch }) {
+			continue
+		}
+		elections = append(elections, stored.election())
+	}
+	ed.mu.Unlock()
+
+	var rerun []uint64
+	for _, prev := range elections {
+		canonicalL1, err := ed.l1.L1BlockRefByNumber(ed.ctx, prev.L1Block.Number)
+		if err != nil {
+			ed.log.Warn("Failed to fetch canonical L1 block of election", "epoch", prev.Epoch, "l1", prev.L1Block, "err", err)
+			failed()
+			continue
+		}
+		canonicalL2, err := ed.l2.L2BlockRefByNumber(ed.ctx, prev.L2Block.Number)
+		if err != nil {
+			ed.log.Warn("Failed to fetch canonical L2 block of election", "epoch", prev.Epoch, "l2", prev.L2Block, "err", err)
+			failed()
+			continue
+		}
+		if canonicalL1.Hash == prev.L1Block.Hash && canonicalL2.Hash == prev.L2Block.Hash {
+			continue
+		}
+
+		ed.log.Warn("Election was computed at a reorged block, re-running election", "epoch", prev.Epoch,
+			"prev_l1", prev.L1Block, "canonical_l1", canonicalL1, "prev_l2", prev.L2Block, "canonical_l2", canonicalL2)
+		winners, tickets, err := ed.election.GetWinnersAtEpoch(ed.ctx, prev.Epoch, rpcblock.ByHash(canonicalL2.Hash), 0, rpcblock.ByHash(canonicalL1.Hash))
+		if err != nil {
+			ed.log.Error("Failed to re-run election", "epoch", prev.Epoch, "err", err)
+			ed.emitter.Emit(rollup.ElectionErrorEvent{Err: err})
+			failed()
+			continue
+		}
+		ed.log.Info("Election winners", "epoch", prev.Epoch, "electionWinners", winners)
+		ed.emitter.Emit(rollup.ElectionWinnerEvent{
+			ElectionWinners: winners,
+			Epoch:           prev.Epoch,
+			L1Block:         canonicalL1.ID(),
+			L2Block:         canonicalL2.ID(),
+			Tickets:         tickets,
+		})
+		rerun = append(rerun, prev.Epoch)
+		ed.metrics.RecordElectionRerun()
+
+		ed.mu.Lock()
+		for i := range ed.electionWinners {
+			if ed.electionWinners[i].epoch == prev.Epoch {
+				ed.electionWinners[i].winners = winners
+				ed.electionWinners[i].l1Block = canonicalL1.ID()
+				ed.electionWinners[i].l2Block = canonicalL2.ID()
+				ed.electionWinners[i].tickets = tickets
+				if len(winners) > 0 {
+					ed.lastSlotTime = winners[len(winners)-1].Time
+				}
+			}
+		}
+		ed.mu.Unlock()
+	}
+	if len(rerun) > 0 {
+		ed.emitter.Emit(rollup.ResetEvent{Err: fmt.Errorf("elections of epochs %v were computed at reorged blocks", rerun)})
+	}
+}
@@ -0,0 +1,168 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChain is a chain of blocks with the given timestamps, numbered from zero.
+type fakeChain struct {
+	times []uint64
+}
+
+func (f *fakeChain) Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
+	panic("not implemented")
+}
+
+func (f *fakeChain) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	if number >= uint64(len(f.times)) {
+		return nil, ethereum.NotFound
+	}
+	return &testutils.MockBlockInfo{
+		InfoHash: common.Hash{byte(number), 0xaa},
+		InfoNum:  number,
+		InfoTime: f.times[number],
+	}, nil
+}
+
+func (f *fakeChain) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
+	return f.InfoByNumber(ctx, uint64(len(f.times)-1))
+}
+
+func TestL1BlockAtTime(t *testing.T) {
+	// L1 chain with slots missed after blocks 3, 6 and 7
+	l1 := &fakeChain{times: []uint64{1000, 1012, 1024, 1036, 1060, 1072, 1084, 1108, 1144, 1156}}
+	e := &Election{
+		l1:  l1,
+		log: testlog.Logger(t, log.LevelInfo),
+		cfg: &rollup.Config{},
+	}
+	cases := []struct {
+		time     uint64
+		expected uint64
+	}{
+		{time: 900, expected: 0},
+		{time: 1000, expected: 0},
+		{time: 1011, expected: 0},
+		{time: 1036, expected: 3},
+		{time: 1048, expected: 3},
+		{time: 1060, expected: 4},
+		{time: 1096, expected: 6},
+		{time: 1120, expected: 7},
+		{time: 1132, expected: 7},
+		{time: 1144, expected: 8},
+		{time: 1156, expected: 9},
+	}
+	for _, c := range cases {
+		actual, err := e.l1BlockAtTime(context.Background(), c.time, 12)
+		require.NoError(t, err)
+		require.Equal(t, c.expected, actual.Number, "block at time %d", c.time)
+		require.Equal(t, common.Hash{byte(c.expected), 0xaa}, actual.Hash)
+	}
+
+	_, err := e.l1BlockAtTime(context.Background(), 1168, 12)
+	require.ErrorContains(t, err, "has not reached")
+
+	// L1 chain with 4 second slots, with slots missed after blocks 1 and 4
+	e.l1 = &fakeChain{times: []uint64{1000, 1004, 1012, 1016, 1020, 1028, 1032}}
+	for time, expected := range map[uint64]uint64{1003: 0, 1008: 1, 1012: 2, 1024: 4, 1028: 5, 1032: 6} {
+		actual, err := e.l1BlockAtTime(context.Background(), time, 4)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual.Number, "block at time %d", time)
+	}
+}
+
+func TestL2BlockAtTime(t *testing.T) {
+	l2 := &fakeChain{times: []uint64{2000, 2002, 2004, 2006, 2008}}
+	e := &Election{
+		l2:  l2,
+		log: testlog.Logger(t, log.LevelInfo),
+		cfg: &rollup.Config{
+			Genesis:   rollup.Genesis{L2Time: 2000},
+			BlockTime: 2,
+		},
+	}
+	actual, err := e.l2BlockAtTime(context.Background(), 1990)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), actual.Number)
+
+	actual, err = e.l2BlockAtTime(context.Background(), 2005)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), actual.Number)
+
+	actual, err = e.l2BlockAtTime(context.Background(), 2008)
+	require.NoError(t, err)
+	require.Equal(t, uint64(4), actual.Number)
+
+	_, err = e.l2BlockAtTime(context.Background(), 2010)
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
+
+// failingBeacon is a beacon client that fails to fetch lookaheads.
+type failingBeacon struct {
+	BeaconClient
+	err error
+}
+
+func (f *failingBeacon) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	return eth.APIGetLookaheadResponse{}, f.err
+}
+
+type tracedStep struct {
+	step string
+	err  error
+}
+
+type tracedElection struct {
+	epoch   uint64
+	winners int
+	err     error
+}
+
+type recordingTracer struct {
+	steps     []tracedStep
+	elections []tracedElection
+}
+
+func (r *recordingTracer) OnElectionStep(step string, duration time.Duration, err error) {
+	r.steps = append(r.steps, tracedStep{step: step, err: err})
+}
+
+func (r *recordingTracer) OnElection(epoch uint64, duration time.Duration, winners int, err error) {
+	r.elections = append(r.elections, tracedElection{epoch: epoch, winners: winners, err: err})
+}
+
+func TestTraceElection(t *testing.T) {
+	errLookahead := errors.New("lookahead unavailable")
+	e := NewElection(&failingBeacon{err: errLookahead}, nil, nil, nil, testlog.Logger(t, log.LevelInfo), nil, &rollup.Config{})
+	tracer := new(recordingTracer)
+	e.SetTracer(tracer)
+
+	_, _, err := e.GetWinnersAtEpoch(context.Background(), 7, rpcblock.Latest, 0, rpcblock.Latest)
+	require.ErrorIs(t, err, errLookahead)
+	_, err = e.RecomputeElection(context.Background(), 8)
+	require.ErrorIs(t, err, errLookahead)
+
+	require.Equal(t, []tracedStep{
+		{step: StageLookahead, err: errLookahead},
+		{step: StageLookahead, err: errLookahead},
+	}, tracer.steps)
+	require.Len(t, tracer.elections, 2)
+	for i, epoch := range []uint64{7, 8} {
+		require.Equal(t, epoch, tracer.elections[i].epoch)
+		require.Zero(t, tracer.elections[i].winners)
+		require.ErrorIs(t, tracer.elections[i].err, errLookahead)
+	}
+}
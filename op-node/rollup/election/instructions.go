@@ -6,6 +6,7 @@ import (
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -14,7 +15,6 @@ import (
 // TODO(spire): The current unimplemented codes are:
 //   - CURRENT_PROPOSER_WITH_CONFIG
 //   - NEXT_PROPOSER_WITH_CONFIG
-//   - PERMISSIONLESS
 const (
 	NO_FALLBACK                  = 0x00
 	CURRENT_PROPOSER             = 0x01
@@ -25,11 +25,12 @@ const (
 	PERMISSIONLESS               = 0x06
 )
 
-func (e *Election) HandleInstructions(ctx context.Context, instructions []uint8, electionWinners []*eth.ElectionWinner, operatorAddresses []common.Address, tickets map[common.Address]*big.Int, l2UnsafeBlock string, l1UnsafeBlock string) ([]*eth.ElectionWinner, error) {
+func (e *Election) HandleInstructions(ctx context.Context, instructions []uint8, electionWinners []*eth.ElectionWinner, operatorAddresses []common.Address, tickets map[common.Address]*big.Int, l2UnsafeBlock rpcblock.Block, l1UnsafeBlock rpcblock.Block) ([]*eth.ElectionWinner, error) {
 	var err error
 
 	// Process instructions
 	for _, instruction := range instructions {
+		unfilled := unfilledSlots(electionWinners)
 		switch instruction {
 		case NO_FALLBACK:
 			// We should never get here, but if we do something is wrong with the system config
@@ -39,37 +40,35 @@ func (e *Election) HandleInstructions(ctx context.Context, instructions []uint8,
 			if err != nil {
 				return []*eth.ElectionWinner{}, err
 			}
-			continue
 		case CURRENT_PROPOSER_WITH_CONFIG:
 			electionWinners, err = e.ProcessCurrentProposerWithConfigInstruction(ctx, electionWinners, operatorAddresses, tickets, l1UnsafeBlock)
 			if err != nil {
 				return []*eth.ElectionWinner{}, err
 			}
-			continue
 		case NEXT_PROPOSER:
 			electionWinners, err = e.ProcessNextProposerInstruction(electionWinners, operatorAddresses, tickets)
 			if err != nil {
 				return []*eth.ElectionWinner{}, err
 			}
-			continue
 		case NEXT_PROPOSER_WITH_CONFIG:
 			electionWinners, err = e.ProcessNextProposerWithConfigInstruction(ctx, electionWinners, operatorAddresses, tickets, l1UnsafeBlock)
 			if err != nil {
 				return []*eth.ElectionWinner{}, err
 			}
-			continue
 		case RANDOM_TICKET_HOLDER:
 			electionWinners, err = e.ProcessRandomTicketInstruction(ctx, electionWinners, l2UnsafeBlock)
 			if err != nil {
 				return []*eth.ElectionWinner{}, err
 			}
-			continue
 		case PERMISSIONLESS:
-			// TODO(spire): This is not implemented yet
-			continue
+			electionWinners = e.ProcessPermissionlessInstruction(electionWinners)
 		default:
 			return []*eth.ElectionWinner{}, fmt.Errorf("unknown fallback instruction: %d", instruction)
 		}
+		// Elections that were not created with NewElection record no metrics
+		if e.metrics != nil {
+			e.metrics.RecordElectionInstruction(instructionLabel(instruction), unfilled-unfilledSlots(electionWinners))
+		}
 	}
 
 	return electionWinners, nil
@@ -154,7 +153,7 @@ func (e *Election) ProcessNextProposerInstruction(electionWinners []*eth.Electio
 	return electionWinners, nil
 }
 
-func (e *Election) ProcessRandomTicketInstruction(ctx context.Context, electionWinners []*eth.ElectionWinner, blockNumber string) ([]*eth.ElectionWinner, error) {
+func (e *Election) ProcessRandomTicketInstruction(ctx context.Context, electionWinners []*eth.ElectionWinner, block rpcblock.Block) ([]*eth.ElectionWinner, error) {
 	var timestamps []uint64
 	// Only get the timestamps that still need to be filled
 	for _, winner := range electionWinners {
@@ -163,7 +162,7 @@ func (e *Election) ProcessRandomTicketInstruction(ctx context.Context, electionW
 		}
 	}
 
-	newWinners, err := e.GetBatchRandomTicketInstruction(ctx, timestamps, blockNumber)
+	newWinners, err := e.GetBatchRandomTicketInstruction(ctx, timestamps, block)
 	if err != nil {
 		return []*eth.ElectionWinner{}, err
 	}
@@ -186,7 +185,7 @@ func (e *Election) ProcessRandomTicketInstruction(ctx context.Context, electionW
 	return electionWinners, nil
 }
 
-func (e *Election) ProcessCurrentProposerWithConfigInstruction(ctx context.Context, electionWinners []*eth.ElectionWinner, operatorAddresses []common.Address, tickets map[common.Address]*big.Int, blockNumber string) ([]*eth.ElectionWinner, error) {
+func (e *Election) ProcessCurrentProposerWithConfigInstruction(ctx context.Context, electionWinners []*eth.ElectionWinner, operatorAddresses []common.Address, tickets map[common.Address]*big.Int, block rpcblock.Block) ([]*eth.ElectionWinner, error) {
 	if len(electionWinners) != len(operatorAddresses) {
 		return []*eth.ElectionWinner{}, fmt.Errorf("invalid input lengths for this instruction")
 	}
@@ -209,7 +208,7 @@ func (e *Election) ProcessCurrentProposerWithConfigInstruction(ctx context.Conte
 	}
 
 	// Make the batch call to check the winner
-	res, err := e.GetBatchCheckSeqConfig(ctx, potentialWinners, blockNumber)
+	res, err := e.GetBatchCheckSeqConfig(ctx, potentialWinners, block)
 	if err != nil {
 		return []*eth.ElectionWinner{}, err
 	}
@@ -243,7 +242,7 @@ func (e *Election) ProcessCurrentProposerWithConfigInstruction(ctx context.Conte
 	return electionWinners, nil
 }
 
-func (e *Election) ProcessNextProposerWithConfigInstruction(ctx context.Context, electionWinners []*eth.ElectionWinner, operatorAddresses []common.Address, tickets map[common.Address]*big.Int, blockNumber string) ([]*eth.ElectionWinner, error) {
+func (e *Election) ProcessNextProposerWithConfigInstruction(ctx context.Context, electionWinners []*eth.ElectionWinner, operatorAddresses []common.Address, tickets map[common.Address]*big.Int, block rpcblock.Block) ([]*eth.ElectionWinner, error) {
 	if len(electionWinners) != len(operatorAddresses) {
 		return []*eth.ElectionWinner{}, fmt.Errorf("invalid input lengths for this instruction")
 	}
@@ -298,7 +297,7 @@ func (e *Election) ProcessNextProposerWithConfigInstruction(ctx context.Context,
 	}
 
 	// Make the batch call to check the winner
-	res, err := e.GetBatchCheckSeqConfig(ctx, potentialWinners, blockNumber)
+	res, err := e.GetBatchCheckSeqConfig(ctx, potentialWinners, block)
 
 	if err != nil {
 		return []*eth.ElectionWinner{}, err
@@ -331,3 +330,16 @@ func (e *Election) ProcessNextProposerWithConfigInstruction(ctx context.Context,
 
 	return electionWinners, nil
 }
+
+// Slots that no earlier instruction filled are opened up, anyone may submit the batch for them
+func (e *Election) ProcessPermissionlessInstruction(electionWinners []*eth.ElectionWinner) []*eth.ElectionWinner {
+	for i, winner := range electionWinners {
+		// slot has a winner, skipping
+		if winner.Address != (common.Address{}) {
+			continue
+		}
+		electionWinners[i].Permissionless = true
+	}
+
+	return electionWinners
+}
@@ -6,22 +6,23 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 )
 
 type MockElection struct {
 	*Election
-	GetBatchRandomTicketInstructionFunc func(ctx context.Context, timestamps []uint64, blockNumber string) ([]RandomTicketInstructionRetdata, error)
+	GetBatchRandomTicketInstructionFunc func(ctx context.Context, timestamps []uint64, block rpcblock.Block) ([]RandomTicketInstructionRetdata, error)
 }
 
 // Override the function to mock the batch
-func (m *MockElection) GetBatchRandomTicketInstruction(ctx context.Context, timestamps []uint64, blockNumber string) ([]RandomTicketInstructionRetdata, error) {
+func (m *MockElection) GetBatchRandomTicketInstruction(ctx context.Context, timestamps []uint64, block rpcblock.Block) ([]RandomTicketInstructionRetdata, error) {
 
-	return m.GetBatchRandomTicketInstructionFunc(ctx, timestamps, blockNumber)
+	return m.GetBatchRandomTicketInstructionFunc(ctx, timestamps, block)
 }
 
-func createMockInputsForHandleInstructions() (context.Context, []*eth.ElectionWinner, []common.Address, map[common.Address]*big.Int, string) {
+func createMockInputsForHandleInstructions() (context.Context, []*eth.ElectionWinner, []common.Address, map[common.Address]*big.Int, rpcblock.Block) {
 	electionWinners := []*eth.ElectionWinner{
 		{Address: common.Address{}},
 		{Address: common.Address{}},
@@ -34,7 +35,7 @@ func createMockInputsForHandleInstructions() (context.Context, []*eth.ElectionWi
 		operatorAddresses[0]: big.NewInt(2),
 		operatorAddresses[1]: big.NewInt(3),
 	}
-	blockNumber := "0x1"
+	blockNumber := rpcblock.ByNumber(1)
 	ctx := context.Background()
 	return ctx, electionWinners, operatorAddresses, tickets, blockNumber
 }
@@ -151,3 +152,49 @@ func TestProcessNextProposerInstruction(t *testing.T) {
 	// Should decrement two for two slots won
 	assert.Equal(t, big.NewInt(1), tickets[operatorAddresses[2]])
 }
+
+func TestPermissionlessInstruction(t *testing.T) {
+	ctx, _, operatorAddresses, tickets, blockNumber := createMockInputsForHandleInstructions()
+	electionWinners := []*eth.ElectionWinner{
+		{Address: common.Address{}, Time: 12},
+		{Address: common.Address{}, Time: 24},
+	}
+	tickets[operatorAddresses[0]] = big.NewInt(1)
+	tickets[operatorAddresses[1]] = big.NewInt(0)
+	e := &Election{}
+
+	instructions := []uint8{CURRENT_PROPOSER, PERMISSIONLESS}
+	result, err := e.HandleInstructions(ctx, instructions, electionWinners, operatorAddresses, tickets, blockNumber, blockNumber)
+
+	assert.NoError(t, err)
+	// Slots won by an earlier instruction stay with their winner
+	assert.Equal(t, eth.ElectionWinner{Address: operatorAddresses[0], Time: 12}, *result[0])
+	// Slots without a winner are opened up
+	assert.Equal(t, eth.ElectionWinner{Time: 24, Permissionless: true}, *result[1])
+}
+
+type instructionFillMetrics struct {
+	NoopMetrics
+	filled map[string]int
+}
+
+func (m *instructionFillMetrics) RecordElectionInstruction(instruction string, filled int) {
+	m.filled[instruction] += filled
+}
+
+func TestInstructionFillMetrics(t *testing.T) {
+	ctx, _, operatorAddresses, tickets, blockNumber := createMockInputsForHandleInstructions()
+	electionWinners := []*eth.ElectionWinner{
+		{Address: common.Address{}, Time: 12},
+		{Address: common.Address{}, Time: 24},
+	}
+	tickets[operatorAddresses[0]] = big.NewInt(1)
+	tickets[operatorAddresses[1]] = big.NewInt(0)
+	m := &instructionFillMetrics{filled: make(map[string]int)}
+	e := &Election{metrics: m}
+
+	instructions := []uint8{CURRENT_PROPOSER, PERMISSIONLESS}
+	_, err := e.HandleInstructions(ctx, instructions, electionWinners, operatorAddresses, tickets, blockNumber, blockNumber)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"current_proposer": 1, "permissionless": 1}, m.filled)
+}
@@ -0,0 +1,80 @@
+package election
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Stages of the election that errors are recorded for.
+const (
+	StageLookahead = "lookahead"
+	// StageLookaheadMismatch is recorded instead of StageLookahead when the lookahead providers disagree,
+	// which points at a faulty or malicious provider rather than an unavailable one.
+	StageLookaheadMismatch = "lookahead_mismatch"
+	StageOperators         = "operators"
+	StageCall              = "call"
+	StageInstruction       = "instruction"
+	StageSlotTime          = "slot_time"
+	StageEpoch             = "epoch"
+	StageReorgCheck        = "reorg_check"
+)
+
+type Metrics interface {
+	// RecordElectionLookahead records the duration of a lookahead fetch from the beacon node.
+	RecordElectionLookahead(duration time.Duration)
+	// RecordElectionCall records the duration of an eth_call of a batch or system config contract.
+	RecordElectionCall(call string, duration time.Duration)
+	// RecordElectionInstruction records the number of slots that an instruction of the fallback list filled.
+	RecordElectionInstruction(instruction string, filled int)
+	// RecordElection records the outcome of an election: the slots without a winner, and the permissionless slots.
+	RecordElection(slots int, empty int, permissionless int)
+	RecordElectionError(stage string)
+	RecordElectionRerun()
+}
+
+type NoopMetrics struct{}
+
+func (NoopMetrics) RecordElectionLookahead(duration time.Duration) {}
+
+func (NoopMetrics) RecordElectionCall(call string, duration time.Duration) {}
+
+func (NoopMetrics) RecordElectionInstruction(instruction string, filled int) {}
+
+func (NoopMetrics) RecordElection(slots int, empty int, permissionless int) {}
+
+func (NoopMetrics) RecordElectionError(stage string) {}
+
+func (NoopMetrics) RecordElectionRerun() {}
+
+var _ Metrics = NoopMetrics{}
+
+var instructionLabels = map[uint8]string{
+	CURRENT_PROPOSER:             "current_proposer",
+	CURRENT_PROPOSER_WITH_CONFIG: "current_proposer_with_config",
+	NEXT_PROPOSER:                "next_proposer",
+	NEXT_PROPOSER_WITH_CONFIG:    "next_proposer_with_config",
+	RANDOM_TICKET_HOLDER:         "random_ticket_holder",
+	PERMISSIONLESS:               "permissionless",
+}
+
+func instructionLabel(instruction uint8) string {
+	if label, ok := instructionLabels[instruction]; ok {
+		return label
+	}
+	return fmt.Sprintf("unknown_%d", instruction)
+}
+
+// unfilledSlots counts the slots without a winner that are not open to anyone either.
+func unfilledSlots(winners []*eth.ElectionWinner) int {
+	count := 0
+	for _, winner := range winners {
+		if winner.Address == (common.Address{}) && !winner.Permissionless {
+			count++
+		}
+	}
+	return count
+}
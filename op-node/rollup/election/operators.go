@@ -0,0 +1,189 @@
+package election
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	OPERATOR_REGISTRY_ABI = `[
+    {
+        "inputs": [
+            {
+                "internalType": "bytes[]",
+                "name": "_pubkeys",
+                "type": "bytes[]"
+            }
+        ],
+        "name": "operatorsOf",
+        "outputs": [
+            {
+                "internalType": "address[]",
+                "name": "operators_",
+                "type": "address[]"
+            }
+        ],
+        "stateMutability": "view",
+        "type": "function"
+    }
+	]`
+)
+
+// ErrNoOperatorRegistry is returned when the rollup config has no operator registry to resolve the operators from.
+var ErrNoOperatorRegistry = errors.New("no operator registry configured")
+
+// OperatorResolver maps the validators of the beacon chain lookahead to the sequencer operator addresses
+// they delegated to, so that L1 proposers can opt in to sequence the L2 chain.
+type OperatorResolver interface {
+	// OperatorsOf returns the operator address of each validator, as of the given L1 block.
+	// Validators that have not registered an operator resolve to the zero address.
+	OperatorsOf(ctx context.Context, validators []*eth.Validator, l1Block rpcblock.Block) ([]common.Address, error)
+}
+
+// RegistryOperatorResolver reads the operators from the L1 operator registry.
+// Validators register an operator with a message signed by their BLS key, which the registry verifies,
+// so only the validator itself can delegate its proposer slots.
+type RegistryOperatorResolver struct {
+	l1       RpcClient
+	registry common.Address
+	abi      abi.ABI
+}
+
+func NewRegistryOperatorResolver(l1 RpcClient, registry common.Address) (*RegistryOperatorResolver, error) {
+	parsedABI, err := abi.JSON(strings.NewReader(OPERATOR_REGISTRY_ABI))
+	if err != nil {
+		return nil, err
+	}
+	return &RegistryOperatorResolver{
+		l1:       l1,
+		registry: registry,
+		abi:      parsedABI,
+	}, nil
+}
+
+func (r *RegistryOperatorResolver) OperatorsOf(ctx context.Context, validators []*eth.Validator, l1Block rpcblock.Block) ([]common.Address, error) {
+	pubkeys := make([][]byte, len(validators))
+	for i, validator := range validators {
+		pubkeys[i] = validator.Pubkey[:]
+	}
+	calldataBytes, err := r.abi.Pack("operatorsOf", pubkeys)
+	if err != nil {
+		return nil, err
+	}
+	calldata := "0x" + hex.EncodeToString(calldataBytes)
+
+	encodedReturnData, err := r.l1.Call(ctx, toCallMsg(r.registry, calldata), l1Block)
+	if err != nil {
+		return nil, err
+	}
+	retdata, err := hex.DecodeString(strings.TrimPrefix(encodedReturnData, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	res, err := r.abi.Unpack("operatorsOf", retdata)
+	if err != nil {
+		return nil, err
+	}
+	operators := res[0].([]common.Address)
+	if len(operators) != len(validators) {
+		return nil, fmt.Errorf("operator registry returned %d operators for %d validators", len(operators), len(validators))
+	}
+	return operators, nil
+}
+
+// NewOperatorResolver creates the cached resolver of the operator registry of the rollup config.
+// Validators can only delegate their proposer slots through the registry, so the registry is required.
+func NewOperatorResolver(l1 RpcClient, cfg *rollup.Config, cacheSize int) (OperatorResolver, error) {
+	if cfg.OperatorRegistryAddress == (common.Address{}) {
+		return nil, ErrNoOperatorRegistry
+	}
+	registry, err := NewRegistryOperatorResolver(l1, cfg.OperatorRegistryAddress)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operator registry resolver: %w", err)
+	}
+	return NewCachedOperatorResolver(registry, cacheSize), nil
+}
+
+type operatorKey struct {
+	l1Block common.Hash
+	pubkey  eth.Bytes48
+}
+
+// CachedOperatorResolver caches the operators resolved by another OperatorResolver, per validator and L1 block.
+// Validators propose multiple slots per epoch, and elections are computed repeatedly at the same L1 blocks,
+// e.g. when they are recomputed or checked against reorgs.
+// Only lookups of L1 blocks referenced by hash are cached, the block at a number or label changes with reorgs.
+type CachedOperatorResolver struct {
+	inner OperatorResolver
+	cache *lru.Cache[operatorKey, common.Address]
+}
+
+func NewCachedOperatorResolver(inner OperatorResolver, size int) *CachedOperatorResolver {
+	// no errors if the size is positive
+	cache, _ := lru.New[operatorKey, common.Address](size)
+	return &CachedOperatorResolver{
+		inner: inner,
+		cache: cache,
+	}
+}
+
+func (c *CachedOperatorResolver) OperatorsOf(ctx context.Context, validators []*eth.Validator, l1Block rpcblock.Block) ([]common.Address, error) {
+	l1Hash, ok := l1Block.Hash()
+	if !ok {
+		return c.inner.OperatorsOf(ctx, validators, l1Block)
+	}
+	out := make([]common.Address, len(validators))
+	var missing []*eth.Validator
+	var missingIdx []int
+	for i, validator := range validators {
+		if operator, ok := c.cache.Get(operatorKey{l1Block: l1Hash, pubkey: validator.Pubkey}); ok {
+			out[i] = operator
+		} else {
+			missing = append(missing, validator)
+			missingIdx = append(missingIdx, i)
+		}
+	}
+	if len(missing) == 0 {
+		return out, nil
+	}
+	operators, err := c.inner.OperatorsOf(ctx, missing, l1Block)
+	if err != nil {
+		return nil, err
+	}
+	for i, operator := range operators {
+		c.cache.Add(operatorKey{l1Block: l1Hash, pubkey: missing[i].Pubkey}, operator)
+		out[missingIdx[i]] = operator
+	}
+	return out, nil
+}
+
+// FakeOperatorResolver resolves validators without an L1 registry, for testing only.
+// Anyone can pick the pubkey prefix of a validator, so it must never be used to derive a live chain.
+// Validators resolve to the configured operator, or otherwise to the address in the first 20 bytes of their pubkey,
+// matching the validator pubkeys of the fake lookahead.
+type FakeOperatorResolver struct {
+	Operators map[eth.Bytes48]common.Address
+}
+
+func (f *FakeOperatorResolver) OperatorsOf(ctx context.Context, validators []*eth.Validator, l1Block rpcblock.Block) ([]common.Address, error) {
+	out := make([]common.Address, len(validators))
+	for i, validator := range validators {
+		if operator, ok := f.Operators[validator.Pubkey]; ok {
+			out[i] = operator
+		} else {
+			out[i] = common.BytesToAddress(validator.Pubkey[:20])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,118 @@
+package election
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRegistry answers operatorsOf calls from a map of pubkeys to operators.
+type fakeRegistry struct {
+	t         *testing.T
+	registry  common.Address
+	operators map[eth.Bytes48]common.Address
+	calls     int
+}
+
+func (f *fakeRegistry) Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
+	f.calls++
+	require.Equal(f.t, f.registry.Hex(), callMsg["to"])
+	parsedABI, err := abi.JSON(strings.NewReader(OPERATOR_REGISTRY_ABI))
+	require.NoError(f.t, err)
+	data := common.FromHex(callMsg["data"].(string))
+	method, err := parsedABI.MethodById(data[:4])
+	require.NoError(f.t, err)
+	args, err := method.Inputs.Unpack(data[4:])
+	require.NoError(f.t, err)
+
+	var out []common.Address
+	for _, pubkey := range args[0].([][]byte) {
+		out = append(out, f.operators[eth.Bytes48(pubkey)])
+	}
+	retdata, err := method.Outputs.Pack(out)
+	require.NoError(f.t, err)
+	return "0x" + hex.EncodeToString(retdata), nil
+}
+
+func TestOperatorResolvers(t *testing.T) {
+	registered := eth.Bytes48{0x01}
+	unregistered := eth.Bytes48{0x02}
+	operator := common.Address{0xaa}
+	validators := []*eth.Validator{
+		{Pubkey: registered, Slot: 0},
+		{Pubkey: unregistered, Slot: 1},
+		{Pubkey: registered, Slot: 2},
+	}
+
+	l1Block := rpcblock.ByHash(common.Hash{0x10})
+	registry := &fakeRegistry{
+		t:         t,
+		registry:  common.Address{0xee},
+		operators: map[eth.Bytes48]common.Address{registered: operator},
+	}
+	resolver, err := NewRegistryOperatorResolver(registry, registry.registry)
+	require.NoError(t, err)
+
+	operators, err := resolver.OperatorsOf(context.Background(), validators, l1Block)
+	require.NoError(t, err)
+	require.Equal(t, []common.Address{operator, {}, operator}, operators)
+	require.Equal(t, 1, registry.calls)
+
+	t.Run("cached", func(t *testing.T) {
+		registry.calls = 0
+		cached := NewCachedOperatorResolver(resolver, 10)
+		operators, err := cached.OperatorsOf(context.Background(), validators[:1], l1Block)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operator}, operators)
+		require.Equal(t, 1, registry.calls)
+
+		// Only the validator that is not cached yet is resolved through the registry
+		operators, err = cached.OperatorsOf(context.Background(), validators, l1Block)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operator, {}, operator}, operators)
+		require.Equal(t, 2, registry.calls)
+
+		operators, err = cached.OperatorsOf(context.Background(), validators, l1Block)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operator, {}, operator}, operators)
+		require.Equal(t, 2, registry.calls)
+
+		// Registrations may change between L1 blocks, so other blocks are not served from the cache
+		_, err = cached.OperatorsOf(context.Background(), validators, rpcblock.ByHash(common.Hash{0x11}))
+		require.NoError(t, err)
+		require.Equal(t, 3, registry.calls)
+
+		// The block at a number changes with reorgs, so lookups by number are never cached
+		_, err = cached.OperatorsOf(context.Background(), validators, rpcblock.ByNumber(0x10))
+		require.NoError(t, err)
+		_, err = cached.OperatorsOf(context.Background(), validators, rpcblock.ByNumber(0x10))
+		require.NoError(t, err)
+		require.Equal(t, 5, registry.calls)
+	})
+
+	t.Run("from config", func(t *testing.T) {
+		_, err := NewOperatorResolver(registry, &rollup.Config{}, 10)
+		require.ErrorIs(t, err, ErrNoOperatorRegistry)
+
+		fromCfg, err := NewOperatorResolver(registry, &rollup.Config{OperatorRegistryAddress: registry.registry}, 10)
+		require.NoError(t, err)
+		operators, err := fromCfg.OperatorsOf(context.Background(), validators, l1Block)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operator, {}, operator}, operators)
+	})
+
+	t.Run("fake", func(t *testing.T) {
+		fake := &FakeOperatorResolver{Operators: map[eth.Bytes48]common.Address{registered: operator}}
+		operators, err := fake.OperatorsOf(context.Background(), validators, l1Block)
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{operator, common.BytesToAddress(unregistered[:20]), operator}, operators)
+	})
+}
@@ -0,0 +1,147 @@
+package election
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/holiman/uint256"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+var ErrInvalidCall = errors.New("invalid election call")
+
+// StateChain provides the blocks and the state of a chain, that the contract calls of the election are executed against.
+type StateChain interface {
+	InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error)
+	InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error)
+	InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error)
+	StateAt(root common.Hash) (*state.StateDB, error)
+	Config() *params.ChainConfig
+	VMConfig() vm.Config
+}
+
+// StateClient implements the ChainClient by executing the contract calls of the election
+// against the state of the chain, instead of with eth_call on an RPC.
+type StateClient struct {
+	StateChain
+}
+
+var _ ChainClient = (*StateClient)(nil)
+
+func NewStateClient(chain StateChain) *StateClient {
+	return &StateClient{StateChain: chain}
+}
+
+// Call executes the call message at the given block, like eth_call. Calls without a recipient execute the data
+// as creation code, and return the data returned by the constructor.
+func (c *StateClient) Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
+	from, to, data, err := parseCallMsg(callMsg)
+	if err != nil {
+		return "", err
+	}
+	info, err := c.blockInfo(ctx, block)
+	if err != nil {
+		return "", err
+	}
+	statedb, err := c.StateAt(info.Root())
+	if err != nil {
+		return "", fmt.Errorf("failed to open state of block %d: %w", info.NumberU64(), err)
+	}
+
+	cfg := c.Config()
+	number := new(big.Int).SetUint64(info.NumberU64())
+	random := info.MixDigest()
+	blockCtx := vm.BlockContext{
+		CanTransfer: core.CanTransfer,
+		Transfer:    core.Transfer,
+		GetHash: func(n uint64) common.Hash {
+			ancestor, err := c.InfoByNumber(ctx, n)
+			if err != nil {
+				return common.Hash{}
+			}
+			return ancestor.Hash()
+		},
+		Coinbase:    info.Coinbase(),
+		BlockNumber: number,
+		Time:        info.Time(),
+		Difficulty:  new(big.Int),
+		BaseFee:     info.BaseFee(),
+		BlobBaseFee: info.BlobBaseFee(),
+		GasLimit:    info.GasLimit(),
+		Random:      &random,
+	}
+	evm := vm.NewEVM(blockCtx, vm.TxContext{Origin: from, GasPrice: new(big.Int)}, statedb, cfg, c.VMConfig())
+	rules := cfg.Rules(number, true, info.Time())
+	statedb.Prepare(rules, from, info.Coinbase(), to, vm.ActivePrecompiles(rules), nil)
+
+	var ret []byte
+	if to == nil {
+		ret, _, _, err = evm.Create(vm.AccountRef(from), data, info.GasLimit(), new(uint256.Int))
+	} else {
+		ret, _, err = evm.Call(vm.AccountRef(from), *to, data, info.GasLimit(), new(uint256.Int))
+	}
+	if err != nil {
+		return "", fmt.Errorf("call at block %d failed: %w", info.NumberU64(), err)
+	}
+	return hexutil.Encode(ret), nil
+}
+
+func (c *StateClient) blockInfo(ctx context.Context, block rpcblock.Block) (eth.BlockInfo, error) {
+	if hash, ok := block.Hash(); ok {
+		return c.InfoByHash(ctx, hash)
+	}
+	var label string
+	switch v := block.ArgValue().(type) {
+	case string:
+		label = v
+	case rpc.BlockNumber:
+		if v >= 0 {
+			return c.InfoByNumber(ctx, uint64(v))
+		}
+		label = v.String()
+	default:
+		return nil, fmt.Errorf("%w: block %v", ErrInvalidCall, block)
+	}
+	switch label {
+	case "latest", "pending":
+		return c.InfoByLabel(ctx, eth.Unsafe)
+	case string(eth.Safe), string(eth.Finalized):
+		return c.InfoByLabel(ctx, eth.BlockLabel(label))
+	}
+	return nil, fmt.Errorf("%w: block %v", ErrInvalidCall, block)
+}
+
+func parseCallMsg(callMsg map[string]interface{}) (common.Address, *common.Address, []byte, error) {
+	var from common.Address
+	if v, ok := callMsg["from"].(string); ok {
+		if !common.IsHexAddress(v) {
+			return common.Address{}, nil, nil, fmt.Errorf("%w: from address %q", ErrInvalidCall, v)
+		}
+		from = common.HexToAddress(v)
+	}
+	var to *common.Address
+	if v, ok := callMsg["to"].(string); ok {
+		if !common.IsHexAddress(v) {
+			return common.Address{}, nil, nil, fmt.Errorf("%w: to address %q", ErrInvalidCall, v)
+		}
+		addr := common.HexToAddress(v)
+		to = &addr
+	}
+	v, _ := callMsg["data"].(string)
+	data, err := hexutil.Decode(v)
+	if err != nil {
+		return common.Address{}, nil, nil, fmt.Errorf("%w: data: %w", ErrInvalidCall, err)
+	}
+	return from, to, data, nil
+}
@@ -0,0 +1,123 @@
+package election
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
+)
+
+// fakeStateChain is a chain with a single block, with a contract that returns the block timestamp.
+type fakeStateChain struct {
+	t        *testing.T
+	db       state.Database
+	header   *types.Header
+	contract common.Address
+}
+
+func newFakeStateChain(t *testing.T) *fakeStateChain {
+	db := state.NewDatabase(rawdb.NewMemoryDatabase())
+	statedb, err := state.New(types.EmptyRootHash, db, nil)
+	require.NoError(t, err)
+	contract := common.Address{0xcc}
+	// TIMESTAMP PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	statedb.SetCode(contract, common.FromHex("0x4260005260206000f3"))
+	root, err := statedb.Commit(0, true)
+	require.NoError(t, err)
+	require.NoError(t, db.TrieDB().Commit(root, false))
+	return &fakeStateChain{
+		t:  t,
+		db: db,
+		header: &types.Header{
+			Number:     big.NewInt(7),
+			Time:       1234,
+			Root:       root,
+			GasLimit:   30_000_000,
+			BaseFee:    big.NewInt(1),
+			Difficulty: new(big.Int),
+		},
+		contract: contract,
+	}
+}
+
+func (f *fakeStateChain) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	if hash != f.header.Hash() {
+		return nil, ethereum.NotFound
+	}
+	return eth.HeaderBlockInfo(f.header), nil
+}
+
+func (f *fakeStateChain) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	require.Equal(f.t, uint64(7), number)
+	return eth.HeaderBlockInfo(f.header), nil
+}
+
+func (f *fakeStateChain) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
+	require.Equal(f.t, eth.BlockLabel(eth.Unsafe), label)
+	return eth.HeaderBlockInfo(f.header), nil
+}
+
+func (f *fakeStateChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return state.New(root, f.db, nil)
+}
+
+func (f *fakeStateChain) Config() *params.ChainConfig {
+	return params.AllDevChainProtocolChanges
+}
+
+func (f *fakeStateChain) VMConfig() vm.Config {
+	return vm.Config{}
+}
+
+func TestStateClientCall(t *testing.T) {
+	chain := newFakeStateChain(t)
+	client := NewStateClient(chain)
+	ctx := context.Background()
+	expected := hexutil.Encode(common.BigToHash(big.NewInt(1234)).Bytes())
+
+	res, err := client.Call(ctx, map[string]interface{}{
+		"from": common.Address{}.Hex(),
+		"to":   chain.contract.Hex(),
+		"data": "0x",
+	}, rpcblock.ByNumber(7))
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+
+	// Calls without a recipient return the data returned by the creation code
+	// TIMESTAMP PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	res, err = client.Call(ctx, map[string]interface{}{
+		"from": common.Address{}.Hex(),
+		"to":   nil,
+		"data": "0x4260005260206000f3",
+	}, rpcblock.Latest)
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+
+	// PUSH1 0 DUP1 REVERT
+	_, err = client.Call(ctx, map[string]interface{}{"to": nil, "data": "0x600080fd"}, rpcblock.Latest)
+	require.ErrorIs(t, err, vm.ErrExecutionReverted)
+
+	_, err = client.Call(ctx, map[string]interface{}{"to": "0xinvalid", "data": "0x"}, rpcblock.Latest)
+	require.ErrorIs(t, err, ErrInvalidCall)
+	_, err = client.Call(ctx, map[string]interface{}{"to": nil, "data": "0x"}, rpcblock.Block{})
+	require.ErrorIs(t, err, ErrInvalidCall)
+
+	// Calls at blocks referenced by hash, as the election pins its reads
+	res, err = client.Call(ctx, map[string]interface{}{"to": chain.contract.Hex(), "data": "0x"}, rpcblock.ByHash(chain.header.Hash()))
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+	_, err = client.Call(ctx, map[string]interface{}{"to": chain.contract.Hex(), "data": "0x"}, rpcblock.ByHash(common.Hash{0x01}))
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
@@ -0,0 +1,48 @@
+package election
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// Tracer traces the steps of computing elections: fetching the lookahead, the contract calls,
+// resolving the operators and running the instructions, to debug slow or failing elections.
+type Tracer interface {
+	// OnElectionStep is called when a step of an election completed, with its duration, and its error if it failed.
+	OnElectionStep(step string, duration time.Duration, err error)
+	// OnElection is called when the election of an epoch completed, with the number of winners, or its error if it failed.
+	OnElection(epoch uint64, duration time.Duration, winners int, err error)
+}
+
+type NoopTracer struct{}
+
+func (NoopTracer) OnElectionStep(step string, duration time.Duration, err error) {}
+
+func (NoopTracer) OnElection(epoch uint64, duration time.Duration, winners int, err error) {}
+
+var _ Tracer = NoopTracer{}
+
+// LogTracer logs the steps of elections at the given level.
+type LogTracer struct {
+	log log.Logger
+	lvl slog.Level
+}
+
+var _ Tracer = (*LogTracer)(nil)
+
+func NewLogTracer(log log.Logger, lvl slog.Level) *LogTracer {
+	return &LogTracer{
+		log: log,
+		lvl: lvl,
+	}
+}
+
+func (lt *LogTracer) OnElectionStep(step string, duration time.Duration, err error) {
+	lt.log.Log(lt.lvl, "Election step", "step", step, "duration", duration, "err", err)
+}
+
+func (lt *LogTracer) OnElection(epoch uint64, duration time.Duration, winners int, err error) {
+	lt.log.Log(lt.lvl, "Election", "epoch", epoch, "duration", duration, "winners", winners, "err", err)
+}
@@ -18,6 +18,27 @@ func (e *ElectionClient) GetElectionWinner(time uint64) eth.ElectionWinner {
 	return e.store.GetElectionWinner(time)
 }
 
+// ResolveElectionWinner returns the election winner of the slot of the given timestamp,
+// or an error if the election of its epoch is not known yet.
+func (e *ElectionClient) ResolveElectionWinner(time uint64) (eth.ElectionWinner, error) {
+	return e.store.ResolveElectionWinner(time)
+}
+
+// StoredElectionWinner returns the election winner of the slot of the given timestamp, if it is kept in memory.
+func (e *ElectionClient) StoredElectionWinner(time uint64) (eth.ElectionWinner, bool) {
+	return e.store.StoredElectionWinner(time)
+}
+
+// PersistedElectionWinner returns the election winner of the slot of the given timestamp,
+// if it is kept in memory or stored in the database.
+func (e *ElectionClient) PersistedElectionWinner(time uint64) (eth.ElectionWinner, bool) {
+	return e.store.PersistedElectionWinner(time)
+}
+
 func (e *ElectionClient) GetLastWinnerInCurrentEpoch() eth.ElectionWinner {
 	return e.store.GetLastWinnerInCurrentEpoch()
 }
+
+func (e *ElectionClient) SecondsPerSlot() uint64 {
+	return e.store.SecondsPerSlot()
+}
@@ -0,0 +1,85 @@
+package election_client
+
+import (
+	"sync"
+
+	gethevent "github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// electionFeedBuffer is the number of elections buffered per subscriber.
+// Elections are dropped for subscribers that fall further behind.
+const electionFeedBuffer = 16
+
+// ElectionFeed streams the elections of the ElectionWinnerEvents to subscribers,
+// both of new epochs and of epochs re-run after an L1 reorg.
+// The events are handled on the event loop, so sending never blocks on a subscriber.
+type ElectionFeed struct {
+	log log.Logger
+
+	mu   sync.Mutex
+	subs map[chan *eth.EpochElection]struct{}
+}
+
+func NewElectionFeed(log log.Logger) *ElectionFeed {
+	return &ElectionFeed{
+		log:  log,
+		subs: make(map[chan *eth.EpochElection]struct{}),
+	}
+}
+
+func (f *ElectionFeed) OnEvent(ev event.Event) bool {
+	x, ok := ev.(rollup.ElectionWinnerEvent)
+	if !ok {
+		return false
+	}
+	election := &eth.EpochElection{
+		Epoch:   x.Epoch,
+		L1Block: x.L1Block,
+		L2Block: x.L2Block,
+		Winners: x.ElectionWinners,
+		Tickets: x.Tickets,
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for buf := range f.subs {
+		select {
+		case buf <- election:
+		default:
+			f.log.Warn("Election subscriber is too slow, dropping election", "epoch", election.Epoch)
+		}
+	}
+	return true
+}
+
+// Subscribe streams the elections to the channel. Elections are buffered for the subscriber,
+// and dropped if the subscriber does not keep up.
+func (f *ElectionFeed) Subscribe(ch chan<- *eth.EpochElection) gethevent.Subscription {
+	buf := make(chan *eth.EpochElection, electionFeedBuffer)
+	f.mu.Lock()
+	f.subs[buf] = struct{}{}
+	f.mu.Unlock()
+	return gethevent.NewSubscription(func(quit <-chan struct{}) error {
+		defer func() {
+			f.mu.Lock()
+			delete(f.subs, buf)
+			f.mu.Unlock()
+		}()
+		for {
+			select {
+			case election := <-buf:
+				select {
+				case ch <- election:
+				case <-quit:
+					return nil
+				}
+			case <-quit:
+				return nil
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package election_client
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func TestElectionFeed(t *testing.T) {
+	feed := NewElectionFeed(testlog.Logger(t, log.LevelInfo))
+	elections := make(chan *eth.EpochElection, 1)
+	sub := feed.Subscribe(elections)
+	defer sub.Unsubscribe()
+
+	require.False(t, feed.OnEvent(derive.DeriverIdleEvent{}))
+
+	ev := rollup.ElectionWinnerEvent{
+		Epoch:           7,
+		L1Block:         eth.BlockID{Hash: common.Hash{0x1}, Number: 70},
+		L2Block:         eth.BlockID{Hash: common.Hash{0x2}, Number: 140},
+		ElectionWinners: []*eth.ElectionWinner{{Address: common.Address{0xaa}, Time: 1000}},
+		Tickets:         map[common.Address]uint64{{0xaa}: 2},
+	}
+	require.True(t, feed.OnEvent(ev))
+	require.Equal(t, &eth.EpochElection{
+		Epoch:   ev.Epoch,
+		L1Block: ev.L1Block,
+		L2Block: ev.L2Block,
+		Winners: ev.ElectionWinners,
+		Tickets: ev.Tickets,
+	}, <-elections)
+}
+
+func TestElectionFeedSlowSubscriber(t *testing.T) {
+	feed := NewElectionFeed(testlog.Logger(t, log.LevelInfo))
+	// Nothing reads from the channel of the subscriber
+	stalled := make(chan *eth.EpochElection)
+	sub := feed.Subscribe(stalled)
+	defer sub.Unsubscribe()
+
+	for epoch := uint64(0); epoch < 2*electionFeedBuffer; epoch++ {
+		require.True(t, feed.OnEvent(rollup.ElectionWinnerEvent{Epoch: epoch}))
+	}
+	// The first election is buffered, the elections after the buffer filled up are dropped
+	require.Equal(t, uint64(0), (<-stalled).Epoch)
+}
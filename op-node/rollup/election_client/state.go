@@ -1,8 +1,11 @@
 package election_client
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
@@ -10,19 +13,110 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// ElectionDB persists the election winners, so they are available after a restart
+// and after the in-memory winners were removed as outdated.
+type ElectionDB interface {
+	Enabled() bool
+	StoreElection(election *eth.EpochElection) error
+	LatestElection(ctx context.Context) (*eth.EpochElection, error)
+	ElectionWinnerAt(ctx context.Context, time uint64) (eth.ElectionWinner, error)
+}
+
+// ElectionRecomputer computes the election of an epoch from L1 and L2 state,
+// for epochs the node did not observe the election of.
+type ElectionRecomputer interface {
+	EpochAt(ctx context.Context, timestamp uint64) (uint64, error)
+	RecomputeElection(ctx context.Context, epoch uint64) (*eth.EpochElection, error)
+}
+
+// SlotClock provides the duration of the L1 slots that election winners are assigned to.
+// Implementations are expected to cache the slot time, it is read on every winner lookup.
+type SlotClock interface {
+	GetSecondsPerSlot(ctx context.Context) (uint64, error)
+}
+
+// slotTimeTimeout bounds the time a lookup of an election winner may block on reading the L1 slot time.
+const slotTimeTimeout = 10 * time.Second
+
+// recomputeTimeout bounds the time recomputing the election of an epoch may take.
+const recomputeTimeout = 30 * time.Second
+
+// recomputeRetryInterval is the time after which the election of an epoch is recomputed again,
+// after recomputing it failed.
+const recomputeRetryInterval = time.Minute
+
+// recomputeQueueSize bounds the number of lookups of missing winners waiting for the background recompute.
+// Lookups are not queued while the queue is full, the winner of the slot stays unknown until it is looked up again.
+const recomputeQueueSize = 64
+
+// ErrElectionNotKnown is returned when the winner of a slot is resolved before the election of its epoch is known.
+var ErrElectionNotKnown = errors.New("election of epoch is not known")
+
 type ElectionStore struct {
 	electionWinnersMap map[uint64]*eth.ElectionWinner
 	log                log.Logger
 	latestWinner       *eth.ElectionWinner
 
+	// epochs that the winners are known of, either from the election deriver or from recomputing the election
+	epochs map[uint64]struct{}
+	// epochs that recomputing the election failed for, with the time of the failure
+	failedEpochs map[uint64]time.Time
+	// times of lookups of missing winners, to recompute the elections of in the background.
+	// nil if elections are recomputed synchronously.
+	recomputeReqs chan uint64
+
+	db         ElectionDB
+	recomputer ElectionRecomputer
+	clock      SlotClock
+	// slotTime is the last L1 slot time read from the clock, 0 if it was not read yet
+	slotTime uint64
+
 	mu sync.Mutex
 }
 
-func NewElectionStore(log log.Logger) *ElectionStore {
-	return &ElectionStore{
+// NewElectionStore creates an ElectionStore. The recomputer may be nil,
+// in which case only the winners of elections observed by the election deriver are known.
+// The clock may be nil if the L2 block time equals the L1 slot time, in which case winners are looked up by exact time.
+//
+// Lookups of winners never block on recomputing an election: the winner of a slot of an epoch that is not known
+// is returned as unknown, and the election of the epoch is recomputed in the background until ctx is done.
+func NewElectionStore(ctx context.Context, log log.Logger, db ElectionDB, recomputer ElectionRecomputer, clock SlotClock) *ElectionStore {
+	e := newElectionStore(log, db, recomputer, clock)
+	if recomputer != nil {
+		e.recomputeReqs = make(chan uint64, recomputeQueueSize)
+		go e.recomputeLoop(ctx)
+	}
+	return e
+}
+
+// NewSyncElectionStore creates an ElectionStore that recomputes missing elections while the winner is looked up.
+// This is only meant for the fault proof program, which has to derive with the winners of every epoch,
+// and does not observe any election live.
+func NewSyncElectionStore(log log.Logger, db ElectionDB, recomputer ElectionRecomputer, clock SlotClock) *ElectionStore {
+	return newElectionStore(log, db, recomputer, clock)
+}
+
+func newElectionStore(log log.Logger, db ElectionDB, recomputer ElectionRecomputer, clock SlotClock) *ElectionStore {
+	e := &ElectionStore{
 		electionWinnersMap: make(map[uint64]*eth.ElectionWinner),
+		epochs:             make(map[uint64]struct{}),
+		failedEpochs:       make(map[uint64]time.Time),
 		log:                log,
+		db:                 db,
+		recomputer:         recomputer,
+		clock:              clock,
+	}
+	// Restore the winners of the latest epoch, so the node does not need to wait for the next election after a restart.
+	if db.Enabled() {
+		latest, err := db.LatestElection(context.Background())
+		if err != nil {
+			log.Info("No stored election winners to restore", "err", err)
+		} else {
+			e.StoreElectionWinners(latest.Winners)
+			e.epochs[latest.Epoch] = struct{}{}
+		}
 	}
+	return e
 }
 
 func (e *ElectionStore) OnEvent(ev event.Event) bool {
@@ -32,6 +126,18 @@ func (e *ElectionStore) OnEvent(ev event.Event) bool {
 	switch x := ev.(type) {
 	case rollup.ElectionWinnerEvent:
 		e.StoreElectionWinners(x.ElectionWinners)
+		e.epochs[x.Epoch] = struct{}{}
+		if len(x.ElectionWinners) > 0 {
+			err := e.db.StoreElection(&eth.EpochElection{
+				Epoch:   x.Epoch,
+				L1Block: x.L1Block,
+				L2Block: x.L2Block,
+				Winners: x.ElectionWinners,
+			})
+			if err != nil {
+				e.log.Error("Failed to persist election winners", "epoch", x.Epoch, "err", err)
+			}
+		}
 	case rollup.ElectionWinnerOutdatedEvent:
 		// remove all election winners with a timestamp less than the outdated timestamp
 		e.RemoveOutdatedElectionWinners(x.Time)
@@ -42,7 +148,167 @@ func (e *ElectionStore) OnEvent(ev event.Event) bool {
 	return true
 }
 
+// GetElectionWinner returns the election winner of the slot that the given timestamp falls in.
+// With L2 blocks shorter than the L1 slot, the winner of a slot covers all L2 blocks of the slot.
+// Winners that are no longer kept in memory are read from the database,
+// and the election is recomputed if the winners of the epoch are not known at all.
+// Unless the store recomputes synchronously, the winner is unknown until the recompute completed.
 func (e *ElectionStore) GetElectionWinner(time uint64) eth.ElectionWinner {
+	slotTime := e.SecondsPerSlot()
+	if winner, ok := e.lookupElectionWinner(time, slotTime); ok {
+		return winner
+	}
+	if e.recomputer == nil {
+		return eth.ElectionWinner{}
+	}
+	if e.recomputeReqs == nil {
+		return e.recomputeElectionWinner(time, slotTime)
+	}
+	e.requestRecompute(time)
+	return eth.ElectionWinner{}
+}
+
+// ResolveElectionWinner returns the election winner of the slot that the given timestamp falls in, like GetElectionWinner,
+// but tells slots without a winner apart from slots of epochs that the election is not known of yet.
+// For the latter it returns ErrElectionNotKnown, after requesting the recompute of the election,
+// so that the derivation retries the slot once the election is known, instead of deriving it without a winner.
+// A store that recomputes synchronously returns the error of the failed recompute instead.
+func (e *ElectionStore) ResolveElectionWinner(time uint64) (eth.ElectionWinner, error) {
+	slotTime := e.SecondsPerSlot()
+	if winner, ok := e.lookupElectionWinner(time, slotTime); ok {
+		return winner, nil
+	}
+	if e.recomputer == nil {
+		// Only the elections observed by the election deriver are known, the slot has no winner.
+		return eth.ElectionWinner{}, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), recomputeTimeout)
+	defer cancel()
+	epoch, err := e.recomputer.EpochAt(ctx, time)
+	if err != nil {
+		return eth.ElectionWinner{}, fmt.Errorf("failed to get epoch of slot at time %d: %w", time, err)
+	}
+	e.mu.Lock()
+	_, known := e.epochs[epoch]
+	e.mu.Unlock()
+	if !known {
+		if e.recomputeReqs != nil {
+			e.requestRecompute(time)
+			return eth.ElectionWinner{}, fmt.Errorf("%w: epoch %d", ErrElectionNotKnown, epoch)
+		}
+		if _, err := e.recomputeEpoch(ctx, epoch); err != nil {
+			return eth.ElectionWinner{}, fmt.Errorf("%w: epoch %d: %w", ErrElectionNotKnown, epoch, err)
+		}
+	}
+	// The election of the epoch is known, the winners may have been stored since the first lookup.
+	winner, _ := e.lookupElectionWinner(time, slotTime)
+	return winner, nil
+}
+
+// lookupElectionWinner returns the election winner of the slot of the given time from memory or the database.
+func (e *ElectionStore) lookupElectionWinner(time uint64, slotTime uint64) (eth.ElectionWinner, bool) {
+	e.mu.Lock()
+	if e.latestWinner != nil {
+		time = slotStart(time, e.latestWinner.Time, slotTime)
+	}
+	out := e.electionWinnersMap[time]
+	e.mu.Unlock()
+	if out != nil {
+		return *out, true
+	}
+	winner, err := e.db.ElectionWinnerAt(context.Background(), time)
+	if err == nil {
+		return winner, true
+	}
+	return eth.ElectionWinner{}, false
+}
+
+// requestRecompute requests the background recompute of the election of the epoch of the given time.
+func (e *ElectionStore) requestRecompute(time uint64) {
+	select {
+	case e.recomputeReqs <- time:
+	default:
+		e.log.Debug("Election recompute queue is full, not recomputing", "time", time)
+	}
+}
+
+// StoredElectionWinner returns the election winner of the slot that the given timestamp falls in,
+// only if the winner is kept in memory. Unlike GetElectionWinner it never reads the database, the L1 slot time,
+// or triggers a recompute, so it is cheap enough for untrusted callers such as gossip validation.
+func (e *ElectionStore) StoredElectionWinner(time uint64) (eth.ElectionWinner, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.latestWinner != nil {
+		time = slotStart(time, e.latestWinner.Time, e.slotTime)
+	}
+	out := e.electionWinnersMap[time]
+	if out == nil {
+		return eth.ElectionWinner{}, false
+	}
+	return *out, true
+}
+
+// PersistedElectionWinner returns the election winner of the slot that the given timestamp falls in,
+// if the winner is kept in memory or was stored in the database. Unlike GetElectionWinner it never triggers a recompute,
+// so it serves the winners of any epoch the node stored to untrusted callers, such as RPC clients.
+func (e *ElectionStore) PersistedElectionWinner(time uint64) (eth.ElectionWinner, bool) {
+	return e.lookupElectionWinner(time, e.SecondsPerSlot())
+}
+
+func (e *ElectionStore) recomputeLoop(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case t := <-e.recomputeReqs:
+			e.recomputeElection(ctx, t)
+		}
+	}
+}
+
+// SecondsPerSlot returns the L1 slot time, or 0 if it is not known.
+func (e *ElectionStore) SecondsPerSlot() uint64 {
+	if e.clock == nil {
+		return 0
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), slotTimeTimeout)
+	defer cancel()
+	slotTime, err := e.clock.GetSecondsPerSlot(ctx)
+	if err != nil {
+		e.log.Warn("Failed to get L1 slot time", "err", err)
+		return 0
+	}
+	e.mu.Lock()
+	e.slotTime = slotTime
+	e.mu.Unlock()
+	return slotTime
+}
+
+// slotStart returns the start time of the slot that the given time falls in.
+// Slots start at the times of election winners, so the time of any winner aligns the slots.
+func slotStart(time uint64, winnerTime uint64, slotTime uint64) uint64 {
+	if slotTime == 0 {
+		return time
+	}
+	offset := (time%slotTime + slotTime - winnerTime%slotTime) % slotTime
+	if offset > time {
+		return time
+	}
+	return time - offset
+}
+
+// recomputeElectionWinner recomputes the election of the epoch that the given time falls in,
+// and returns the winner of the slot of the given time.
+func (e *ElectionStore) recomputeElectionWinner(time uint64, slotTime uint64) eth.ElectionWinner {
+	election := e.recomputeElection(context.Background(), time)
+	if election == nil {
+		return eth.ElectionWinner{}
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(election.Winners) > 0 {
+		time = slotStart(time, election.Winners[0].Time, slotTime)
+	}
 	out := e.electionWinnersMap[time]
 	if out == nil {
 		return eth.ElectionWinner{}
@@ -50,7 +316,65 @@ func (e *ElectionStore) GetElectionWinner(time uint64) eth.ElectionWinner {
 	return *out
 }
 
+// recomputeElection recomputes and stores the election of the epoch that the given time falls in.
+// The election is not recomputed if the winners of the epoch are known,
+// or if recomputing it failed less than recomputeRetryInterval ago.
+// Returns nil if the election was not recomputed.
+func (e *ElectionStore) recomputeElection(ctx context.Context, t uint64) *eth.EpochElection {
+	ctx, cancel := context.WithTimeout(ctx, recomputeTimeout)
+	defer cancel()
+	epoch, err := e.recomputer.EpochAt(ctx, t)
+	if err != nil {
+		e.log.Warn("Failed to get epoch of election winner", "time", t, "err", err)
+		return nil
+	}
+	e.mu.Lock()
+	_, known := e.epochs[epoch]
+	failedAt, failed := e.failedEpochs[epoch]
+	e.mu.Unlock()
+	if known {
+		// The election of the epoch is known, there is no winner at this time.
+		return nil
+	}
+	if failed && time.Since(failedAt) < recomputeRetryInterval {
+		return nil
+	}
+	election, err := e.recomputeEpoch(ctx, epoch)
+	if err != nil {
+		return nil
+	}
+	return election
+}
+
+// recomputeEpoch recomputes and stores the election of the epoch.
+func (e *ElectionStore) recomputeEpoch(ctx context.Context, epoch uint64) (*eth.EpochElection, error) {
+	election, err := e.recomputer.RecomputeElection(ctx, epoch)
+	if err != nil {
+		e.log.Warn("Failed to recompute election", "epoch", epoch, "err", err)
+		e.mu.Lock()
+		e.failedEpochs[epoch] = time.Now()
+		e.mu.Unlock()
+		return nil, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.failedEpochs, epoch)
+	// Winners observed by the election deriver in the meantime take precedence.
+	if _, known := e.epochs[epoch]; !known {
+		// Recomputed winners also align the slots of later lookups, if no election was observed before.
+		e.StoreElectionWinners(election.Winners)
+		e.epochs[epoch] = struct{}{}
+		if err := e.db.StoreElection(election); err != nil {
+			e.log.Error("Failed to persist election winners", "epoch", epoch, "err", err)
+		}
+	}
+	return election, nil
+}
+
 func (e *ElectionStore) GetLastWinnerInCurrentEpoch() eth.ElectionWinner {
+	e.mu.Lock()
+	defer e.mu.Unlock()
 	out := e.latestWinner
 	if out == nil {
 		return eth.ElectionWinner{}
@@ -0,0 +1,225 @@
+package election_client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+type noDB struct{}
+
+func (noDB) Enabled() bool                                   { return false }
+func (noDB) StoreElection(election *eth.EpochElection) error { return nil }
+func (noDB) LatestElection(ctx context.Context) (*eth.EpochElection, error) {
+	return nil, errors.New("disabled")
+}
+func (noDB) ElectionWinnerAt(ctx context.Context, time uint64) (eth.ElectionWinner, error) {
+	return eth.ElectionWinner{}, errors.New("disabled")
+}
+
+type fixedSlotClock uint64
+
+func (c fixedSlotClock) GetSecondsPerSlot(ctx context.Context) (uint64, error) {
+	return uint64(c), nil
+}
+
+// stubRecomputer recomputes elections of 32 second epochs, with a winner every 4 seconds.
+type stubRecomputer struct {
+	recomputes atomic.Int32
+	err        error
+	release    chan struct{}
+}
+
+func (r *stubRecomputer) EpochAt(ctx context.Context, timestamp uint64) (uint64, error) {
+	return timestamp / 32, nil
+}
+
+func (r *stubRecomputer) RecomputeElection(ctx context.Context, epoch uint64) (*eth.EpochElection, error) {
+	r.recomputes.Add(1)
+	if r.release != nil {
+		<-r.release
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	election := &eth.EpochElection{Epoch: epoch}
+	for i := uint64(0); i < 8; i++ {
+		election.Winners = append(election.Winners, &eth.ElectionWinner{Address: common.Address{0xaa}, Time: epoch*32 + i*4})
+	}
+	return election, nil
+}
+
+func TestSlotStart(t *testing.T) {
+	require.Equal(t, uint64(1005), slotStart(1005, 17, 0))
+	require.Equal(t, uint64(1004), slotStart(1004, 1000, 4))
+	require.Equal(t, uint64(1004), slotStart(1007, 1000, 4))
+	require.Equal(t, uint64(1008), slotStart(1008, 1000, 4))
+	// Times before the aligning winner
+	require.Equal(t, uint64(996), slotStart(999, 1000, 4))
+	// Times before the first slot are not aligned
+	require.Equal(t, uint64(0), slotStart(0, 1001, 4))
+}
+
+func TestSubSlotElectionWinners(t *testing.T) {
+	store := NewElectionStore(context.Background(), testlog.Logger(t, log.LevelInfo), noDB{}, nil, fixedSlotClock(4))
+	winners := []*eth.ElectionWinner{
+		{Address: common.Address{0xaa}, Time: 1000},
+		{Address: common.Address{0xbb}, Time: 1004},
+		{Time: 1008, Permissionless: true},
+	}
+	store.OnEvent(rollup.ElectionWinnerEvent{ElectionWinners: winners, Epoch: 1})
+
+	require.Equal(t, uint64(4), store.SecondsPerSlot())
+	// With 2 second L2 blocks, each winner covers two L2 blocks
+	require.Equal(t, *winners[0], store.GetElectionWinner(1000))
+	require.Equal(t, *winners[0], store.GetElectionWinner(1002))
+	require.Equal(t, *winners[1], store.GetElectionWinner(1004))
+	require.Equal(t, *winners[1], store.GetElectionWinner(1006))
+	require.Equal(t, *winners[2], store.GetElectionWinner(1010))
+	// No winner is known past the last slot
+	require.Equal(t, eth.ElectionWinner{}, store.GetElectionWinner(1012))
+	require.Equal(t, *winners[2], store.GetLastWinnerInCurrentEpoch())
+
+	t.Run("without clock", func(t *testing.T) {
+		store := NewElectionStore(context.Background(), testlog.Logger(t, log.LevelInfo), noDB{}, nil, nil)
+		store.OnEvent(rollup.ElectionWinnerEvent{ElectionWinners: winners, Epoch: 1})
+		require.Equal(t, uint64(0), store.SecondsPerSlot())
+		require.Equal(t, *winners[1], store.GetElectionWinner(1004))
+		require.Equal(t, eth.ElectionWinner{}, store.GetElectionWinner(1006))
+	})
+}
+
+func TestRecomputeElection(t *testing.T) {
+	t.Run("async", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		recomputer := &stubRecomputer{release: make(chan struct{})}
+		store := NewElectionStore(ctx, testlog.Logger(t, log.LevelInfo), noDB{}, recomputer, fixedSlotClock(4))
+		// The lookup does not block on the recompute
+		require.Equal(t, eth.ElectionWinner{}, store.GetElectionWinner(1000))
+		close(recomputer.release)
+		expected := eth.ElectionWinner{Address: common.Address{0xaa}, Time: 1000}
+		require.Eventually(t, func() bool {
+			return store.GetElectionWinner(1002) == expected
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Equal(t, int32(1), recomputer.recomputes.Load())
+	})
+
+	t.Run("failures are cached per epoch", func(t *testing.T) {
+		recomputer := &stubRecomputer{err: errors.New("boom")}
+		store := NewSyncElectionStore(testlog.Logger(t, log.LevelInfo), noDB{}, recomputer, fixedSlotClock(4))
+		require.Equal(t, eth.ElectionWinner{}, store.GetElectionWinner(1000))
+		require.Equal(t, eth.ElectionWinner{}, store.GetElectionWinner(1004))
+		require.Equal(t, int32(1), recomputer.recomputes.Load())
+		// Other epochs are still recomputed
+		require.Equal(t, eth.ElectionWinner{}, store.GetElectionWinner(1100))
+		require.Equal(t, int32(2), recomputer.recomputes.Load())
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		recomputer := &stubRecomputer{}
+		store := NewSyncElectionStore(testlog.Logger(t, log.LevelInfo), noDB{}, recomputer, fixedSlotClock(4))
+		require.Equal(t, eth.ElectionWinner{Address: common.Address{0xaa}, Time: 1000}, store.GetElectionWinner(1002))
+		require.Equal(t, eth.ElectionWinner{Address: common.Address{0xaa}, Time: 1004}, store.GetElectionWinner(1004))
+		require.Equal(t, int32(1), recomputer.recomputes.Load())
+	})
+}
+
+func TestResolveElectionWinner(t *testing.T) {
+	t.Run("pending recompute", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		recomputer := &stubRecomputer{release: make(chan struct{})}
+		store := NewElectionStore(ctx, testlog.Logger(t, log.LevelInfo), noDB{}, recomputer, fixedSlotClock(4))
+		// The winner is not known yet, rather than not elected
+		_, err := store.ResolveElectionWinner(1000)
+		require.ErrorIs(t, err, ErrElectionNotKnown)
+		close(recomputer.release)
+		expected := eth.ElectionWinner{Address: common.Address{0xaa}, Time: 1000}
+		require.Eventually(t, func() bool {
+			winner, err := store.ResolveElectionWinner(1002)
+			return err == nil && winner == expected
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("known epoch without winner", func(t *testing.T) {
+		store := NewSyncElectionStore(testlog.Logger(t, log.LevelInfo), noDB{}, &stubRecomputer{}, nil)
+		store.OnEvent(rollup.ElectionWinnerEvent{ElectionWinners: []*eth.ElectionWinner{{Address: common.Address{0xaa}, Time: 992}}, Epoch: 31})
+		winner, err := store.ResolveElectionWinner(994)
+		require.NoError(t, err)
+		require.Equal(t, eth.ElectionWinner{}, winner)
+	})
+
+	t.Run("sync recompute failure", func(t *testing.T) {
+		store := NewSyncElectionStore(testlog.Logger(t, log.LevelInfo), noDB{}, &stubRecomputer{err: errors.New("boom")}, fixedSlotClock(4))
+		_, err := store.ResolveElectionWinner(1000)
+		require.ErrorIs(t, err, ErrElectionNotKnown)
+		require.ErrorContains(t, err, "boom")
+	})
+}
+
+func TestStoredElectionWinner(t *testing.T) {
+	recomputer := &stubRecomputer{}
+	store := NewSyncElectionStore(testlog.Logger(t, log.LevelInfo), noDB{}, recomputer, fixedSlotClock(4))
+	_, ok := store.StoredElectionWinner(1000)
+	require.False(t, ok)
+	require.Zero(t, recomputer.recomputes.Load(), "stored lookups never recompute")
+
+	winners := []*eth.ElectionWinner{{Address: common.Address{0xaa}, Time: 1000}}
+	store.OnEvent(rollup.ElectionWinnerEvent{ElectionWinners: winners, Epoch: 31})
+	winner, ok := store.StoredElectionWinner(1000)
+	require.True(t, ok)
+	require.Equal(t, *winners[0], winner)
+	// Sub-slot times are aligned once the slot time was read
+	_, ok = store.StoredElectionWinner(1002)
+	require.False(t, ok)
+	require.Equal(t, uint64(4), store.SecondsPerSlot())
+	winner, ok = store.StoredElectionWinner(1002)
+	require.True(t, ok)
+	require.Equal(t, *winners[0], winner)
+}
+
+// winnerDB stores the winners of elections by the start time of their slot.
+type winnerDB map[uint64]eth.ElectionWinner
+
+func (db winnerDB) Enabled() bool                                   { return true }
+func (db winnerDB) StoreElection(election *eth.EpochElection) error { return nil }
+func (db winnerDB) LatestElection(ctx context.Context) (*eth.EpochElection, error) {
+	return nil, errors.New("not found")
+}
+func (db winnerDB) ElectionWinnerAt(ctx context.Context, time uint64) (eth.ElectionWinner, error) {
+	winner, ok := db[time]
+	if !ok {
+		return eth.ElectionWinner{}, errors.New("not found")
+	}
+	return winner, nil
+}
+
+func TestPersistedElectionWinner(t *testing.T) {
+	recomputer := &stubRecomputer{}
+	old := eth.ElectionWinner{Address: common.Address{0xbb}, Time: 200}
+	store := NewSyncElectionStore(testlog.Logger(t, log.LevelInfo), winnerDB{old.Time: old}, recomputer, fixedSlotClock(4))
+	winners := []*eth.ElectionWinner{{Address: common.Address{0xaa}, Time: 1000}}
+	store.OnEvent(rollup.ElectionWinnerEvent{ElectionWinners: winners, Epoch: 31})
+
+	winner, ok := store.PersistedElectionWinner(1002)
+	require.True(t, ok)
+	require.Equal(t, *winners[0], winner)
+	// Winners that are no longer kept in memory are read from the database
+	winner, ok = store.PersistedElectionWinner(203)
+	require.True(t, ok)
+	require.Equal(t, old, winner)
+	_, ok = store.PersistedElectionWinner(500)
+	require.False(t, ok)
+	require.Zero(t, recomputer.recomputes.Load(), "persisted lookups never recompute")
+}
@@ -1,6 +1,8 @@
 package rollup
 
 import (
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
@@ -56,6 +58,13 @@ func (ev LivenessErrorEvent) String() string {
 // is there a better place to put this / better way to do this?
 type ElectionWinnerEvent struct {
 	ElectionWinners []*eth.ElectionWinner
+	// Epoch is the epoch the election winners were computed for
+	Epoch uint64
+	// L1Block and L2Block are the blocks the election inputs were read from
+	L1Block eth.BlockID
+	L2Block eth.BlockID
+	// Tickets is the ticket count of each operator of the lookahead, before the election burned any
+	Tickets map[common.Address]uint64
 }
 
 func (ev ElectionWinnerEvent) String() string {
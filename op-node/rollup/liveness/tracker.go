@@ -0,0 +1,292 @@
+package liveness
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/event"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	// maxSlots bounds the number of slots that the liveness records are kept of.
+	maxSlots = 8192
+	// maxElections bounds the number of elections that are kept to prove the winners of missed slots.
+	maxElections = 64
+	// l1QueueSize bounds the number of traversed L1 blocks waiting to be checked.
+	// The slots of L1 blocks traversed while the queue is full are not tracked.
+	l1QueueSize = 256
+	// electionLookback is the number of L1 blocks before the first restored slot to restore the elections from,
+	// as elections are computed before the epoch of the slots they elect.
+	electionLookback = 64
+)
+
+var ErrSlotNotTracked = errors.New("slot is not tracked")
+
+type L1Fetcher interface {
+	FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error)
+}
+
+type ElectionWinners interface {
+	GetElectionWinner(time uint64) eth.ElectionWinner
+}
+
+// DB persists the liveness records, and provides the elections to restore after a restart.
+type DB interface {
+	StoreSlotLiveness(slot eth.SlotLiveness) error
+	// DeleteSlotLiveness deletes the records of the L1 blocks from the first up to, excluding, the second number.
+	DeleteSlotLiveness(from uint64, to uint64) error
+	LatestSlotLiveness(ctx context.Context, count int) ([]eth.SlotLiveness, error)
+	ElectionsSinceL1(ctx context.Context, l1BlockNum uint64) ([]*eth.EpochElection, error)
+}
+
+type Metrics interface {
+	RecordSequencerSlot(submitted bool)
+}
+
+// Tracker checks for every L1 block traversed by the derivation whether the election winner of its slot
+// submitted a valid batch in it, and keeps the liveness records of the operators.
+// Slots without a winner, and permissionless slots, have no operator to hold accountable and are not tracked.
+// The L1 blocks are checked in the background, so fetching their receipts does not block the event loop.
+type Tracker struct {
+	log     log.Logger
+	ctx     context.Context
+	cfg     *rollup.Config
+	l1      L1Fetcher
+	winners ElectionWinners
+	db      DB
+	metrics Metrics
+
+	// l1Blocks are the traversed L1 blocks waiting to be checked, in traversal order.
+	l1Blocks chan eth.L1BlockRef
+
+	mu sync.Mutex
+	// slots are the liveness records of the tracked slots, in L1 block order.
+	slots []eth.SlotLiveness
+	// elections are the latest elections, to prove the winner of a slot.
+	elections []*eth.EpochElection
+}
+
+var _ event.Deriver = (*Tracker)(nil)
+
+// NewTracker creates a Tracker that restores the records persisted in the db,
+// and checks the traversed L1 blocks until ctx is done.
+func NewTracker(ctx context.Context, log log.Logger, cfg *rollup.Config, l1 L1Fetcher, winners ElectionWinners, db DB, metrics Metrics) *Tracker {
+	t := &Tracker{
+		log:      log,
+		ctx:      ctx,
+		cfg:      cfg,
+		l1:       l1,
+		winners:  winners,
+		db:       db,
+		metrics:  metrics,
+		l1Blocks: make(chan eth.L1BlockRef, l1QueueSize),
+	}
+	t.restore()
+	go t.run()
+	return t
+}
+
+func (t *Tracker) restore() {
+	slots, err := t.db.LatestSlotLiveness(t.ctx, maxSlots)
+	if err != nil {
+		t.log.Error("Failed to restore slot liveness records", "err", err)
+		return
+	}
+	if len(slots) == 0 {
+		return
+	}
+	t.slots = slots
+	first := slots[0].L1Block.Number
+	elections, err := t.db.ElectionsSinceL1(t.ctx, first-min(first, electionLookback))
+	if err != nil {
+		t.log.Error("Failed to restore elections of slot liveness records", "err", err)
+	}
+	for _, election := range elections {
+		t.addElection(election)
+	}
+	t.log.Info("Restored slot liveness records", "slots", len(t.slots), "elections", len(t.elections))
+}
+
+func (t *Tracker) run() {
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case ref := <-t.l1Blocks:
+			t.checkL1Block(ref)
+		}
+	}
+}
+
+func (t *Tracker) OnEvent(ev event.Event) bool {
+	switch x := ev.(type) {
+	case derive.DeriverL1StatusEvent:
+		select {
+		case t.l1Blocks <- x.Origin:
+		default:
+			t.log.Warn("Liveness tracker is behind, slot liveness is not tracked", "l1", x.Origin)
+		}
+	case rollup.ElectionWinnerEvent:
+		t.addElection(&eth.EpochElection{
+			Epoch:   x.Epoch,
+			L1Block: x.L1Block,
+			L2Block: x.L2Block,
+			Winners: x.ElectionWinners,
+		})
+	default:
+		return false
+	}
+	return true
+}
+
+func (t *Tracker) checkL1Block(ref eth.L1BlockRef) {
+	t.mu.Lock()
+	// The derivation traverses L1 blocks in order, an older block means it was reset, e.g. because of a reorg.
+	// Drop the records of the blocks that are traversed again.
+	if i := slices.IndexFunc(t.slots, func(s eth.SlotLiveness) bool { return s.L1Block.Number >= ref.Number }); i >= 0 {
+		t.log.Info("Dropping liveness records of re-traversed L1 blocks", "from", ref.Number, "count", len(t.slots)-i)
+		t.slots = t.slots[:i]
+		if err := t.db.DeleteSlotLiveness(ref.Number, math.MaxUint64); err != nil {
+			t.log.Error("Failed to delete liveness records of re-traversed L1 blocks", "from", ref.Number, "err", err)
+		}
+	}
+	t.mu.Unlock()
+
+	winner := t.winners.GetElectionWinner(ref.Time)
+	if winner.Address == (common.Address{}) || winner.Permissionless {
+		return
+	}
+	_, receipts, err := t.l1.FetchReceipts(t.ctx, ref.Hash)
+	if err != nil {
+		t.log.Warn("Failed to fetch L1 receipts, slot liveness is not tracked", "l1", ref, "err", err)
+		return
+	}
+	slot := eth.SlotLiveness{Winner: winner, L1Block: ref.ID()}
+	for _, receipt := range receipts {
+		if receipt.Status == types.ReceiptStatusSuccessful && derive.IsValidBatchTx(t.cfg, receipt, winner, t.log) {
+			slot.Submitted = true
+			slot.BatchTx = receipt.TxHash
+			break
+		}
+	}
+	if !slot.Submitted {
+		t.log.Warn("Election winner missed its slot", "winner", winner, "l1", ref)
+	}
+	t.metrics.RecordSequencerSlot(slot.Submitted)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.slots = append(t.slots, slot)
+	if err := t.db.StoreSlotLiveness(slot); err != nil {
+		t.log.Error("Failed to persist slot liveness", "l1", ref, "err", err)
+	}
+	if len(t.slots) > maxSlots {
+		t.slots = slices.Delete(t.slots, 0, len(t.slots)-maxSlots)
+		if err := t.db.DeleteSlotLiveness(0, t.slots[0].L1Block.Number); err != nil {
+			t.log.Error("Failed to prune slot liveness records", "err", err)
+		}
+	}
+}
+
+func (t *Tracker) addElection(election *eth.EpochElection) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// A recomputed election replaces the previous election of the epoch
+	t.elections = slices.DeleteFunc(t.elections, func(e *eth.EpochElection) bool { return e.Epoch == election.Epoch })
+	t.elections = append(t.elections, election)
+	if len(t.elections) > maxElections {
+		t.elections = slices.Delete(t.elections, 0, len(t.elections)-maxElections)
+	}
+}
+
+// SequencerLiveness returns the liveness record of the operator, over the tracked slots.
+func (t *Tracker) SequencerLiveness(operator common.Address) *eth.SequencerLiveness {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	out := &eth.SequencerLiveness{Operator: operator, MissedSlots: []eth.SlotLiveness{}}
+	if len(t.slots) > 0 {
+		out.FromL1 = t.slots[0].L1Block
+		out.ToL1 = t.slots[len(t.slots)-1].L1Block
+	}
+	for _, slot := range t.slots {
+		if slot.Winner.Address != operator {
+			continue
+		}
+		out.Won++
+		if !slot.Submitted {
+			out.Missed++
+			out.MissedSlots = append(out.MissedSlots, slot)
+		}
+	}
+	return out
+}
+
+// MissedSlotProof returns the evidence that the winner of the slot of the given L1 block did not submit a valid batch.
+func (t *Tracker) MissedSlotProof(ctx context.Context, l1BlockNum uint64) (*eth.MissedSlotProof, error) {
+	t.mu.Lock()
+	i := slices.IndexFunc(t.slots, func(s eth.SlotLiveness) bool { return s.L1Block.Number == l1BlockNum })
+	var slot eth.SlotLiveness
+	if i >= 0 {
+		slot = t.slots[i]
+	}
+	election := t.electionOf(slot.Winner)
+	t.mu.Unlock()
+
+	if i < 0 {
+		return nil, fmt.Errorf("%w: L1 block %d", ErrSlotNotTracked, l1BlockNum)
+	}
+	if slot.Submitted {
+		return nil, fmt.Errorf("winner %s submitted batch %s in L1 block %s", slot.Winner, slot.BatchTx, slot.L1Block)
+	}
+	if election == nil {
+		return nil, fmt.Errorf("election of winner %s is not known", slot.Winner)
+	}
+
+	info, receipts, err := t.l1.FetchReceipts(ctx, slot.L1Block.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch receipts of L1 block %s: %w", slot.L1Block, err)
+	}
+	header, err := info.HeaderRLP()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode header of L1 block %s: %w", slot.L1Block, err)
+	}
+	proof := &eth.MissedSlotProof{
+		Slot:         slot,
+		Header:       header,
+		ReceiptsRoot: info.ReceiptHash(),
+		Receipts:     make([]hexutil.Bytes, 0, len(receipts)),
+		Election:     election,
+	}
+	for _, receipt := range receipts {
+		data, err := receipt.MarshalBinary()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode receipt %s: %w", receipt.TxHash, err)
+		}
+		proof.Receipts = append(proof.Receipts, data)
+	}
+	return proof, nil
+}
+
+// electionOf returns the election that the winner won its slot in, or nil if it is not known.
+func (t *Tracker) electionOf(winner eth.ElectionWinner) *eth.EpochElection {
+	for _, election := range t.elections {
+		for _, w := range election.Winners {
+			if *w == winner {
+				return election
+			}
+		}
+	}
+	return nil
+}
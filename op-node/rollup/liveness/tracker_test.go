@@ -0,0 +1,189 @@
+package liveness
+
+import (
+	"cmp"
+	"context"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/testutils"
+	"github.com/ethereum-optimism/optimism/packages/contracts-bedrock/snapshots"
+)
+
+type winnersFn func(time uint64) eth.ElectionWinner
+
+func (fn winnersFn) GetElectionWinner(time uint64) eth.ElectionWinner {
+	return fn(time)
+}
+
+// memDB keeps the liveness records in memory.
+type memDB struct {
+	mu        sync.Mutex
+	slots     map[uint64]eth.SlotLiveness
+	elections []*eth.EpochElection
+}
+
+func (m *memDB) StoreSlotLiveness(slot eth.SlotLiveness) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.slots[slot.L1Block.Number] = slot
+	return nil
+}
+
+func (m *memDB) DeleteSlotLiveness(from uint64, to uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for num := range m.slots {
+		if num >= from && num < to {
+			delete(m.slots, num)
+		}
+	}
+	return nil
+}
+
+func (m *memDB) LatestSlotLiveness(ctx context.Context, count int) ([]eth.SlotLiveness, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []eth.SlotLiveness
+	for _, slot := range m.slots {
+		out = append(out, slot)
+	}
+	slices.SortFunc(out, func(a, b eth.SlotLiveness) int { return cmp.Compare(a.L1Block.Number, b.L1Block.Number) })
+	return out[max(0, len(out)-count):], nil
+}
+
+func (m *memDB) ElectionsSinceL1(ctx context.Context, l1BlockNum uint64) ([]*eth.EpochElection, error) {
+	return m.elections, nil
+}
+
+func batchReceipt(cfg *rollup.Config, submitter common.Address, txHash common.Hash) *types.Receipt {
+	topic0 := snapshots.LoadBatchInboxABI().Events["BatchSubmitted"].ID
+	return &types.Receipt{
+		Status: types.ReceiptStatusSuccessful,
+		TxHash: txHash,
+		Logs: []*types.Log{{
+			Address: cfg.BatchInboxContractAddress,
+			Topics:  []common.Hash{topic0, common.BytesToHash(submitter.Bytes())},
+		}},
+	}
+}
+
+func TestTracker(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{BatchInboxContractAddress: common.Address{0x42}}
+	alice, bob := common.Address{0xaa}, common.Address{0xbb}
+	winners := map[uint64]eth.ElectionWinner{
+		12: {Address: alice, Time: 12},
+		24: {Address: bob, Time: 24},
+		36: {Address: alice, Time: 36},
+		48: {Time: 48, Permissionless: true},
+	}
+	l1 := &testutils.MockL1Source{}
+	db := &memDB{slots: make(map[uint64]eth.SlotLiveness)}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	winnersOf := winnersFn(func(time uint64) eth.ElectionWinner { return winners[time] })
+	tracker := NewTracker(ctx, testlog.Logger(t, log.LevelCrit), cfg, l1, winnersOf, db, metrics.NoopMetrics)
+
+	election := &eth.EpochElection{Epoch: 1, Winners: []*eth.ElectionWinner{}}
+	for _, time := range []uint64{12, 24, 36, 48} {
+		w := winners[time]
+		election.Winners = append(election.Winners, &w)
+	}
+	tracker.OnEvent(rollup.ElectionWinnerEvent{ElectionWinners: election.Winners, Epoch: election.Epoch})
+
+	refs := make([]eth.L1BlockRef, 5)
+	infos := make([]*testutils.MockBlockInfo, 5)
+	for i := range refs {
+		infos[i] = testutils.RandomBlockInfo(rng)
+		infos[i].InfoNum = uint64(100 + i)
+		infos[i].InfoTime = uint64(12 * (i + 1))
+		infos[i].InfoHeaderRLP = testutils.RandomData(rng, 100)
+		refs[i] = infos[i].BlockRef()
+	}
+	aliceBatch := testutils.RandomHash(rng)
+	// Alice submits in her first slot
+	l1.ExpectFetchReceipts(refs[0].Hash, infos[0], types.Receipts{batchReceipt(cfg, alice, aliceBatch)}, nil)
+	// Alice submits in the slot of Bob, which is not valid for Bob
+	l1.ExpectFetchReceipts(refs[1].Hash, infos[1], types.Receipts{batchReceipt(cfg, alice, testutils.RandomHash(rng))}, nil)
+	// Alice misses her second slot
+	l1.ExpectFetchReceipts(refs[2].Hash, infos[2], types.Receipts{}, nil)
+	for _, ref := range refs {
+		// The permissionless slot, and the slot without winner, are not fetched
+		tracker.OnEvent(derive.DeriverL1StatusEvent{Origin: ref})
+	}
+	// The L1 blocks are checked in the background
+	require.Eventually(t, func() bool {
+		return tracker.SequencerLiveness(alice).Won == 2 && tracker.SequencerLiveness(bob).Won == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	l1.AssertExpectations(t)
+
+	aliceLiveness := tracker.SequencerLiveness(alice)
+	require.Equal(t, uint64(2), aliceLiveness.Won)
+	require.Equal(t, uint64(1), aliceLiveness.Missed)
+	require.Equal(t, refs[0].ID(), aliceLiveness.FromL1)
+	require.Equal(t, refs[2].ID(), aliceLiveness.ToL1)
+	require.Equal(t, []eth.SlotLiveness{{Winner: winners[36], L1Block: refs[2].ID()}}, aliceLiveness.MissedSlots)
+
+	bobLiveness := tracker.SequencerLiveness(bob)
+	require.Equal(t, uint64(1), bobLiveness.Won)
+	require.Equal(t, uint64(1), bobLiveness.Missed)
+
+	t.Run("proof", func(t *testing.T) {
+		receipts := types.Receipts{batchReceipt(cfg, alice, testutils.RandomHash(rng))}
+		l1.ExpectFetchReceipts(refs[1].Hash, infos[1], receipts, nil)
+		proof, err := tracker.MissedSlotProof(context.Background(), refs[1].Number)
+		require.NoError(t, err)
+		require.Equal(t, winners[24], proof.Slot.Winner)
+		require.Equal(t, infos[1].ReceiptHash(), proof.ReceiptsRoot)
+		require.Len(t, proof.Receipts, 1)
+		require.Equal(t, election, proof.Election)
+		header, err := infos[1].HeaderRLP()
+		require.NoError(t, err)
+		require.Equal(t, header, []byte(proof.Header))
+
+		_, err = tracker.MissedSlotProof(context.Background(), refs[0].Number)
+		require.ErrorContains(t, err, "submitted batch")
+		_, err = tracker.MissedSlotProof(context.Background(), refs[3].Number)
+		require.ErrorIs(t, err, ErrSlotNotTracked)
+	})
+
+	t.Run("reorg", func(t *testing.T) {
+		// The derivation re-traverses the L1 block of the slot of Bob, which now has his batch
+		bobBatch := testutils.RandomHash(rng)
+		l1.ExpectFetchReceipts(refs[1].Hash, infos[1], types.Receipts{batchReceipt(cfg, bob, bobBatch)}, nil)
+		tracker.OnEvent(derive.DeriverL1StatusEvent{Origin: refs[1]})
+		require.Eventually(t, func() bool {
+			liveness := tracker.SequencerLiveness(bob)
+			return liveness.Won == 1 && liveness.Missed == 0
+		}, 5*time.Second, 10*time.Millisecond)
+		l1.AssertExpectations(t)
+
+		bobLiveness := tracker.SequencerLiveness(bob)
+		require.Equal(t, uint64(1), bobLiveness.Won)
+		require.Equal(t, uint64(0), bobLiveness.Missed)
+		// The records of later blocks are dropped, until they are traversed again
+		require.Equal(t, uint64(1), tracker.SequencerLiveness(alice).Won)
+	})
+
+	t.Run("restore", func(t *testing.T) {
+		db.elections = []*eth.EpochElection{election}
+		restored := NewTracker(ctx, testlog.Logger(t, log.LevelCrit), cfg, l1, winnersOf, db, metrics.NoopMetrics)
+		require.Equal(t, tracker.SequencerLiveness(alice), restored.SequencerLiveness(alice))
+		require.Equal(t, tracker.SequencerLiveness(bob), restored.SequencerLiveness(bob))
+		require.Equal(t, election, restored.electionOf(winners[24]))
+	})
+}
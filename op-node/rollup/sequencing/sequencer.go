@@ -36,6 +36,8 @@ type Metrics interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordSequencingError()
+	RecordSequencerHandoff(leader bool)
+	RecordElectionWinnerWait(duration time.Duration)
 }
 
 type SequencerStateListener interface {
@@ -54,6 +56,7 @@ type AsyncGossiper interface {
 type ElectionClient interface {
 	GetLastWinnerInCurrentEpoch() eth.ElectionWinner
 	GetElectionWinner(time uint64) eth.ElectionWinner
+	SecondsPerSlot() uint64
 }
 
 // SequencerActionEvent triggers the sequencer to start/seal a block, if active and ready to act.
@@ -67,6 +70,35 @@ func (ev SequencerActionEvent) String() string {
 	return "sequencer-action"
 }
 
+// SequencerLeaderEvent is emitted when the sequencer starts building blocks,
+// as the elected sequencer of the slot of the next L2 block.
+type SequencerLeaderEvent struct {
+	Winner eth.ElectionWinner
+}
+
+func (ev SequencerLeaderEvent) String() string {
+	return "sequencer-leader"
+}
+
+// SequencerFollowerEvent is emitted when the sequencer stops building blocks,
+// to follow the unsafe blocks of another elected sequencer.
+type SequencerFollowerEvent struct {
+	Winner eth.ElectionWinner
+}
+
+func (ev SequencerFollowerEvent) String() string {
+	return "sequencer-follower"
+}
+
+// electionRole is the role of the sequencer in the slot of the next L2 block.
+type electionRole uint8
+
+const (
+	roleUnknown electionRole = iota
+	roleLeader
+	roleFollower
+)
+
 type BuildingState struct {
 	Onto eth.L2BlockRef
 	Info eth.PayloadInfo
@@ -121,6 +153,16 @@ type Sequencer struct {
 	latestHead   eth.L2BlockRef
 
 	electionClient ElectionClient
+	// electionAddress is the address the sequencer is elected with.
+	// If zero, the sequencer builds all blocks, regardless of the election.
+	electionAddress common.Address
+	// permissionless is whether the sequencer builds blocks in the permissionless slots, that anyone may build.
+	// Blocks of permissionless slots are not accepted on the election topic, so the unsafe chains of the builders
+	// may diverge until the first batch of the slot that is included on L1 decides the canonical block.
+	permissionless bool
+	role           electionRole
+	// waitingForElection is when the sequencer started waiting for the election of the next epoch, zero if not waiting
+	waitingForElection time.Time
 
 	latestHeadSet chan struct{}
 
@@ -137,6 +179,8 @@ func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.C
 	conductor conductor.SequencerConductor,
 	asyncGossip AsyncGossiper,
 	electionClient ElectionClient,
+	electionAddress common.Address,
+	permissionless bool,
 	metrics Metrics) *Sequencer {
 	return &Sequencer{
 		ctx:              driverCtx,
@@ -152,6 +196,8 @@ func NewSequencer(driverCtx context.Context, log log.Logger, rollupCfg *rollup.C
 		timeNow:          time.Now,
 		toBlockRef:       derive.PayloadToBlockRef,
 		electionClient:   electionClient,
+		electionAddress:  electionAddress,
+		permissionless:   permissionless,
 	}
 }
 
@@ -385,22 +431,80 @@ func (d *Sequencer) onSequencerAction(x SequencerActionEvent) {
 		} else if d.latest == (BuildingState{}) {
 			latestElectionWinner := d.electionClient.GetLastWinnerInCurrentEpoch()
 			if latestElectionWinner != (eth.ElectionWinner{}) {
-				if latestElectionWinner.Time == d.latestHead.Time {
+				// A winner covers all L2 blocks of its L1 slot. Wait for the next election
+				// if the head is in the last slot with a known winner, and the next block is past that slot.
+				slotTime := max(d.electionClient.SecondsPerSlot(), d.rollupCfg.BlockTime)
+				slotEnd := latestElectionWinner.Time + slotTime
+				if latestElectionWinner.Time <= d.latestHead.Time && d.latestHead.Time < slotEnd && d.latestHead.Time+d.rollupCfg.BlockTime >= slotEnd {
 					d.log.Info("Waiting for election winner update...", "latestHead", d.latestHead.Time, "latestElectionWinner", latestElectionWinner)
+					if d.waitingForElection.IsZero() {
+						d.waitingForElection = d.timeNow()
+					}
 					// We need to try retrying the build action when this check passes
 					// TODO(spire): This might be too aggressive of a delay time wise, we are getting a lot of logs
 					// but there are no reorgs and it seems to build correctly.
-					// This also might break when we eventually remove the l1BlockTime == l2BlockTime invariant
 					d.nextActionOK = true
 					d.nextAction = time.Now().Add(time.Second / 2)
 					return
 				}
 			}
+			if !d.waitingForElection.IsZero() {
+				d.metrics.RecordElectionWinnerWait(d.timeNow().Sub(d.waitingForElection))
+				d.waitingForElection = time.Time{}
+			}
+			if !d.electedForNextBlock() {
+				return
+			}
 			d.startBuildingBlock()
 		}
 	}
 }
 
+// electedForNextBlock checks whether the sequencer may build the next L2 block.
+// Without an election address the sequencer builds all blocks. Otherwise it only builds in the slots
+// won by its election address, or in permissionless slots if it opted in to build those,
+// and follows the unsafe blocks of the winner in other slots.
+func (d *Sequencer) electedForNextBlock() bool {
+	// Without a head, startBuildingBlock requests a forkchoice update first.
+	if d.electionAddress == (common.Address{}) || d.latestHead == (eth.L2BlockRef{}) {
+		return true
+	}
+	nextTime := d.latestHead.Time + d.rollupCfg.BlockTime
+	winner := d.electionClient.GetElectionWinner(nextTime)
+	if winner == (eth.ElectionWinner{}) {
+		d.log.Info("Waiting for the election winner of the next block", "latestHead", d.latestHead, "time", nextTime)
+		d.nextActionOK = true
+		d.nextAction = d.timeNow().Add(time.Second / 2)
+		return false
+	}
+	if (winner.Permissionless && d.permissionless) || (!winner.Permissionless && winner.Address == d.electionAddress) {
+		d.setElectionRole(roleLeader, winner)
+		return true
+	}
+	d.setElectionRole(roleFollower, winner)
+	// The unsafe blocks of the winner reschedule the next action when they arrive,
+	// check again after a block otherwise.
+	d.nextActionOK = true
+	d.nextAction = d.timeNow().Add(time.Duration(d.rollupCfg.BlockTime) * time.Second)
+	return false
+}
+
+func (d *Sequencer) setElectionRole(role electionRole, winner eth.ElectionWinner) {
+	if d.role == role {
+		return
+	}
+	d.role = role
+	leader := role == roleLeader
+	d.metrics.RecordSequencerHandoff(leader)
+	if leader {
+		d.log.Info("Elected for the next block, building blocks", "winner", winner, "head", d.latestHead)
+		d.emitter.Emit(SequencerLeaderEvent{Winner: winner})
+	} else {
+		d.log.Info("Another sequencer was elected for the next block, following its blocks", "winner", winner, "head", d.latestHead)
+		d.emitter.Emit(SequencerFollowerEvent{Winner: winner})
+	}
+}
+
 func (d *Sequencer) onEngineTemporaryError(x rollup.EngineTemporaryErrorEvent) {
 	if d.latest == (BuildingState{}) {
 		d.log.Debug("Engine reported temporary error, but sequencer is not using engine", "err", x.Err)
@@ -723,6 +827,7 @@ func (d *Sequencer) Stop(ctx context.Context) (common.Hash, error) {
 	d.latest = BuildingState{} // By wiping this state we cannot continue from it later.
 
 	d.nextActionOK = false
+	d.role = roleUnknown
 	d.active.Store(false)
 	d.log.Info("Sequencer has been stopped")
 	return d.latestHead.Hash, nil
@@ -152,9 +152,13 @@ func (f *FakeAsyncGossip) Start() {
 var _ AsyncGossiper = (*FakeAsyncGossip)(nil)
 
 type FakeElectionClient struct {
+	winnerFn func(timestamp uint64) eth.ElectionWinner
 }
 
 func (e *FakeElectionClient) GetElectionWinner(timestamp uint64) eth.ElectionWinner {
+	if e.winnerFn != nil {
+		return e.winnerFn(timestamp)
+	}
 	return eth.ElectionWinner{}
 }
 
@@ -162,6 +166,10 @@ func (e *FakeElectionClient) GetLastWinnerInCurrentEpoch() eth.ElectionWinner {
 	return eth.ElectionWinner{}
 }
 
+func (e *FakeElectionClient) SecondsPerSlot() uint64 {
+	return 0
+}
+
 // TestSequencer_StartStop runs through start/stop state back and forth to test state changes.
 func TestSequencer_StartStop(t *testing.T) {
 	logger := testlog.Logger(t, log.LevelError)
@@ -619,6 +627,108 @@ func TestSequencerBuild(t *testing.T) {
 	require.Equal(t, testClock.Now(), nextTime, "start asap on the next block")
 }
 
+// TestSequencerElectionHandoff tests the sequencer only builds the blocks of the slots won by its election address,
+// and follows the blocks of other winners.
+func TestSequencerElectionHandoff(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+
+	local, other := common.Address{0xaa}, common.Address{0xbb}
+	seq.electionAddress = local
+	var winner eth.ElectionWinner
+	deps.electionClient.winnerFn = func(timestamp uint64) eth.ElectionWinner {
+		return winner
+	}
+
+	emitter.ExpectOnce(engine.ForkchoiceRequestEvent{})
+	require.NoError(t, seq.Init(context.Background(), true))
+	emitter.AssertExpectations(t)
+
+	head := eth.L2BlockRef{
+		Hash:   common.Hash{0x22},
+		Number: 100,
+		L1Origin: eth.BlockID{
+			Hash:   common.Hash{0x11, 0xa},
+			Number: 1000,
+		},
+		Time: uint64(testClock.Now().Unix()),
+	}
+	seq.OnEvent(engine.ForkchoiceUpdateEvent{UnsafeL2Head: head})
+	nextTime := head.Time + deps.cfg.BlockTime
+
+	// The winner of the next block is not known yet, wait for the election
+	seq.OnEvent(SequencerActionEvent{})
+	emitter.AssertExpectations(t)
+	next, ok := seq.NextAction()
+	require.True(t, ok)
+	require.Equal(t, testClock.Now().Add(time.Second/2), next)
+
+	// Another sequencer won the slot, follow it
+	winner = eth.ElectionWinner{Address: other, Time: nextTime}
+	emitter.ExpectOnce(SequencerFollowerEvent{Winner: winner})
+	seq.OnEvent(SequencerActionEvent{})
+	emitter.AssertExpectations(t)
+	next, ok = seq.NextAction()
+	require.True(t, ok)
+	require.Equal(t, testClock.Now().Add(time.Duration(deps.cfg.BlockTime)*time.Second), next)
+
+	// The transition is only signaled once
+	seq.OnEvent(SequencerActionEvent{})
+	emitter.AssertExpectations(t)
+
+	// The slot of the next block is won by the local sequencer, take over and build
+	winner = eth.ElectionWinner{Address: local, Time: nextTime}
+	deps.l1OriginSelector.l1OriginFn = func(l2Head eth.L2BlockRef) (eth.L1BlockRef, error) {
+		return eth.L1BlockRef{
+			Hash:       common.Hash{0x11, 0xb},
+			ParentHash: common.Hash{0x11, 0xa},
+			Number:     1001,
+			Time:       29998,
+		}, nil
+	}
+	emitter.ExpectOnce(SequencerLeaderEvent{Winner: winner})
+	emitter.ExpectOnceRun(func(ev event.Event) {
+		x, ok := ev.(engine.BuildStartEvent)
+		require.True(t, ok)
+		require.Equal(t, head, x.Attributes.Parent)
+	})
+	seq.OnEvent(SequencerActionEvent{})
+	emitter.AssertExpectations(t)
+}
+
+// TestSequencerPermissionlessSlots tests the sequencer only builds the blocks of permissionless slots if it opted in.
+func TestSequencerPermissionlessSlots(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelError)
+	seq, deps := createSequencer(logger)
+	testClock := clock.NewSimpleClock()
+	seq.timeNow = testClock.Now
+	testClock.SetTime(30000)
+	emitter := &testutils.MockEmitter{}
+	seq.AttachEmitter(emitter)
+	seq.electionAddress = common.Address{0xaa}
+
+	head := eth.L2BlockRef{Hash: common.Hash{0x22}, Number: 100, Time: uint64(testClock.Now().Unix())}
+	seq.latestHead = head
+	winner := eth.ElectionWinner{Time: head.Time + deps.cfg.BlockTime, Permissionless: true}
+	deps.electionClient.winnerFn = func(timestamp uint64) eth.ElectionWinner {
+		return winner
+	}
+
+	emitter.ExpectOnce(SequencerFollowerEvent{Winner: winner})
+	require.False(t, seq.electedForNextBlock())
+	emitter.AssertExpectations(t)
+
+	seq.permissionless = true
+	emitter.ExpectOnce(SequencerLeaderEvent{Winner: winner})
+	require.True(t, seq.electedForNextBlock())
+	emitter.AssertExpectations(t)
+}
+
 type sequencerTestDeps struct {
 	cfg              *rollup.Config
 	attribBuilder    *FakeAttributesBuilder
@@ -667,7 +777,7 @@ func createSequencer(log log.Logger) (*Sequencer, *sequencerTestDeps) {
 	}
 	seq := NewSequencer(context.Background(), log, cfg, deps.attribBuilder,
 		deps.l1OriginSelector, deps.seqState, deps.conductor,
-		deps.asyncGossip, deps.electionClient,
+		deps.asyncGossip, deps.electionClient, common.Address{}, false,
 		metrics.NoopMetrics)
 	// We create mock payloads, with the epoch-id as tx[0], rather than proper L1Block-info deposit tx.
 	seq.toBlockRef = func(rollupCfg *rollup.Config, payload *eth.ExecutionPayload) (eth.L2BlockRef, error) {
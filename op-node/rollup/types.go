@@ -133,6 +133,13 @@ type Config struct {
 	AuctionContractAddress common.Address `json:"auction_contract_address"`
 	// L1 System Config Address
 	L1SystemConfigAddress common.Address `json:"l1_system_config_address"`
+	// L1 Based Inbox Contract Address, optional.
+	// When set, L2 transactions broadcast to this chain via BasedInbox.broadcastTx are force-included.
+	BasedInboxContractAddress common.Address `json:"based_inbox_contract_address,omitempty"`
+	// L1 Operator Registry Address.
+	// The validators of the lookahead are mapped to the sequencer operators they registered in the registry.
+	// Required to derive the election winners.
+	OperatorRegistryAddress common.Address `json:"operator_registry_address,omitempty"`
 
 	// L1 address that declares the protocol versions, optional (Beta feature)
 	ProtocolVersionsAddress common.Address `json:"protocol_versions_address,omitempty"`
@@ -567,6 +574,12 @@ func (c *Config) AltDAEnabled() bool {
 	return c.AltDAConfig != nil
 }
 
+// IsForcedInclusion returns true if L2 transactions broadcast through the BasedInbox are force-included
+// in blocks at or past the given timestamp. Forced inclusion requires Fjord, to price the L1 data fee of forced transactions.
+func (c *Config) IsForcedInclusion(timestamp uint64) bool {
+	return c.BasedInboxContractAddress != (common.Address{}) && c.IsFjord(timestamp)
+}
+
 // SyncLookback computes the number of blocks to walk back in order to find the correct L1 origin.
 // In alt-da mode longest possible window is challenge + resolve windows.
 func (c *Config) SyncLookback() uint64 {
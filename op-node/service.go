@@ -46,7 +46,10 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	configPersistence := NewConfigPersistence(ctx)
 
-	driverConfig := NewDriverConfig(ctx)
+	driverConfig, err := NewDriverConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load driver config: %w", err)
+	}
 
 	p2pSignerSetup, err := p2pcli.LoadSignerSetup(ctx)
 	if err != nil {
@@ -105,6 +108,7 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		RuntimeConfigReloadInterval: ctx.Duration(flags.RuntimeConfigReloadIntervalFlag.Name),
 		ConfigPersistence:           configPersistence,
 		SafeDBPath:                  ctx.String(flags.SafeDBPath.Name),
+		ElectionDBPath:              ctx.String(flags.ElectionDBPath.Name),
 		Sync:                        *syncConfig,
 		RollupHalt:                  haltOption,
 
@@ -138,13 +142,15 @@ func NewSupervisorEndpointConfig(ctx *cli.Context) node.SupervisorEndpointSetup
 
 func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
 	return &node.L1BeaconEndpointConfig{
-		BeaconAddr:             ctx.String(flags.BeaconAddr.Name),
-		BeaconHeader:           ctx.String(flags.BeaconHeader.Name),
-		BeaconFallbackAddrs:    ctx.StringSlice(flags.BeaconFallbackAddrs.Name),
-		BeaconCheckIgnore:      ctx.Bool(flags.BeaconCheckIgnore.Name),
-		BeaconFetchAllSidecars: ctx.Bool(flags.BeaconFetchAllSidecars.Name),
-		BeaconFakeLookahead:    ctx.Bool(flags.BeaconFakeLookahead.Name),
-		BeaconFakeValidators:   ctx.StringSlice(flags.BeaconFakeValidators.Name),
+		BeaconAddr:                ctx.String(flags.BeaconAddr.Name),
+		BeaconHeader:              ctx.String(flags.BeaconHeader.Name),
+		BeaconFallbackAddrs:       ctx.StringSlice(flags.BeaconFallbackAddrs.Name),
+		BeaconCheckIgnore:         ctx.Bool(flags.BeaconCheckIgnore.Name),
+		BeaconFetchAllSidecars:    ctx.Bool(flags.BeaconFetchAllSidecars.Name),
+		BeaconFakeLookahead:       ctx.Bool(flags.BeaconFakeLookahead.Name),
+		BeaconFakeValidators:      ctx.StringSlice(flags.BeaconFakeValidators.Name),
+		BeaconLookaheadFile:       ctx.String(flags.BeaconLookaheadFile.Name),
+		BeaconLookaheadVerifyAddr: ctx.String(flags.BeaconLookaheadVerifyAddr.Name),
 	}
 }
 
@@ -198,14 +204,24 @@ func NewConfigPersistence(ctx *cli.Context) node.ConfigPersistence {
 	return node.NewConfigPersistence(stateFile)
 }
 
-func NewDriverConfig(ctx *cli.Context) *driver.Config {
-	return &driver.Config{
-		VerifierConfDepth:   ctx.Uint64(flags.VerifierL1Confs.Name),
-		SequencerConfDepth:  ctx.Uint64(flags.SequencerL1Confs.Name),
-		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
-		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
-		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+func NewDriverConfig(ctx *cli.Context) (*driver.Config, error) {
+	var electionAddress common.Address
+	if addr := ctx.String(flags.SequencerElectionAddressFlag.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid %s: %q", flags.SequencerElectionAddressFlag.Name, addr)
+		}
+		electionAddress = common.HexToAddress(addr)
 	}
+	return &driver.Config{
+		VerifierConfDepth:        ctx.Uint64(flags.VerifierL1Confs.Name),
+		SequencerConfDepth:       ctx.Uint64(flags.SequencerL1Confs.Name),
+		SequencerEnabled:         ctx.Bool(flags.SequencerEnabledFlag.Name),
+		SequencerStopped:         ctx.Bool(flags.SequencerStoppedFlag.Name),
+		SequencerMaxSafeLag:      ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerElectionAddress: electionAddress,
+		SequencerPermissionless:  ctx.Bool(flags.SequencerPermissionlessFlag.Name),
+		FakeOperators:            ctx.Bool(flags.BeaconFakeOperators.Name),
+	}, nil
 }
 
 func NewRollupConfigFromCLI(log log.Logger, ctx *cli.Context) (*rollup.Config, error) {
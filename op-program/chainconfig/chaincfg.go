@@ -37,3 +37,17 @@ func RollupConfigByChainID(chainID uint64) (*rollup.Config, error) {
 func ChainConfigByChainID(chainID uint64) (*params.ChainConfig, error) {
 	return params.LoadOPStackChainConfig(chainID)
 }
+
+var L1ChainConfigsByChainID = map[uint64]*params.ChainConfig{
+	params.MainnetChainConfig.ChainID.Uint64(): params.MainnetChainConfig,
+	params.SepoliaChainConfig.ChainID.Uint64(): params.SepoliaChainConfig,
+	params.HoleskyChainConfig.ChainID.Uint64(): params.HoleskyChainConfig,
+}
+
+func L1ChainConfigByChainID(chainID uint64) (*params.ChainConfig, error) {
+	config, ok := L1ChainConfigsByChainID[chainID]
+	if !ok {
+		return nil, fmt.Errorf("unknown L1 chain ID: %d", chainID)
+	}
+	return config, nil
+}
@@ -22,6 +22,7 @@ const (
 	// These local keys are only used for custom chains
 	L2ChainConfigLocalIndex
 	RollupConfigLocalIndex
+	L1ChainConfigLocalIndex
 )
 
 // CustomChainIDIndicator is used to detect when the program should load custom chain configuration
@@ -36,6 +37,7 @@ type BootInfo struct {
 
 	L2ChainConfig *params.ChainConfig
 	RollupConfig  *rollup.Config
+	L1ChainConfig *params.ChainConfig
 }
 
 type oracleClient interface {
@@ -59,6 +61,7 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 
 	var l2ChainConfig *params.ChainConfig
 	var rollupConfig *rollup.Config
+	var l1ChainConfig *params.ChainConfig
 	if l2ChainID == CustomChainIDIndicator {
 		l2ChainConfig = new(params.ChainConfig)
 		err := json.Unmarshal(br.r.Get(L2ChainConfigLocalIndex), &l2ChainConfig)
@@ -70,6 +73,11 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		if err != nil {
 			panic("failed to bootstrap rollup config")
 		}
+		l1ChainConfig = new(params.ChainConfig)
+		err = json.Unmarshal(br.r.Get(L1ChainConfigLocalIndex), l1ChainConfig)
+		if err != nil {
+			panic("failed to bootstrap l1ChainConfig")
+		}
 	} else {
 		var err error
 		rollupConfig, err = chainconfig.RollupConfigByChainID(l2ChainID)
@@ -80,6 +88,10 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		if err != nil {
 			panic(err)
 		}
+		l1ChainConfig, err = chainconfig.L1ChainConfigByChainID(rollupConfig.L1ChainID.Uint64())
+		if err != nil {
+			panic(err)
+		}
 	}
 
 	return &BootInfo{
@@ -90,5 +102,6 @@ func (br *BootstrapClient) BootInfo() *BootInfo {
 		L2ChainID:          l2ChainID,
 		L2ChainConfig:      l2ChainConfig,
 		RollupConfig:       rollupConfig,
+		L1ChainConfig:      l1ChainConfig,
 	}
 }
@@ -10,6 +10,7 @@ import (
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,6 +23,7 @@ func TestBootstrapClient(t *testing.T) {
 		L2ChainID:          chaincfg.Sepolia.L2ChainID.Uint64(),
 		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
 		RollupConfig:       chaincfg.Sepolia,
+		L1ChainConfig:      params.SepoliaChainConfig,
 	}
 	mockOracle := &mockBoostrapOracle{bootInfo, false}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
@@ -37,6 +39,7 @@ func TestBootstrapClient_CustomChain(t *testing.T) {
 		L2ChainID:          CustomChainIDIndicator,
 		L2ChainConfig:      chainconfig.OPSepoliaChainConfig,
 		RollupConfig:       chaincfg.Sepolia,
+		L1ChainConfig:      params.SepoliaChainConfig,
 	}
 	mockOracle := &mockBoostrapOracle{bootInfo, true}
 	readBootInfo := NewBootstrapClient(mockOracle).BootInfo()
@@ -85,6 +88,12 @@ func (o *mockBoostrapOracle) Get(key preimage.Key) []byte {
 		}
 		b, _ := json.Marshal(o.b.RollupConfig)
 		return b
+	case L1ChainConfigLocalIndex.PreimageKey():
+		if !o.custom {
+			panic(fmt.Sprintf("unexpected oracle request for preimage key %x", key.PreimageKey()))
+		}
+		b, _ := json.Marshal(o.b.L1ChainConfig)
+		return b
 	default:
 		panic("unknown key")
 	}
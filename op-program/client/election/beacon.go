@@ -0,0 +1,147 @@
+package election
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// L1Blocks looks up the L1 blocks up to the L1 head of the program.
+type L1Blocks interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error)
+}
+
+// LookaheadSource provides the proposer lookahead that an L1 block commits to.
+// A source must prove the lookahead against the parent beacon block root of the L1 block,
+// the program must not accept a lookahead that the on-chain pre-image oracle cannot serve.
+type LookaheadSource interface {
+	LookaheadByBlockHash(blockHash common.Hash) *Lookahead
+}
+
+// OracleBeaconClient implements the election BeaconClient with the proposer lookaheads that L1 blocks commit to.
+// The lookahead of an epoch is read from the last L1 block before the start of the epoch,
+// and the beacon chain config from the lookahead of the L1 head.
+type OracleBeaconClient struct {
+	l1     L1Blocks
+	oracle LookaheadSource
+
+	// head is the lookahead of the L1 head, loaded on first use
+	head *Lookahead
+}
+
+var _ election.BeaconClient = (*OracleBeaconClient)(nil)
+
+func NewOracleBeaconClient(l1 L1Blocks, oracle LookaheadSource) *OracleBeaconClient {
+	return &OracleBeaconClient{
+		l1:     l1,
+		oracle: oracle,
+	}
+}
+
+func (c *OracleBeaconClient) config(ctx context.Context) (*Lookahead, error) {
+	if c.head != nil {
+		return c.head, nil
+	}
+	head, err := c.l1.L1BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	c.head = c.oracle.LookaheadByBlockHash(head.Hash)
+	return c.head, nil
+}
+
+func (c *OracleBeaconClient) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	cfg, err := c.config(ctx)
+	if err != nil {
+		return eth.APIGetLookaheadResponse{}, err
+	}
+	if epoch == 0 {
+		return eth.APIGetLookaheadResponse{}, fmt.Errorf("no L1 block before epoch %d commits to its lookahead", epoch)
+	}
+	epochStart := cfg.GenesisTime + epoch*cfg.SlotsPerEpoch()*cfg.SecondsPerSlot
+	ref, err := c.blockAtTime(ctx, epochStart-1, cfg.SecondsPerSlot)
+	if err != nil {
+		return eth.APIGetLookaheadResponse{}, fmt.Errorf("failed to find L1 block before epoch %d: %w", epoch, err)
+	}
+	proposers, err := c.oracle.LookaheadByBlockHash(ref.Hash).ProposersOf(epoch)
+	if err != nil {
+		return eth.APIGetLookaheadResponse{}, fmt.Errorf("L1 block %s does not commit to lookahead of epoch %d: %w", ref, epoch, err)
+	}
+	return eth.APIGetLookaheadResponse{Data: proposers}, nil
+}
+
+// blockAtTime returns the last L1 block at or before the given time.
+// L1 blocks are at least one slot apart, so walking back from the head by the number of slots
+// to the time gives a block at or before the time, from where the search moves forward.
+func (c *OracleBeaconClient) blockAtTime(ctx context.Context, timestamp uint64, slotTime uint64) (eth.L1BlockRef, error) {
+	head, err := c.l1.L1BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return eth.L1BlockRef{}, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head.Time < timestamp {
+		return eth.L1BlockRef{}, fmt.Errorf("L1 head %s at time %d has not reached time %d", head, head.Time, timestamp)
+	}
+	back := min(head.Number, (head.Time-timestamp+slotTime-1)/slotTime)
+	ref, err := c.l1.L1BlockRefByNumber(ctx, head.Number-back)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	if ref.Time > timestamp {
+		return eth.L1BlockRef{}, fmt.Errorf("no L1 block at or before time %d", timestamp)
+	}
+	for ref.Number < head.Number {
+		next, err := c.l1.L1BlockRefByNumber(ctx, ref.Number+1)
+		if err != nil {
+			return eth.L1BlockRef{}, err
+		}
+		if next.Time > timestamp {
+			break
+		}
+		ref = next
+	}
+	return ref, nil
+}
+
+func (c *OracleBeaconClient) GetEpochNumber(ctx context.Context, timestamp uint64) (uint64, error) {
+	cfg, err := c.config(ctx)
+	if err != nil {
+		return 0, err
+	}
+	slot, err := c.GetSlotNumber(ctx, timestamp)
+	if err != nil {
+		return 0, err
+	}
+	return slot / cfg.SlotsPerEpoch(), nil
+}
+
+func (c *OracleBeaconClient) GetSlotNumber(ctx context.Context, timestamp uint64) (uint64, error) {
+	cfg, err := c.config(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if timestamp < cfg.GenesisTime {
+		return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, cfg.GenesisTime)
+	}
+	return (timestamp - cfg.GenesisTime) / cfg.SecondsPerSlot, nil
+}
+
+func (c *OracleBeaconClient) GetTimeFromSlot(ctx context.Context, slot uint64) (uint64, error) {
+	cfg, err := c.config(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return cfg.GenesisTime + slot*cfg.SecondsPerSlot, nil
+}
+
+func (c *OracleBeaconClient) GetSecondsPerSlot(ctx context.Context) (uint64, error) {
+	cfg, err := c.config(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return cfg.SecondsPerSlot, nil
+}
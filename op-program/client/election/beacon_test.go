@@ -0,0 +1,85 @@
+package election
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// fakeL1 is an L1 chain of blocks with the given timestamps, numbered from zero, with the last block as head.
+// Every block commits to the lookahead of the epoch of its slot, with 12 second slots and 4 slots per epoch.
+type fakeL1 struct {
+	times []uint64
+	// lookaheads counts the lookaheads loaded per block number
+	lookaheads map[uint64]int
+}
+
+func (f *fakeL1) ref(num uint64) eth.L1BlockRef {
+	return eth.L1BlockRef{Hash: common.Hash{byte(num), 0xbb}, Number: num, Time: f.times[num]}
+}
+
+func (f *fakeL1) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	return f.ref(uint64(len(f.times) - 1)), nil
+}
+
+func (f *fakeL1) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	if num >= uint64(len(f.times)) {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return f.ref(num), nil
+}
+
+func (f *fakeL1) LookaheadByBlockHash(blockHash common.Hash) *Lookahead {
+	num := uint64(blockHash[0])
+	f.lookaheads[num]++
+	return testLookahead((f.times[num]-1000)/12/4, 4)
+}
+
+func TestOracleBeaconClient(t *testing.T) {
+	// Slots 0 to 10, with slots 4, 5 and 8 missed
+	l1 := &fakeL1{
+		times:      []uint64{1000, 1012, 1024, 1036, 1072, 1084, 1108, 1120},
+		lookaheads: make(map[uint64]int),
+	}
+	bc := NewOracleBeaconClient(l1, l1)
+	ctx := context.Background()
+
+	slotTime, err := bc.GetSecondsPerSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), slotTime)
+	require.Equal(t, 1, l1.lookaheads[7], "config is read from the lookahead of the L1 head")
+	epoch, err := bc.GetEpochNumber(ctx, 1047)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), epoch)
+	epoch, err = bc.GetEpochNumber(ctx, 1048)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), epoch)
+	slotStart, err := bc.GetTimeFromSlot(ctx, 9)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1108), slotStart)
+	_, err = bc.GetSlotNumber(ctx, 999)
+	require.Error(t, err)
+
+	// The lookahead of epoch 1 is committed to by the last block of epoch 0
+	resp, err := bc.GetLookahead(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, testLookahead(0, 4).Proposers[4:], resp.Data)
+	require.Equal(t, 1, l1.lookaheads[3])
+
+	// The first slot of epoch 1 was missed, the lookahead of epoch 2 is committed to by the block of slot 7
+	resp, err = bc.GetLookahead(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, testLookahead(1, 4).Proposers[4:], resp.Data)
+	require.Equal(t, 1, l1.lookaheads[5])
+
+	// The L1 head has not reached the end of epoch 2
+	_, err = bc.GetLookahead(ctx, 3)
+	require.ErrorContains(t, err, "has not reached")
+	_, err = bc.GetLookahead(ctx, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package election
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-program/client/l2"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// L1StateChain is the L1 chain up to the L1 head of the program, with the L1 state read from the pre-image oracle.
+type L1StateChain struct {
+	blocks   L1Blocks
+	oracle   l1.Oracle
+	db       state.Database
+	chainCfg *params.ChainConfig
+}
+
+var _ election.StateChain = (*L1StateChain)(nil)
+
+// NewL1StateChain creates the L1 chain with the given L1 chain config,
+// which determines the L1 forks that the election contracts are executed with.
+func NewL1StateChain(blocks L1Blocks, oracle l1.Oracle, stateOracle Oracle, chainCfg *params.ChainConfig) *L1StateChain {
+	return &L1StateChain{
+		blocks:   blocks,
+		oracle:   oracle,
+		db:       state.NewDatabase(rawdb.NewDatabase(l2.NewOracleBackedDB(stateOracle))),
+		chainCfg: chainCfg,
+	}
+}
+
+func (c *L1StateChain) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return c.oracle.HeaderByBlockHash(hash), nil
+}
+
+func (c *L1StateChain) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	ref, err := c.blocks.L1BlockRefByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	return c.oracle.HeaderByBlockHash(ref.Hash), nil
+}
+
+func (c *L1StateChain) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
+	ref, err := c.blocks.L1BlockRefByLabel(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+	return c.oracle.HeaderByBlockHash(ref.Hash), nil
+}
+
+func (c *L1StateChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	statedb, err := state.New(root, c.db, nil)
+	if err != nil {
+		return nil, err
+	}
+	statedb.MakeSinglethreaded()
+	return statedb, nil
+}
+
+func (c *L1StateChain) Config() *params.ChainConfig {
+	return c.chainCfg
+}
+
+func (c *L1StateChain) VMConfig() vm.Config {
+	return vm.Config{}
+}
+
+// L2Chain is the L2 chain that the program derives, see l2.OracleBackedL2Chain.
+type L2Chain interface {
+	CurrentHeader() *types.Header
+	CurrentSafeBlock() *types.Header
+	CurrentFinalBlock() *types.Header
+	GetHeaderByHash(hash common.Hash) *types.Header
+	GetHeaderByNumber(n uint64) *types.Header
+	StateAt(root common.Hash) (*state.StateDB, error)
+	Config() *params.ChainConfig
+	GetVMConfig() *vm.Config
+}
+
+// L2StateChain is the L2 chain as derived by the program so far.
+type L2StateChain struct {
+	chain L2Chain
+}
+
+var _ election.StateChain = (*L2StateChain)(nil)
+
+func NewL2StateChain(chain L2Chain) *L2StateChain {
+	return &L2StateChain{chain: chain}
+}
+
+func (c *L2StateChain) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	header := c.chain.GetHeaderByHash(hash)
+	if header == nil {
+		return nil, fmt.Errorf("%w: L2 block %s", ethereum.NotFound, hash)
+	}
+	return eth.HeaderBlockInfo(header), nil
+}
+
+func (c *L2StateChain) InfoByNumber(ctx context.Context, number uint64) (eth.BlockInfo, error) {
+	header := c.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, fmt.Errorf("%w: L2 block %d", ethereum.NotFound, number)
+	}
+	return eth.HeaderBlockInfo(header), nil
+}
+
+func (c *L2StateChain) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.BlockInfo, error) {
+	switch label {
+	case eth.Unsafe:
+		return eth.HeaderBlockInfo(c.chain.CurrentHeader()), nil
+	case eth.Safe:
+		return eth.HeaderBlockInfo(c.chain.CurrentSafeBlock()), nil
+	case eth.Finalized:
+		return eth.HeaderBlockInfo(c.chain.CurrentFinalBlock()), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", l1.ErrUnknownLabel, label)
+	}
+}
+
+func (c *L2StateChain) StateAt(root common.Hash) (*state.StateDB, error) {
+	return c.chain.StateAt(root)
+}
+
+func (c *L2StateChain) Config() *params.ChainConfig {
+	return c.chain.Config()
+}
+
+func (c *L2StateChain) VMConfig() vm.Config {
+	return *c.chain.GetVMConfig()
+}
@@ -0,0 +1,65 @@
+package election
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/election_client"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// operatorCacheSize is the number of resolved validator operators to cache, enough for the proposers of a few epochs.
+const operatorCacheSize = 1024
+
+var errNoElectionDB = errors.New("no election database in the program")
+
+// ErrNoLookaheadSource is returned when the program has no proven source of the proposer lookahead.
+var ErrNoLookaheadSource = errors.New("no provable source of the proposer lookahead")
+
+// noElectionDB keeps no elections, every election the program needs is recomputed from the oracle data.
+type noElectionDB struct{}
+
+func (noElectionDB) Enabled() bool {
+	return false
+}
+
+func (noElectionDB) StoreElection(*eth.EpochElection) error {
+	return nil
+}
+
+func (noElectionDB) LatestElection(context.Context) (*eth.EpochElection, error) {
+	return nil, errNoElectionDB
+}
+
+func (noElectionDB) ElectionWinnerAt(context.Context, uint64) (eth.ElectionWinner, error) {
+	return eth.ElectionWinner{}, errNoElectionDB
+}
+
+// NewElectionClient creates the election client of the derivation pipeline of the program.
+// The program does not observe elections live, so the election of every epoch is recomputed,
+// as a node does for epochs it did not observe: from the lookahead committed to by the L1 chain,
+// the L1 state at the L1 head, and the L2 state of the derived L2 chain.
+// The L1 contracts are executed with the given L1 chain config.
+func NewElectionClient(logger log.Logger, cfg *rollup.Config, l1ChainCfg *params.ChainConfig, l1Blocks L1Blocks, l1Oracle l1.Oracle,
+	oracle Oracle, lookaheads LookaheadSource, l2Chain L2Chain) (*election_client.ElectionClient, error) {
+	if lookaheads == nil {
+		return nil, ErrNoLookaheadSource
+	}
+	beaconClient := NewOracleBeaconClient(l1Blocks, lookaheads)
+	l1Client := election.NewStateClient(NewL1StateChain(l1Blocks, l1Oracle, oracle, l1ChainCfg))
+	l2Client := election.NewStateClient(NewL2StateChain(l2Chain))
+
+	operators, err := election.NewOperatorResolver(l1Client, cfg, operatorCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	elec := election.NewElection(beaconClient, l2Client, l1Client, operators, logger, election.NoopMetrics{}, cfg)
+	store := election_client.NewSyncElectionStore(logger, noElectionDB{}, elec, beaconClient)
+	return election_client.NewElectionClient(store), nil
+}
@@ -0,0 +1,39 @@
+package election
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+const (
+	HintL1StateNode = "l1-state-node"
+	HintL1Code      = "l1-code"
+	// HintL1BeaconLookahead asks for the nodes of the beacon block and state trees that prove the proposer lookahead
+	// of the beacon state of the beacon block with the hinted root.
+	HintL1BeaconLookahead = "l1-beacon-lookahead"
+)
+
+type StateNodeHint common.Hash
+
+var _ preimage.Hint = StateNodeHint{}
+
+func (l StateNodeHint) Hint() string {
+	return HintL1StateNode + " " + (common.Hash)(l).String()
+}
+
+type CodeHint common.Hash
+
+var _ preimage.Hint = CodeHint{}
+
+func (l CodeHint) Hint() string {
+	return HintL1Code + " " + (common.Hash)(l).String()
+}
+
+type BeaconLookaheadHint common.Hash
+
+var _ preimage.Hint = BeaconLookaheadHint{}
+
+func (l BeaconLookaheadHint) Hint() string {
+	return HintL1BeaconLookahead + " " + (common.Hash)(l).String()
+}
@@ -0,0 +1,96 @@
+package election
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	lookaheadHeaderLen = 8 + 8 + 8
+	proposerLen        = 48 + 8
+)
+
+var ErrInvalidLookahead = errors.New("invalid lookahead")
+
+// Lookahead is the proposer schedule of the beacon chain that an L1 block commits to:
+// the proposer lookahead of the beacon state at the parent beacon block root of the L1 block,
+// with the proposers of the epoch of that state, and of the next epoch.
+// It also carries the beacon chain config, to map slots to L1 timestamps.
+type Lookahead struct {
+	GenesisTime    uint64
+	SecondsPerSlot uint64
+	Epoch          uint64
+	// Proposers of the slots of Epoch and the next epoch, ordered by slot.
+	Proposers []*eth.Validator
+}
+
+func (l *Lookahead) SlotsPerEpoch() uint64 {
+	return uint64(len(l.Proposers) / 2)
+}
+
+// ProposersOf returns the proposers of the given epoch, which must be the epoch of the lookahead or the next one.
+func (l *Lookahead) ProposersOf(epoch uint64) ([]*eth.Validator, error) {
+	if epoch != l.Epoch && epoch != l.Epoch+1 {
+		return nil, fmt.Errorf("lookahead of epochs %d and %d does not include epoch %d", l.Epoch, l.Epoch+1, epoch)
+	}
+	offset := (epoch - l.Epoch) * l.SlotsPerEpoch()
+	return l.Proposers[offset : offset+l.SlotsPerEpoch()], nil
+}
+
+func (l *Lookahead) check() error {
+	if l.SecondsPerSlot == 0 {
+		return fmt.Errorf("%w: zero seconds per slot", ErrInvalidLookahead)
+	}
+	if len(l.Proposers) == 0 || len(l.Proposers)%2 != 0 {
+		return fmt.Errorf("%w: expected proposers of two epochs, got %d proposers", ErrInvalidLookahead, len(l.Proposers))
+	}
+	firstSlot := l.Epoch * l.SlotsPerEpoch()
+	for i, proposer := range l.Proposers {
+		if expected := firstSlot + uint64(i); uint64(proposer.Slot) != expected {
+			return fmt.Errorf("%w: expected proposer of slot %d, got slot %d", ErrInvalidLookahead, expected, proposer.Slot)
+		}
+	}
+	return nil
+}
+
+// MarshalBinary encodes the lookahead as the genesis time, seconds per slot and epoch,
+// followed by the pubkey and validator index of every proposer. The slots of the proposers are implied by their order.
+func (l *Lookahead) MarshalBinary() ([]byte, error) {
+	if err := l.check(); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, lookaheadHeaderLen+len(l.Proposers)*proposerLen)
+	out = binary.BigEndian.AppendUint64(out, l.GenesisTime)
+	out = binary.BigEndian.AppendUint64(out, l.SecondsPerSlot)
+	out = binary.BigEndian.AppendUint64(out, l.Epoch)
+	for _, proposer := range l.Proposers {
+		out = append(out, proposer.Pubkey[:]...)
+		out = binary.BigEndian.AppendUint64(out, uint64(proposer.ValidatorIndex))
+	}
+	return out, nil
+}
+
+func (l *Lookahead) UnmarshalBinary(data []byte) error {
+	if len(data) < lookaheadHeaderLen || (len(data)-lookaheadHeaderLen)%proposerLen != 0 {
+		return fmt.Errorf("%w: unexpected length %d", ErrInvalidLookahead, len(data))
+	}
+	l.GenesisTime = binary.BigEndian.Uint64(data[0:8])
+	l.SecondsPerSlot = binary.BigEndian.Uint64(data[8:16])
+	l.Epoch = binary.BigEndian.Uint64(data[16:24])
+	count := (len(data) - lookaheadHeaderLen) / proposerLen
+	firstSlot := l.Epoch * uint64(count/2)
+	l.Proposers = make([]*eth.Validator, count)
+	for i := range l.Proposers {
+		entry := data[lookaheadHeaderLen+i*proposerLen:]
+		proposer := &eth.Validator{
+			ValidatorIndex: eth.Uint64String(binary.BigEndian.Uint64(entry[48:56])),
+			Slot:           eth.Uint64String(firstSlot + uint64(i)),
+		}
+		copy(proposer.Pubkey[:], entry[:48])
+		l.Proposers[i] = proposer
+	}
+	return l.check()
+}
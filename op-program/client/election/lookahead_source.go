@@ -0,0 +1,201 @@
+package election
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/bits"
+
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Generalized indices of the nodes of the SSZ merkle trees of beacon blocks and states, that the proposer lookahead is read from.
+// The beacon state layout is that of the Fulu fork, which adds the proposer lookahead to the state (EIP-7917),
+// with the mainnet preset.
+const (
+	// SlotsPerEpoch is the SLOTS_PER_EPOCH of the mainnet preset.
+	SlotsPerEpoch = 32
+
+	// BeaconBlockStateRootGIndex is the state root in the tree of a beacon block, or of its header, of 5 fields.
+	BeaconBlockStateRootGIndex = 8 + 3
+
+	// The beacon state has 38 fields, in a tree of depth 6.
+	BeaconStateGenesisTimeGIndex       = 64 + 0
+	BeaconStateSlotGIndex              = 64 + 2
+	BeaconStateValidatorsGIndex        = 64 + 11
+	BeaconStateProposerLookaheadGIndex = 64 + 37
+
+	// proposerLookaheadLen is the number of proposers in the lookahead: those of the epoch of the state and of the next epoch.
+	proposerLookaheadLen = 2 * SlotsPerEpoch
+	// proposerLookaheadDepth is the depth of the tree of the lookahead, with 4 validator indices packed per chunk.
+	proposerLookaheadDepth = 4
+	// validatorsDepth is the depth of the tree of the validators list, of VALIDATOR_REGISTRY_LIMIT 2**40.
+	validatorsDepth = 40
+	// validatorDepth is the depth of the tree of a validator, of 8 fields.
+	validatorDepth = 3
+
+	lookaheadCacheSize = 16
+)
+
+// BeaconStateValidatorsLengthGIndex returns the generalized index of the length of the validators list in the beacon state.
+func BeaconStateValidatorsLengthGIndex() uint64 {
+	return BeaconStateValidatorsGIndex<<1 | 1
+}
+
+// BeaconStateProposerLookaheadChunkGIndex returns the generalized index of the chunk of the proposer lookahead
+// with the validator indices of the proposers of the given lookahead slot and the three slots after it.
+func BeaconStateProposerLookaheadChunkGIndex(slot uint64) uint64 {
+	return BeaconStateProposerLookaheadGIndex<<proposerLookaheadDepth | slot/4
+}
+
+// BeaconStateValidatorPubkeyGIndices returns the generalized indices of the two chunks of the pubkey
+// of the validator with the given index in the beacon state.
+func BeaconStateValidatorPubkeyGIndices(index uint64) (uint64, uint64) {
+	validator := (BeaconStateValidatorsGIndex<<1)<<validatorsDepth | index
+	pubkey := validator << validatorDepth
+	return pubkey << 1, pubkey<<1 | 1
+}
+
+// LookaheadGIndices returns the generalized indices of the beacon state nodes that the proposer lookahead is read from,
+// when the lookahead has proposers with the given validator indices.
+func LookaheadGIndices(proposers []uint64) []uint64 {
+	gindices := []uint64{
+		BeaconStateGenesisTimeGIndex,
+		BeaconStateSlotGIndex,
+		BeaconStateValidatorsLengthGIndex(),
+	}
+	for slot := uint64(0); slot < proposerLookaheadLen; slot += 4 {
+		gindices = append(gindices, BeaconStateProposerLookaheadChunkGIndex(slot))
+	}
+	for _, index := range proposers {
+		lo, hi := BeaconStateValidatorPubkeyGIndices(index)
+		gindices = append(gindices, lo, hi)
+	}
+	return gindices
+}
+
+// PreimageLookaheadSource proves the proposer lookahead of L1 blocks against their parent beacon block root (EIP-4788),
+// by walking the SSZ merkle trees of the parent beacon block and its post-state, of which every node is the sha256
+// pre-image of its two child nodes.
+// The lookahead of an L1 block is thus the proposer lookahead of the beacon state of its parent beacon block.
+type PreimageLookaheadSource struct {
+	l1     l1.Oracle
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+
+	lookaheads *simplelru.LRU[common.Hash, *Lookahead]
+}
+
+var _ LookaheadSource = (*PreimageLookaheadSource)(nil)
+
+func NewPreimageLookaheadSource(l1Oracle l1.Oracle, raw preimage.Oracle, hint preimage.Hinter) *PreimageLookaheadSource {
+	lookaheads, _ := simplelru.NewLRU[common.Hash, *Lookahead](lookaheadCacheSize, nil)
+	return &PreimageLookaheadSource{
+		l1:         l1Oracle,
+		oracle:     raw,
+		hint:       hint,
+		lookaheads: lookaheads,
+	}
+}
+
+func (s *PreimageLookaheadSource) LookaheadByBlockHash(blockHash common.Hash) *Lookahead {
+	if lookahead, ok := s.lookaheads.Get(blockHash); ok {
+		return lookahead
+	}
+	header := s.l1.HeaderByBlockHash(blockHash)
+	beaconRoot := header.ParentBeaconRoot()
+	if beaconRoot == nil {
+		panic(fmt.Errorf("L1 block %s has no parent beacon block root", blockHash))
+	}
+	s.hint.Hint(BeaconLookaheadHint(*beaconRoot))
+	tree := &sszTree{oracle: s.oracle, nodes: make(map[common.Hash][]byte)}
+
+	stateRoot := tree.node(*beaconRoot, BeaconBlockStateRootGIndex)
+	genesisTime := tree.uint64At(stateRoot, BeaconStateGenesisTimeGIndex)
+	slot := tree.uint64At(stateRoot, BeaconStateSlotGIndex)
+	// The execution payload of the parent beacon block is the parent of the L1 block, at the time of the slot of the state.
+	parentTime := s.l1.HeaderByBlockHash(header.ParentHash()).Time()
+	if slot == 0 || parentTime <= genesisTime || (parentTime-genesisTime)%slot != 0 {
+		panic(fmt.Errorf("beacon state at slot %d with genesis time %d does not match parent of L1 block %s at time %d",
+			slot, genesisTime, blockHash, parentTime))
+	}
+	if tree.node(stateRoot, BeaconStateProposerLookaheadGIndex) == zeroHash(proposerLookaheadDepth) {
+		panic(fmt.Errorf("beacon state at slot %d has no proposer lookahead", slot))
+	}
+
+	lookahead := &Lookahead{
+		GenesisTime:    genesisTime,
+		SecondsPerSlot: (parentTime - genesisTime) / slot,
+		Epoch:          slot / SlotsPerEpoch,
+	}
+	validators := tree.uint64At(stateRoot, BeaconStateValidatorsLengthGIndex())
+	for i := uint64(0); i < proposerLookaheadLen; i++ {
+		chunk := tree.node(stateRoot, BeaconStateProposerLookaheadChunkGIndex(i))
+		index := binary.LittleEndian.Uint64(chunk[(i%4)*8:])
+		if index >= validators {
+			panic(fmt.Errorf("proposer of lookahead slot %d has validator index %d, but there are %d validators", i, index, validators))
+		}
+		lo, hi := BeaconStateValidatorPubkeyGIndices(index)
+		proposer := &eth.Validator{
+			ValidatorIndex: eth.Uint64String(index),
+			Slot:           eth.Uint64String(lookahead.Epoch*SlotsPerEpoch + i),
+		}
+		copy(proposer.Pubkey[:32], tree.node(stateRoot, lo).Bytes())
+		copy(proposer.Pubkey[32:], tree.node(stateRoot, hi).Bytes())
+		lookahead.Proposers = append(lookahead.Proposers, proposer)
+	}
+	if err := lookahead.check(); err != nil {
+		panic(err)
+	}
+	s.lookaheads.Add(blockHash, lookahead)
+	return lookahead
+}
+
+// sszTree walks SSZ merkle trees with the sha256 pre-images of their nodes.
+type sszTree struct {
+	oracle preimage.Oracle
+	// nodes caches the children of the nodes that were walked
+	nodes map[common.Hash][]byte
+}
+
+// node returns the node at the generalized index in the tree with the given root.
+func (t *sszTree) node(root common.Hash, gindex uint64) common.Hash {
+	node := root
+	for depth := bits.Len64(gindex) - 2; depth >= 0; depth-- {
+		children, ok := t.nodes[node]
+		if !ok {
+			children = t.oracle.Get(preimage.Sha256Key(node))
+			if len(children) != 64 {
+				panic(fmt.Errorf("invalid SSZ node %s: expected 64 bytes, got %d", node, len(children)))
+			}
+			t.nodes[node] = children
+		}
+		if gindex>>depth&1 == 0 {
+			node = common.Hash(children[:32])
+		} else {
+			node = common.Hash(children[32:])
+		}
+	}
+	return node
+}
+
+// uint64At returns the uint64 leaf at the generalized index in the tree with the given root.
+func (t *sszTree) uint64At(root common.Hash, gindex uint64) uint64 {
+	leaf := t.node(root, gindex)
+	return binary.LittleEndian.Uint64(leaf[:8])
+}
+
+// zeroHash returns the root of a tree of the given depth of zero chunks.
+func zeroHash(depth int) common.Hash {
+	var node common.Hash
+	for i := 0; i < depth; i++ {
+		node = sha256.Sum256(append(node[:], node[:]...))
+	}
+	return node
+}
@@ -0,0 +1,60 @@
+package election
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func testLookahead(epoch uint64, slotsPerEpoch uint64) *Lookahead {
+	lookahead := &Lookahead{GenesisTime: 1000, SecondsPerSlot: 12, Epoch: epoch}
+	for slot := epoch * slotsPerEpoch; slot < (epoch+2)*slotsPerEpoch; slot++ {
+		lookahead.Proposers = append(lookahead.Proposers, &eth.Validator{
+			Pubkey:         eth.Bytes48{byte(slot), 0xaa},
+			ValidatorIndex: eth.Uint64String(100 + slot),
+			Slot:           eth.Uint64String(slot),
+		})
+	}
+	return lookahead
+}
+
+func TestLookaheadRoundTrip(t *testing.T) {
+	lookahead := testLookahead(3, 4)
+	data, err := lookahead.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, lookaheadHeaderLen+8*proposerLen)
+
+	var decoded Lookahead
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, lookahead, &decoded)
+	require.Equal(t, uint64(4), decoded.SlotsPerEpoch())
+
+	proposers, err := decoded.ProposersOf(4)
+	require.NoError(t, err)
+	require.Equal(t, lookahead.Proposers[4:], proposers)
+	_, err = decoded.ProposersOf(5)
+	require.Error(t, err)
+	_, err = decoded.ProposersOf(2)
+	require.Error(t, err)
+}
+
+func TestLookaheadInvalid(t *testing.T) {
+	lookahead := testLookahead(3, 4)
+	lookahead.Proposers[2].Slot++
+	_, err := lookahead.MarshalBinary()
+	require.ErrorIs(t, err, ErrInvalidLookahead)
+
+	lookahead = testLookahead(3, 4)
+	lookahead.Proposers = lookahead.Proposers[:7]
+	_, err = lookahead.MarshalBinary()
+	require.ErrorIs(t, err, ErrInvalidLookahead)
+
+	lookahead = testLookahead(3, 4)
+	data, err := lookahead.MarshalBinary()
+	require.NoError(t, err)
+	var decoded Lookahead
+	require.ErrorIs(t, decoded.UnmarshalBinary(data[:len(data)-1]), ErrInvalidLookahead)
+	require.ErrorIs(t, decoded.UnmarshalBinary(data[:lookaheadHeaderLen]), ErrInvalidLookahead)
+}
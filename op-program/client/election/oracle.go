@@ -0,0 +1,45 @@
+package election
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// Oracle defines the high-level API used to retrieve the L1 data that the election is computed from,
+// in addition to the L1 blocks retrieved through the l1.Oracle.
+// The returned data is always the preimage of the requested hash.
+type Oracle interface {
+	// NodeByHash retrieves the merkle-patricia trie node pre-image of the L1 state for a given hash.
+	// Trie nodes may be from the world state trie or any account storage trie.
+	NodeByHash(nodeHash common.Hash) []byte
+
+	// CodeByHash retrieves the L1 contract code pre-image for a given hash.
+	CodeByHash(codeHash common.Hash) []byte
+}
+
+// PreimageOracle implements Oracle using by interfacing with the pure preimage.Oracle
+// to fetch pre-images to decode into the requested data.
+type PreimageOracle struct {
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+}
+
+var _ Oracle = (*PreimageOracle)(nil)
+
+func NewPreimageOracle(raw preimage.Oracle, hint preimage.Hinter) *PreimageOracle {
+	return &PreimageOracle{
+		oracle: raw,
+		hint:   hint,
+	}
+}
+
+func (p *PreimageOracle) NodeByHash(nodeHash common.Hash) []byte {
+	p.hint.Hint(StateNodeHint(nodeHash))
+	return p.oracle.Get(preimage.Keccak256Key(nodeHash))
+}
+
+func (p *PreimageOracle) CodeByHash(codeHash common.Hash) []byte {
+	p.hint.Hint(CodeHint(codeHash))
+	return p.oracle.Get(preimage.Keccak256Key(codeHash))
+}
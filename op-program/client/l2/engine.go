@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/predeploys"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -143,6 +144,33 @@ func (o *OracleEngine) L2BlockRefByNumber(ctx context.Context, n uint64) (eth.L2
 	return o.L2BlockRefByHash(ctx, hash)
 }
 
+// GetProof reads the account of the given address from the state of the block with the given hash.
+// The account fields are read directly from the oracle-backed state, no merkle proof is included in the result.
+func (o *OracleEngine) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	if len(storage) > 0 {
+		return nil, errors.New("storage proofs are not supported")
+	}
+	header := o.backend.GetHeaderByHash(common.HexToHash(blockTag))
+	if header == nil {
+		return nil, fmt.Errorf("%w: block %s", ErrNotFound, blockTag)
+	}
+	stateDB, err := o.backend.StateAt(header.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open L2 state db at block %s: %w", header.Hash(), err)
+	}
+	codeHash := stateDB.GetCodeHash(address)
+	if codeHash == (common.Hash{}) {
+		codeHash = types.EmptyCodeHash
+	}
+	return &eth.AccountResult{
+		Address:     address,
+		Balance:     (*hexutil.Big)(stateDB.GetBalance(address).ToBig()),
+		CodeHash:    codeHash,
+		Nonce:       hexutil.Uint64(stateDB.GetNonce(address)),
+		StorageHash: stateDB.GetStorageRoot(address),
+	}, nil
+}
+
 func (o *OracleEngine) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
 	payload, err := o.PayloadByHash(ctx, hash)
 	if err != nil {
@@ -8,6 +8,7 @@ import (
 	"slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/types"
 
 	opnode "github.com/ethereum-optimism/optimism/op-node"
@@ -22,17 +23,18 @@ import (
 )
 
 var (
-	ErrMissingRollupConfig = errors.New("missing rollup config")
-	ErrMissingL2Genesis    = errors.New("missing l2 genesis")
-	ErrInvalidL1Head       = errors.New("invalid l1 head")
-	ErrInvalidL2Head       = errors.New("invalid l2 head")
-	ErrInvalidL2OutputRoot = errors.New("invalid l2 output root")
-	ErrL1AndL2Inconsistent = errors.New("l1 and l2 options must be specified together or both omitted")
-	ErrInvalidL2Claim      = errors.New("invalid l2 claim")
-	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
-	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
-	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
-	ErrInvalidDataFormat   = errors.New("invalid data format")
+	ErrMissingRollupConfig  = errors.New("missing rollup config")
+	ErrMissingL2Genesis     = errors.New("missing l2 genesis")
+	ErrMissingL1ChainConfig = errors.New("missing l1 chain config")
+	ErrInvalidL1Head        = errors.New("invalid l1 head")
+	ErrInvalidL2Head        = errors.New("invalid l2 head")
+	ErrInvalidL2OutputRoot  = errors.New("invalid l2 output root")
+	ErrL1AndL2Inconsistent  = errors.New("l1 and l2 options must be specified together or both omitted")
+	ErrInvalidL2Claim       = errors.New("invalid l2 claim")
+	ErrInvalidL2ClaimBlock  = errors.New("invalid l2 claim block number")
+	ErrDataDirRequired      = errors.New("datadir must be specified when in non-fetching mode")
+	ErrNoExecInServerMode   = errors.New("exec command must not be set when in server mode")
+	ErrInvalidDataFormat    = errors.New("invalid data format")
 )
 
 type Config struct {
@@ -63,6 +65,8 @@ type Config struct {
 	L2ClaimBlockNumber uint64
 	// L2ChainConfig is the op-geth chain config for the L2 execution engine
 	L2ChainConfig *params.ChainConfig
+	// L1ChainConfig is the chain config of the L1 chain, that the election contracts are executed with
+	L1ChainConfig *params.ChainConfig
 	// ExecCmd specifies the client program to execute in a separate process.
 	// If unset, the fault proof client is run in the same process.
 	ExecCmd string
@@ -97,6 +101,9 @@ func (c *Config) Check() error {
 	if c.L2ChainConfig == nil {
 		return ErrMissingL2Genesis
 	}
+	if c.L1ChainConfig == nil {
+		return ErrMissingL1ChainConfig
+	}
 	if (c.L1URL != "") != (c.L2URL != "") {
 		return ErrL1AndL2Inconsistent
 	}
@@ -116,7 +123,8 @@ func (c *Config) FetchingEnabled() bool {
 	return c.L1URL != "" && c.L2URL != "" && c.L1BeaconURL != ""
 }
 
-// NewConfig creates a Config with all optional values set to the CLI default value
+// NewConfig creates a Config with all optional values set to the CLI default value.
+// The L1 chain config is only set for known L1 chains, it must be set explicitly for custom L1 chains.
 func NewConfig(
 	rollupCfg *rollup.Config,
 	l2Genesis *params.ChainConfig,
@@ -128,9 +136,11 @@ func NewConfig(
 ) *Config {
 	_, err := params.LoadOPStackChainConfig(l2Genesis.ChainID.Uint64())
 	isCustomConfig := err != nil
+	l1ChainConfig, _ := chainconfig.L1ChainConfigByChainID(rollupCfg.L1ChainID.Uint64())
 	return &Config{
 		Rollup:              rollupCfg,
 		L2ChainConfig:       l2Genesis,
+		L1ChainConfig:       l1ChainConfig,
 		L1Head:              l1Head,
 		L2Head:              l2Head,
 		L2OutputRoot:        l2OutputRoot,
@@ -192,6 +202,18 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	if err != nil {
 		return nil, fmt.Errorf("invalid genesis: %w", err)
 	}
+	var l1ChainConfig *params.ChainConfig
+	if l1GenesisPath := ctx.String(flags.L1GenesisPath.Name); l1GenesisPath != "" {
+		l1ChainConfig, err = loadChainConfigFromGenesis(l1GenesisPath)
+		if err != nil {
+			return nil, fmt.Errorf("invalid l1 genesis: %w", err)
+		}
+	} else {
+		l1ChainConfig, err = chainconfig.L1ChainConfigByChainID(rollupCfg.L1ChainID.Uint64())
+		if err != nil {
+			return nil, fmt.Errorf("flag %s is required for custom L1 chains: %w", flags.L1GenesisPath.Name, err)
+		}
+	}
 	dbFormat := types.DataFormat(ctx.String(flags.DataFormat.Name))
 	if !slices.Contains(types.SupportedDataFormats, dbFormat) {
 		return nil, fmt.Errorf("invalid %w: %v", ErrInvalidDataFormat, dbFormat)
@@ -202,6 +224,7 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 		DataFormat:          dbFormat,
 		L2URL:               ctx.String(flags.L2NodeAddr.Name),
 		L2ChainConfig:       l2ChainConfig,
+		L1ChainConfig:       l1ChainConfig,
 		L2Head:              l2Head,
 		L2OutputRoot:        l2OutputRoot,
 		L2Claim:             l2Claim,
@@ -220,12 +243,12 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 func loadChainConfigFromGenesis(path string) (*params.ChainConfig, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("read l2 genesis file: %w", err)
+		return nil, fmt.Errorf("read genesis file: %w", err)
 	}
 	var genesis core.Genesis
 	err = json.Unmarshal(data, &genesis)
 	if err != nil {
-		return nil, fmt.Errorf("parse l2 genesis file: %w", err)
+		return nil, fmt.Errorf("parse genesis file: %w", err)
 	}
 	return genesis.Config, nil
 }
@@ -88,6 +88,13 @@ func TestL2GenesisRequired(t *testing.T) {
 	require.ErrorIs(t, err, ErrMissingL2Genesis)
 }
 
+func TestL1ChainConfigRequired(t *testing.T) {
+	config := validConfig()
+	config.L1ChainConfig = nil
+	err := config.Check()
+	require.ErrorIs(t, err, ErrMissingL1ChainConfig)
+}
+
 func TestFetchingArgConsistency(t *testing.T) {
 	t.Run("RequireL2WhenL1Set", func(t *testing.T) {
 		cfg := validConfig()
@@ -77,6 +77,11 @@ var (
 		Usage:   "Path to the op-geth genesis file",
 		EnvVars: prefixEnvVars("L2_GENESIS"),
 	}
+	L1GenesisPath = &cli.StringFlag{
+		Name:    "l1.genesis",
+		Usage:   "Path to the L1 genesis file, required if the L1 chain is not a known public chain",
+		EnvVars: prefixEnvVars("L1_GENESIS"),
+	}
 	L1NodeAddr = &cli.StringFlag{
 		Name:    "l1",
 		Usage:   "Address of L1 JSON-RPC endpoint to use (eth namespace required)",
@@ -132,6 +137,7 @@ var programFlags = []cli.Flag{
 	DataFormat,
 	L2NodeAddr,
 	L2GenesisPath,
+	L1GenesisPath,
 	L1NodeAddr,
 	L1BeaconAddr,
 	L1TrustRPC,
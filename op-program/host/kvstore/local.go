@@ -25,6 +25,7 @@ var (
 	l2ChainIDKey          = client.L2ChainIDLocalIndex.PreimageKey()
 	l2ChainConfigKey      = client.L2ChainConfigLocalIndex.PreimageKey()
 	rollupKey             = client.RollupConfigLocalIndex.PreimageKey()
+	l1ChainConfigKey      = client.L1ChainConfigLocalIndex.PreimageKey()
 )
 
 func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
@@ -51,6 +52,8 @@ func (s *LocalPreimageSource) Get(key common.Hash) ([]byte, error) {
 		return json.Marshal(s.config.L2ChainConfig)
 	case rollupKey:
 		return json.Marshal(s.config.Rollup)
+	case l1ChainConfigKey:
+		return json.Marshal(s.config.L1ChainConfig)
 	default:
 		return nil, ErrNotFound
 	}
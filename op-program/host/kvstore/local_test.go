@@ -21,6 +21,7 @@ func TestLocalPreimageSource(t *testing.T) {
 		L2Claim:            common.HexToHash("0x3333"),
 		L2ClaimBlockNumber: 1234,
 		L2ChainConfig:      params.GoerliChainConfig,
+		L1ChainConfig:      params.SepoliaChainConfig,
 	}
 	source := NewLocalPreimageSource(cfg)
 	tests := []struct {
@@ -35,6 +36,7 @@ func TestLocalPreimageSource(t *testing.T) {
 		{"L2ChainID", l2ChainIDKey, binary.BigEndian.AppendUint64(nil, cfg.L2ChainConfig.ChainID.Uint64())},
 		{"Rollup", rollupKey, asJson(t, cfg.Rollup)},
 		{"ChainConfig", l2ChainConfigKey, asJson(t, cfg.L2ChainConfig)},
+		{"L1ChainConfig", l1ChainConfigKey, asJson(t, cfg.L1ChainConfig)},
 		{"Unknown", preimage.LocalIndexKey(1000).PreimageKey(), nil},
 	}
 	for _, test := range tests {
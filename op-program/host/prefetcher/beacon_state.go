@@ -0,0 +1,144 @@
+package prefetcher
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/election"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SSZ types of the beacon chain, with the limits of the mainnet preset.
+var (
+	sszUint8   = sszUint(1)
+	sszUint64  = sszUint(8)
+	sszUint256 = sszUint(32)
+	sszBytes4  = sszBytes(4)
+	sszBytes20 = sszBytes(20)
+	sszBytes32 = sszBytes(32)
+	sszBytes48 = sszBytes(48)
+	sszBytes96 = sszBytes(96)
+
+	beaconBlockHeaderType = sszContainer{sszUint64, sszUint64, sszBytes32, sszBytes32, sszBytes32}
+
+	forkType       = sszContainer{sszBytes4, sszBytes4, sszUint64}
+	eth1DataType   = sszContainer{sszBytes32, sszUint64, sszBytes32}
+	checkpointType = sszContainer{sszUint64, sszBytes32}
+	validatorType  = sszContainer{sszBytes48, sszBytes32, sszUint64, sszUint8, sszUint64, sszUint64, sszUint64, sszUint64}
+
+	syncCommitteeType = sszContainer{sszVector{sszBytes48, 512}, sszBytes48}
+
+	executionPayloadHeaderType = sszContainer{
+		sszBytes32, sszBytes20, sszBytes32, sszBytes32, sszBytes(256), sszBytes32,
+		sszUint64, sszUint64, sszUint64, sszUint64, sszList{sszUint8, 32}, sszUint256,
+		sszBytes32, sszBytes32, sszBytes32, sszUint64, sszUint64,
+	}
+
+	historicalSummaryType        = sszContainer{sszBytes32, sszBytes32}
+	pendingDepositType           = sszContainer{sszBytes48, sszBytes32, sszUint64, sszBytes96, sszUint64}
+	pendingPartialWithdrawalType = sszContainer{sszUint64, sszUint64, sszUint64}
+	pendingConsolidationType     = sszContainer{sszUint64, sszUint64}
+
+	// beaconStateType is the BeaconState of the Fulu fork.
+	beaconStateType = sszContainer{
+		sszUint64,                               // genesis_time
+		sszBytes32,                              // genesis_validators_root
+		sszUint64,                               // slot
+		forkType,                                // fork
+		beaconBlockHeaderType,                   // latest_block_header
+		sszVector{sszBytes32, 8192},             // block_roots
+		sszVector{sszBytes32, 8192},             // state_roots
+		sszList{sszBytes32, 1 << 24},            // historical_roots
+		eth1DataType,                            // eth1_data
+		sszList{eth1DataType, 2048},             // eth1_data_votes
+		sszUint64,                               // eth1_deposit_index
+		sszList{validatorType, 1 << 40},         // validators
+		sszList{sszUint64, 1 << 40},             // balances
+		sszVector{sszBytes32, 65536},            // randao_mixes
+		sszVector{sszUint64, 8192},              // slashings
+		sszList{sszUint8, 1 << 40},              // previous_epoch_participation
+		sszList{sszUint8, 1 << 40},              // current_epoch_participation
+		sszBytes(1),                             // justification_bits
+		checkpointType,                          // previous_justified_checkpoint
+		checkpointType,                          // current_justified_checkpoint
+		checkpointType,                          // finalized_checkpoint
+		sszList{sszUint64, 1 << 40},             // inactivity_scores
+		syncCommitteeType,                       // current_sync_committee
+		syncCommitteeType,                       // next_sync_committee
+		executionPayloadHeaderType,              // latest_execution_payload_header
+		sszUint64,                               // next_withdrawal_index
+		sszUint64,                               // next_withdrawal_validator_index
+		sszList{historicalSummaryType, 1 << 24}, // historical_summaries
+		sszUint64,                               // deposit_requests_start_index
+		sszUint64,                               // deposit_balance_to_consume
+		sszUint64,                               // exit_balance_to_consume
+		sszUint64,                               // earliest_exit_epoch
+		sszUint64,                               // consolidation_balance_to_consume
+		sszUint64,                               // earliest_consolidation_epoch
+		sszList{pendingDepositType, 1 << 27},    // pending_deposits
+		sszList{pendingPartialWithdrawalType, 1 << 27},   // pending_partial_withdrawals
+		sszList{pendingConsolidationType, 1 << 18},       // pending_consolidations
+		sszVector{sszUint64, 2 * election.SlotsPerEpoch}, // proposer_lookahead
+	}
+)
+
+const beaconStateProposerLookaheadField = 37
+
+// storeBeaconLookahead stores the nodes of the beacon block and state trees that prove the proposer lookahead
+// of the beacon state of the beacon block with the given root.
+func (p *Prefetcher) storeBeaconLookahead(ctx context.Context, root common.Hash) error {
+	header, err := p.l1BeaconFetcher.BeaconBlockHeader(ctx, root)
+	if err != nil {
+		return fmt.Errorf("failed to fetch header of beacon block %s: %w", root, err)
+	}
+	proof := make(sszProof)
+	headerRoot, err := beaconBlockHeaderType.hashTreeRoot(encodeBeaconBlockHeader(header), []uint64{election.BeaconBlockStateRootGIndex}, proof)
+	if err != nil {
+		return fmt.Errorf("failed to merkleize header of beacon block %s: %w", root, err)
+	}
+	if headerRoot != root {
+		return fmt.Errorf("header of beacon block %s has root %s", root, headerRoot)
+	}
+
+	stateRoot := common.Hash(header.StateRoot)
+	state, err := p.l1BeaconFetcher.BeaconStateSSZ(ctx, stateRoot)
+	if err != nil {
+		return fmt.Errorf("failed to fetch beacon state %s: %w", stateRoot, err)
+	}
+	fields, err := beaconStateType.fields(state)
+	if err != nil {
+		return fmt.Errorf("failed to decode beacon state %s: %w", stateRoot, err)
+	}
+	lookahead := fields[beaconStateProposerLookaheadField]
+	proposers := make([]uint64, len(lookahead)/8)
+	for i := range proposers {
+		proposers[i] = binary.LittleEndian.Uint64(lookahead[i*8:])
+	}
+	computed, err := beaconStateType.hashTreeRoot(state, election.LookaheadGIndices(proposers), proof)
+	if err != nil {
+		return fmt.Errorf("failed to merkleize beacon state %s: %w", stateRoot, err)
+	}
+	if computed != stateRoot {
+		return fmt.Errorf("beacon state %s has root %s", stateRoot, computed)
+	}
+
+	for node, children := range proof {
+		if err := p.kvStore.Put(preimage.Sha256Key(node).PreimageKey(), children); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeBeaconBlockHeader(header eth.BeaconBlockHeader) []byte {
+	out := make([]byte, 0, 8+8+32+32+32)
+	out = binary.LittleEndian.AppendUint64(out, uint64(header.Slot))
+	out = binary.LittleEndian.AppendUint64(out, uint64(header.ProposerIndex))
+	out = append(out, header.ParentRoot[:]...)
+	out = append(out, header.StateRoot[:]...)
+	return append(out, header.BodyRoot[:]...)
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/election"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
@@ -45,6 +46,18 @@ type L1BlobSource interface {
 	GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error)
 }
 
+// L1ElectionSource provides the L1 state that elections are computed from.
+type L1ElectionSource interface {
+	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
+	CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
+}
+
+// L1BeaconStateSource provides the beacon states that the proposer lookaheads of L1 blocks are proven against.
+type L1BeaconStateSource interface {
+	BeaconBlockHeader(ctx context.Context, root common.Hash) (eth.BeaconBlockHeader, error)
+	BeaconStateSSZ(ctx context.Context, stateRoot common.Hash) ([]byte, error)
+}
+
 type L2Source interface {
 	InfoAndTxsByHash(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Transactions, error)
 	NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error)
@@ -53,21 +66,25 @@ type L2Source interface {
 }
 
 type Prefetcher struct {
-	logger        log.Logger
-	l1Fetcher     L1Source
-	l1BlobFetcher L1BlobSource
-	l2Fetcher     L2Source
-	lastHint      string
-	kvStore       kvstore.KV
+	logger            log.Logger
+	l1Fetcher         L1Source
+	l1BlobFetcher     L1BlobSource
+	l1ElectionFetcher L1ElectionSource
+	l1BeaconFetcher   L1BeaconStateSource
+	l2Fetcher         L2Source
+	lastHint          string
+	kvStore           kvstore.KV
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l1BlobFetcher L1BlobSource, l1ElectionFetcher L1ElectionSource, l1BeaconFetcher L1BeaconStateSource, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
 	return &Prefetcher{
-		logger:        logger,
-		l1Fetcher:     NewRetryingL1Source(logger, l1Fetcher),
-		l1BlobFetcher: NewRetryingL1BlobSource(logger, l1BlobFetcher),
-		l2Fetcher:     NewRetryingL2Source(logger, l2Fetcher),
-		kvStore:       kvStore,
+		logger:            logger,
+		l1Fetcher:         NewRetryingL1Source(logger, l1Fetcher),
+		l1BlobFetcher:     NewRetryingL1BlobSource(logger, l1BlobFetcher),
+		l1ElectionFetcher: NewRetryingL1ElectionSource(logger, l1ElectionFetcher),
+		l1BeaconFetcher:   NewRetryingL1BeaconStateSource(logger, l1BeaconFetcher),
+		l2Fetcher:         NewRetryingL2Source(logger, l2Fetcher),
+		kvStore:           kvStore,
 	}
 }
 
@@ -235,6 +252,31 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 			return err
 		}
 		return p.kvStore.Put(preimage.PrecompileKey(inputHash).PreimageKey(), result)
+	case election.HintL1StateNode:
+		if len(hintBytes) != 32 {
+			return fmt.Errorf("invalid L1 state node hint: %x", hint)
+		}
+		hash := common.Hash(hintBytes)
+		node, err := p.l1ElectionFetcher.NodeByHash(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 state node %s: %w", hash, err)
+		}
+		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), node)
+	case election.HintL1Code:
+		if len(hintBytes) != 32 {
+			return fmt.Errorf("invalid L1 code hint: %x", hint)
+		}
+		hash := common.Hash(hintBytes)
+		code, err := p.l1ElectionFetcher.CodeByHash(ctx, hash)
+		if err != nil {
+			return fmt.Errorf("failed to fetch L1 contract code %s: %w", hash, err)
+		}
+		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), code)
+	case election.HintL1BeaconLookahead:
+		if len(hintBytes) != 32 {
+			return fmt.Errorf("invalid L1 beacon lookahead hint: %x", hint)
+		}
+		return p.storeBeaconLookahead(ctx, common.Hash(hintBytes))
 	case l2.HintL2BlockHeader, l2.HintL2Transactions:
 		if len(hintBytes) != 32 {
 			return fmt.Errorf("invalid L2 header/tx hint: %x", hint)
@@ -5,9 +5,11 @@ import (
 	"crypto/sha256"
 	"encoding/binary"
 	"fmt"
+	"math/big"
 	"math/rand"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -17,7 +19,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+	"github.com/ethereum-optimism/optimism/op-program/client/election"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
+	l1test "github.com/ethereum-optimism/optimism/op-program/client/l1/test"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
@@ -512,6 +516,171 @@ func TestFetchL2Code(t *testing.T) {
 	})
 }
 
+func TestFetchL1StateNode(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	node := testutils.RandomData(rng, 30)
+	hash := crypto.Keccak256Hash(node)
+	key := preimage.Keccak256Key(hash).PreimageKey()
+
+	t.Run("AlreadyKnown", func(t *testing.T) {
+		prefetcher, _, _, _, kv := createPrefetcher(t)
+		require.NoError(t, kv.Put(key, node))
+
+		oracle := election.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.NodeByHash(hash)
+		require.EqualValues(t, node, result)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, _, electionCl, _ := createElectionPrefetcher(t)
+		electionCl.ExpectNodeByHash(hash, node, nil)
+		defer electionCl.AssertExpectations(t)
+
+		oracle := election.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result := oracle.NodeByHash(hash)
+		require.EqualValues(t, node, result)
+	})
+}
+
+func TestFetchL1Code(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	code := testutils.RandomData(rng, 30)
+	hash := crypto.Keccak256Hash(code)
+
+	prefetcher, _, electionCl, _ := createElectionPrefetcher(t)
+	electionCl.ExpectCodeByHash(hash, code, nil)
+	defer electionCl.AssertExpectations(t)
+
+	oracle := election.NewPreimageOracle(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+	result := oracle.CodeByHash(hash)
+	require.EqualValues(t, code, result)
+}
+
+func TestFetchL1BeaconLookahead(t *testing.T) {
+	genesisTime := uint64(1000)
+	slot := uint64(3*election.SlotsPerEpoch + 5)
+	validators := 100
+	proposers := make([]uint64, 2*election.SlotsPerEpoch)
+	for i := range proposers {
+		proposers[i] = uint64(i*7) % uint64(validators)
+	}
+	state := testBeaconState(genesisTime, slot, validators, proposers)
+	stateRoot, err := beaconStateType.hashTreeRoot(state, nil, nil)
+	require.NoError(t, err)
+	header := eth.BeaconBlockHeader{Slot: eth.Uint64String(slot), ProposerIndex: 3, StateRoot: eth.Bytes32(stateRoot)}
+	beaconRoot, err := beaconBlockHeaderType.hashTreeRoot(encodeBeaconBlockHeader(header), nil, nil)
+	require.NoError(t, err)
+
+	// The parent of the L1 block is the execution payload of the beacon block
+	parent := &types.Header{Number: big.NewInt(1), Time: genesisTime + slot*12}
+	block := &types.Header{Number: big.NewInt(2), Time: parent.Time + 12, ParentHash: parent.Hash(), ParentBeaconRoot: &beaconRoot}
+	l1Oracle := l1test.NewStubOracle(t)
+	l1Oracle.Blocks[parent.Hash()] = eth.HeaderBlockInfo(parent)
+	l1Oracle.Blocks[block.Hash()] = eth.HeaderBlockInfo(block)
+
+	t.Run("Valid", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		prefetcher.l1BeaconFetcher = &stubBeaconStates{headers: map[common.Hash]eth.BeaconBlockHeader{beaconRoot: header}, states: map[common.Hash][]byte{stateRoot: state}}
+
+		source := election.NewPreimageLookaheadSource(l1Oracle, asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		lookahead := source.LookaheadByBlockHash(block.Hash())
+		require.Equal(t, genesisTime, lookahead.GenesisTime)
+		require.Equal(t, uint64(12), lookahead.SecondsPerSlot)
+		require.Equal(t, uint64(3), lookahead.Epoch)
+		require.Len(t, lookahead.Proposers, len(proposers))
+		for i, proposer := range lookahead.Proposers {
+			require.Equal(t, proposers[i], uint64(proposer.ValidatorIndex))
+			require.Equal(t, 3*election.SlotsPerEpoch+uint64(i), uint64(proposer.Slot))
+			require.Equal(t, testPubkey(proposers[i]), proposer.Pubkey)
+		}
+	})
+
+	t.Run("InvalidState", func(t *testing.T) {
+		prefetcher, _, _, _, _ := createPrefetcher(t)
+		tampered := testBeaconState(genesisTime, slot+1, validators, proposers)
+		prefetcher.l1BeaconFetcher = &stubBeaconStates{headers: map[common.Hash]eth.BeaconBlockHeader{beaconRoot: header}, states: map[common.Hash][]byte{stateRoot: tampered}}
+
+		require.NoError(t, prefetcher.Hint(election.BeaconLookaheadHint(beaconRoot).Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Sha256Key(beaconRoot).PreimageKey())
+		require.ErrorContains(t, err, "beacon state")
+	})
+}
+
+// testBeaconState encodes a beacon state with the given validators, of which the pubkeys are given by testPubkey,
+// and the given proposer lookahead. The other fields are empty.
+func testBeaconState(genesisTime uint64, slot uint64, validators int, proposers []uint64) []byte {
+	fields := make([][]byte, len(beaconStateType))
+	for i, field := range beaconStateType {
+		fields[i] = make([]byte, field.fixedSize())
+	}
+	binary.LittleEndian.PutUint64(fields[0], genesisTime)
+	binary.LittleEndian.PutUint64(fields[2], slot)
+	for i := 0; i < validators; i++ {
+		validator := make([]byte, validatorType.fixedSize())
+		pubkey := testPubkey(uint64(i))
+		copy(validator, pubkey[:])
+		fields[11] = append(fields[11], validator...)
+	}
+	for i, proposer := range proposers {
+		binary.LittleEndian.PutUint64(fields[beaconStateProposerLookaheadField][i*8:], proposer)
+	}
+	// the execution payload header has an empty extra data
+	payloadHeader := make([][]byte, len(executionPayloadHeaderType))
+	for i, field := range executionPayloadHeaderType {
+		payloadHeader[i] = make([]byte, field.fixedSize())
+	}
+	fields[24] = encodeTestContainer(executionPayloadHeaderType, payloadHeader)
+	return encodeTestContainer(beaconStateType, fields)
+}
+
+func encodeTestContainer(container sszContainer, fields [][]byte) []byte {
+	var fixed, variable []byte
+	offset := 0
+	for i, field := range container {
+		if field.fixedSize() == 0 {
+			offset += 4
+		} else {
+			offset += len(fields[i])
+		}
+	}
+	for i, field := range container {
+		if field.fixedSize() == 0 {
+			fixed = binary.LittleEndian.AppendUint32(fixed, uint32(offset+len(variable)))
+			variable = append(variable, fields[i]...)
+		} else {
+			fixed = append(fixed, fields[i]...)
+		}
+	}
+	return append(fixed, variable...)
+}
+
+func testPubkey(index uint64) (pubkey eth.Bytes48) {
+	pubkey[0] = 0xaa
+	binary.BigEndian.PutUint64(pubkey[40:], index)
+	return
+}
+
+type stubBeaconStates struct {
+	headers map[common.Hash]eth.BeaconBlockHeader
+	states  map[common.Hash][]byte
+}
+
+func (s *stubBeaconStates) BeaconBlockHeader(ctx context.Context, root common.Hash) (eth.BeaconBlockHeader, error) {
+	header, ok := s.headers[root]
+	if !ok {
+		return eth.BeaconBlockHeader{}, ethereum.NotFound
+	}
+	return header, nil
+}
+
+func (s *stubBeaconStates) BeaconStateSSZ(ctx context.Context, stateRoot common.Hash) ([]byte, error) {
+	state, ok := s.states[stateRoot]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return state, nil
+}
+
 func TestBadHints(t *testing.T) {
 	prefetcher, _, _, _, kv := createPrefetcher(t)
 	hash := common.Hash{0xad}
@@ -569,7 +738,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l1BlobSource, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LevelInfo), l1Source, l1BlobSource, new(testutils.MockDebugClient), new(stubBeaconStates), l2Cl, kv)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -621,10 +790,25 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *test
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, l1BlobSource, new(testutils.MockDebugClient), new(stubBeaconStates), l2Source, kv)
 	return prefetcher, l1Source, l1BlobSource, l2Source, kv
 }
 
+func createElectionPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *testutils.MockDebugClient, kvstore.KV) {
+	logger := testlog.Logger(t, log.LevelDebug)
+	kv := kvstore.NewMemKV()
+
+	l1Source := new(testutils.MockL1Source)
+	l1ElectionSource := new(testutils.MockDebugClient)
+	l2Source := &l2Client{
+		MockL2Client:    new(testutils.MockL2Client),
+		MockDebugClient: new(testutils.MockDebugClient),
+	}
+
+	prefetcher := NewPrefetcher(logger, l1Source, new(testutils.MockBlobsFetcher), l1ElectionSource, new(stubBeaconStates), l2Source, kv)
+	return prefetcher, l1Source, l1ElectionSource, kv
+}
+
 func storeBlock(t *testing.T, kv kvstore.KV, block *types.Block, receipts types.Receipts) {
 	// Pre-store receipts
 	opaqueRcpts, err := eth.EncodeReceipts(receipts)
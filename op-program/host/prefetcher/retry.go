@@ -95,6 +95,78 @@ func (s *RetryingL1BlobSource) GetBlobs(ctx context.Context, ref eth.L1BlockRef,
 
 var _ L1BlobSource = (*RetryingL1BlobSource)(nil)
 
+type RetryingL1ElectionSource struct {
+	logger   log.Logger
+	source   L1ElectionSource
+	strategy retry.Strategy
+}
+
+func NewRetryingL1ElectionSource(logger log.Logger, source L1ElectionSource) *RetryingL1ElectionSource {
+	return &RetryingL1ElectionSource{
+		logger:   logger,
+		source:   source,
+		strategy: retry.Exponential(),
+	}
+}
+
+func (s *RetryingL1ElectionSource) NodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]byte, error) {
+		n, err := s.source.NodeByHash(ctx, hash)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve l1 state node", "hash", hash, "err", err)
+		}
+		return n, err
+	})
+}
+
+func (s *RetryingL1ElectionSource) CodeByHash(ctx context.Context, hash common.Hash) ([]byte, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]byte, error) {
+		c, err := s.source.CodeByHash(ctx, hash)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve l1 code", "hash", hash, "err", err)
+		}
+		return c, err
+	})
+}
+
+var _ L1ElectionSource = (*RetryingL1ElectionSource)(nil)
+
+type RetryingL1BeaconStateSource struct {
+	logger   log.Logger
+	source   L1BeaconStateSource
+	strategy retry.Strategy
+}
+
+func NewRetryingL1BeaconStateSource(logger log.Logger, source L1BeaconStateSource) *RetryingL1BeaconStateSource {
+	return &RetryingL1BeaconStateSource{
+		logger:   logger,
+		source:   source,
+		strategy: retry.Exponential(),
+	}
+}
+
+func (s *RetryingL1BeaconStateSource) BeaconBlockHeader(ctx context.Context, root common.Hash) (eth.BeaconBlockHeader, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() (eth.BeaconBlockHeader, error) {
+		h, err := s.source.BeaconBlockHeader(ctx, root)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve beacon block header", "root", root, "err", err)
+		}
+		return h, err
+	})
+}
+
+func (s *RetryingL1BeaconStateSource) BeaconStateSSZ(ctx context.Context, stateRoot common.Hash) ([]byte, error) {
+	return retry.Do(ctx, maxAttempts, s.strategy, func() ([]byte, error) {
+		state, err := s.source.BeaconStateSSZ(ctx, stateRoot)
+		if err != nil {
+			s.logger.Warn("Failed to retrieve beacon state", "root", stateRoot, "err", err)
+		}
+		return state, err
+	})
+}
+
+var _ L1BeaconStateSource = (*RetryingL1BeaconStateSource)(nil)
+
 type RetryingL2Source struct {
 	logger   log.Logger
 	source   L2Source
@@ -0,0 +1,281 @@
+package prefetcher
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errInvalidSSZ = errors.New("invalid SSZ encoding")
+
+// zeroHashes are the roots of trees of zero chunks, by depth.
+var zeroHashes = func() (out [65]common.Hash) {
+	for i := 1; i < len(out); i++ {
+		out[i] = hashPair(out[i-1], out[i-1])
+	}
+	return
+}()
+
+// sszProof collects the nodes of SSZ merkle trees by their hash, as the sha256 pre-images of their two child nodes.
+type sszProof map[common.Hash][]byte
+
+// sszType is an SSZ type, of which the hash tree root of encoded values is computed,
+// to prove parts of the values against the root.
+type sszType interface {
+	// fixedSize returns the encoded size of a fixed-size type, or 0 for a variable-size type.
+	fixedSize() int
+	// hashTreeRoot merkleizes the encoded value. The nodes on the paths to the given generalized indices,
+	// relative to the root of the value, are added to the proof.
+	hashTreeRoot(data []byte, gindices []uint64, proof sszProof) (common.Hash, error)
+}
+
+// sszUint is an unsigned integer, or a boolean, of the given number of bytes.
+type sszUint int
+
+func (t sszUint) fixedSize() int {
+	return int(t)
+}
+
+func (t sszUint) hashTreeRoot(data []byte, _ []uint64, _ sszProof) (common.Hash, error) {
+	if len(data) != int(t) {
+		return common.Hash{}, fmt.Errorf("%w: expected uint of %d bytes, got %d", errInvalidSSZ, t, len(data))
+	}
+	var chunk common.Hash
+	copy(chunk[:], data)
+	return chunk, nil
+}
+
+// sszBytes is a fixed-size byte vector, or a bitvector of the given number of bytes.
+type sszBytes int
+
+func (t sszBytes) fixedSize() int {
+	return int(t)
+}
+
+func (t sszBytes) hashTreeRoot(data []byte, gindices []uint64, proof sszProof) (common.Hash, error) {
+	if len(data) != int(t) {
+		return common.Hash{}, fmt.Errorf("%w: expected %d bytes, got %d", errInvalidSSZ, t, len(data))
+	}
+	return merkleize(pack(data), treeDepth((int(t)+31)/32), gindices, proof), nil
+}
+
+// sszVector is a vector of fixed-size elements.
+type sszVector struct {
+	elem   sszType
+	length int
+}
+
+func (t sszVector) fixedSize() int {
+	return t.elem.fixedSize() * t.length
+}
+
+func (t sszVector) hashTreeRoot(data []byte, gindices []uint64, proof sszProof) (common.Hash, error) {
+	if len(data) != t.fixedSize() {
+		return common.Hash{}, fmt.Errorf("%w: expected vector of %d bytes, got %d", errInvalidSSZ, t.fixedSize(), len(data))
+	}
+	return elementsRoot(t.elem, data, t.length, gindices, proof)
+}
+
+// sszList is a list of fixed-size elements, of up to limit elements.
+type sszList struct {
+	elem  sszType
+	limit int
+}
+
+func (t sszList) fixedSize() int {
+	return 0
+}
+
+func (t sszList) hashTreeRoot(data []byte, gindices []uint64, proof sszProof) (common.Hash, error) {
+	size := t.elem.fixedSize()
+	if len(data)%size != 0 || len(data)/size > t.limit {
+		return common.Hash{}, fmt.Errorf("%w: %d bytes is not a list of up to %d elements of %d bytes", errInvalidSSZ, len(data), t.limit, size)
+	}
+	// The root of a list mixes in its length: the data is at generalized index 2, the length at 3.
+	var dataGIndices []uint64
+	for _, g := range gindices {
+		depth := bits.Len64(g) - 1
+		if depth >= 2 && g>>(depth-1)&1 == 0 {
+			dataGIndices = append(dataGIndices, 1<<(depth-1)|g&(1<<(depth-1)-1))
+		}
+	}
+	dataRoot, err := elementsRoot(t.elem, data, t.limit, dataGIndices, proof)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var length common.Hash
+	binary.LittleEndian.PutUint64(length[:], uint64(len(data)/size))
+	root := hashPair(dataRoot, length)
+	if proof != nil && len(gindices) > 0 {
+		proof[root] = append(dataRoot.Bytes(), length.Bytes()...)
+	}
+	return root, nil
+}
+
+// sszContainer is a container of the given field types.
+type sszContainer []sszType
+
+func (t sszContainer) fixedSize() int {
+	size := 0
+	for _, field := range t {
+		if field.fixedSize() == 0 {
+			return 0
+		}
+		size += field.fixedSize()
+	}
+	return size
+}
+
+// fields splits the encoded container into the encoded fields.
+func (t sszContainer) fields(data []byte) ([][]byte, error) {
+	fields := make([][]byte, len(t))
+	var offsets []int
+	var variable []int
+	pos := 0
+	for i, field := range t {
+		size := field.fixedSize()
+		if size == 0 {
+			// variable-size fields are encoded after the fixed-size part, which has their offsets
+			size = 4
+		}
+		if pos+size > len(data) {
+			return nil, fmt.Errorf("%w: container of %d bytes is too short", errInvalidSSZ, len(data))
+		}
+		if field.fixedSize() == 0 {
+			offsets = append(offsets, int(binary.LittleEndian.Uint32(data[pos:])))
+			variable = append(variable, i)
+		} else {
+			fields[i] = data[pos : pos+size]
+		}
+		pos += size
+	}
+	if len(offsets) == 0 && pos != len(data) {
+		return nil, fmt.Errorf("%w: expected container of %d bytes, got %d", errInvalidSSZ, pos, len(data))
+	}
+	if len(offsets) > 0 && offsets[0] != pos {
+		return nil, fmt.Errorf("%w: expected first offset %d, got %d", errInvalidSSZ, pos, offsets[0])
+	}
+	for i, field := range variable {
+		end := len(data)
+		if i+1 < len(offsets) {
+			end = offsets[i+1]
+		}
+		if offsets[i] > end || end > len(data) {
+			return nil, fmt.Errorf("%w: invalid offset %d of field %d", errInvalidSSZ, offsets[i], field)
+		}
+		fields[field] = data[offsets[i]:end]
+	}
+	return fields, nil
+}
+
+func (t sszContainer) hashTreeRoot(data []byte, gindices []uint64, proof sszProof) (common.Hash, error) {
+	fields, err := t.fields(data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	depth := treeDepth(len(t))
+	children := childGIndices(gindices, depth)
+	roots := make([]common.Hash, len(t))
+	for i, field := range t {
+		if roots[i], err = field.hashTreeRoot(fields[i], children[i], proof); err != nil {
+			return common.Hash{}, fmt.Errorf("field %d: %w", i, err)
+		}
+	}
+	return merkleize(roots, depth, gindices, proof), nil
+}
+
+// elementsRoot merkleizes the encoded elements of a vector or list, of up to limit elements.
+// Basic elements are packed into chunks, the roots of composite elements are the chunks.
+func elementsRoot(elem sszType, data []byte, limit int, gindices []uint64, proof sszProof) (common.Hash, error) {
+	if _, ok := elem.(sszUint); ok {
+		return merkleize(pack(data), treeDepth((limit*elem.fixedSize()+31)/32), gindices, proof), nil
+	}
+	depth := treeDepth(limit)
+	children := childGIndices(gindices, depth)
+	size := elem.fixedSize()
+	roots := make([]common.Hash, len(data)/size)
+	for i := range roots {
+		var err error
+		if roots[i], err = elem.hashTreeRoot(data[i*size:(i+1)*size], children[i], proof); err != nil {
+			return common.Hash{}, fmt.Errorf("element %d: %w", i, err)
+		}
+	}
+	return merkleize(roots, depth, gindices, proof), nil
+}
+
+// childGIndices maps the generalized indices below the leaves of a tree of the given depth
+// to the leaves, with the generalized indices relative to the leaf.
+func childGIndices(gindices []uint64, depth int) map[int][]uint64 {
+	children := make(map[int][]uint64)
+	for _, g := range gindices {
+		below := bits.Len64(g) - 1 - depth
+		if below <= 0 {
+			continue
+		}
+		leaf := int(g>>below) - 1<<depth
+		children[leaf] = append(children[leaf], 1<<below|g&(1<<below-1))
+	}
+	return children
+}
+
+// merkleize computes the root of the tree of the given depth with the given chunks as leaves, padded with zero chunks.
+// The nodes on the paths to the given generalized indices are added to the proof.
+func merkleize(chunks []common.Hash, depth int, gindices []uint64, proof sszProof) common.Hash {
+	if len(chunks) == 0 {
+		return zeroHashes[depth]
+	}
+	layer := chunks
+	for height := 0; height < depth; height++ {
+		next := make([]common.Hash, (len(layer)+1)/2)
+		for i := range next {
+			next[i] = hashPair(layer[2*i], sibling(layer, 2*i+1, height))
+		}
+		// add the nodes of the next layer that are on the paths to the generalized indices
+		parentDepth := depth - height - 1
+		for _, g := range gindices {
+			gdepth := bits.Len64(g) - 1
+			if parentDepth >= gdepth || proof == nil {
+				continue
+			}
+			i := int(g>>(gdepth-parentDepth)) - 1<<parentDepth
+			if i < len(next) {
+				proof[next[i]] = append(layer[2*i].Bytes(), sibling(layer, 2*i+1, height).Bytes()...)
+			}
+		}
+		layer = next
+	}
+	return layer[0]
+}
+
+// sibling returns the node at the index of the layer of the given height, or the zero node past the end of the layer.
+func sibling(layer []common.Hash, i int, height int) common.Hash {
+	if i < len(layer) {
+		return layer[i]
+	}
+	return zeroHashes[height]
+}
+
+// pack packs the encoded basic values into chunks.
+func pack(data []byte) []common.Hash {
+	chunks := make([]common.Hash, (len(data)+31)/32)
+	for i := range chunks {
+		copy(chunks[i][:], data[i*32:])
+	}
+	return chunks
+}
+
+// treeDepth returns the depth of a tree with the given number of leaves.
+func treeDepth(leaves int) int {
+	if leaves <= 1 {
+		return 0
+	}
+	return bits.Len64(uint64(leaves - 1))
+}
+
+func hashPair(a, b common.Hash) common.Hash {
+	return sha256.Sum256(append(a[:], b[:]...))
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -15,6 +16,11 @@ type RollupClientInterface interface {
 	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
 	RollupConfig(ctx context.Context) (*rollup.Config, error)
 	GetElectionWinners(ctx context.Context, epoch uint64) ([]eth.ElectionWinner, error)
+	GetElectionWinner(ctx context.Context, timestamp uint64) (eth.ElectionWinner, error)
+	GetEpochSchedule(ctx context.Context) (*eth.EpochSchedule, error)
+	GetFallbackList(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error)
+	GetTicketCounts(ctx context.Context) (*eth.ElectionTickets, error)
+	SubscribeElections(ctx context.Context, ch chan<- *eth.EpochElection) (ethereum.Subscription, error)
 	StartSequencer(ctx context.Context, unsafeHead common.Hash) error
 	SequencerActive(ctx context.Context) (bool, error)
 	Close()
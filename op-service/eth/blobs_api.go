@@ -39,6 +39,15 @@ type BeaconBlockHeader struct {
 	BodyRoot      Bytes32      `json:"body_root"`
 }
 
+type APIBeaconBlockHeaderResponse struct {
+	Data BeaconBlockHeaderData `json:"data"`
+}
+
+type BeaconBlockHeaderData struct {
+	Root   Bytes32                 `json:"root"`
+	Header SignedBeaconBlockHeader `json:"header"`
+}
+
 type APIGetBlobSidecarsResponse struct {
 	Data []*APIBlobSidecar `json:"data"`
 }
@@ -53,6 +62,7 @@ type APIGenesisResponse struct {
 
 type ReducedConfigData struct {
 	SecondsPerSlot Uint64String `json:"SECONDS_PER_SLOT"`
+	SlotsPerEpoch  Uint64String `json:"SLOTS_PER_EPOCH"`
 }
 
 type APIConfigResponse struct {
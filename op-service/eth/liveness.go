@@ -0,0 +1,41 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// SlotLiveness records whether the election winner of a slot submitted a valid batch in the L1 block of the slot.
+type SlotLiveness struct {
+	Winner    ElectionWinner `json:"winner"`
+	L1Block   BlockID        `json:"l1Block"`
+	Submitted bool           `json:"submitted"`
+	// BatchTx is the first valid batch transaction of the winner in the L1 block, if any.
+	BatchTx common.Hash `json:"batchTx"`
+}
+
+// SequencerLiveness is the liveness record of an operator, over the slots that the node tracked.
+type SequencerLiveness struct {
+	Operator common.Address `json:"operator"`
+	// FromL1 and ToL1 are the first and last L1 block that the record covers.
+	FromL1 BlockID `json:"fromL1"`
+	ToL1   BlockID `json:"toL1"`
+	// Won is the number of tracked slots that the operator won.
+	Won uint64 `json:"won"`
+	// Missed is the number of won slots without a valid batch of the operator.
+	Missed      uint64         `json:"missed"`
+	MissedSlots []SlotLiveness `json:"missedSlots"`
+}
+
+// MissedSlotProof is the evidence of a missed slot, for a penalty contract to verify:
+// the header of the L1 block of the slot, its receipts, none of which contains a valid batch of the winner,
+// and the election that the winner won the slot in.
+type MissedSlotProof struct {
+	Slot SlotLiveness `json:"slot"`
+	// Header is the RLP encoded L1 block header.
+	Header       hexutil.Bytes `json:"header"`
+	ReceiptsRoot common.Hash   `json:"receiptsRoot"`
+	// Receipts are the consensus encoded receipts of the L1 block, which hash to the receipts root.
+	Receipts []hexutil.Bytes `json:"receipts"`
+	Election *EpochElection  `json:"election"`
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
 type Validator struct {
@@ -15,12 +16,51 @@ type Validator struct {
 type ElectionWinner struct {
 	Address common.Address
 	Time    uint64
+	// Permissionless is set for slots without a winner, for which anyone may submit batches.
+	// The first valid batch submitted for the slot wins it.
+	Permissionless bool `json:",omitempty"`
 }
 
 func (e ElectionWinner) String() string {
+	if e.Permissionless {
+		return fmt.Sprintf("permissionless:%d", e.Time)
+	}
 	return fmt.Sprintf("%s:%d", e.Address.String(), e.Time)
 }
 
+// EpochElection is the outcome of the election of an epoch,
+// along with the L1 and L2 blocks that the election inputs were read from.
+type EpochElection struct {
+	Epoch   uint64
+	L1Block BlockID
+	L2Block BlockID
+	Winners []*ElectionWinner
+	// Tickets is the ticket count of each operator of the lookahead at the L2 block, before the election burned any.
+	// It is only known for elections computed by the node, elections read from the election DB do not include it.
+	Tickets map[common.Address]uint64 `json:",omitempty"`
+}
+
+// EpochSchedule holds the elections of the current epoch and the next epoch.
+// The election of the next epoch is computed at the last slot of the current epoch, until then it is nil.
+type EpochSchedule struct {
+	CurrentEpoch uint64
+	Current      *EpochElection
+	Next         *EpochElection
+}
+
+// ElectionFallbackList is the fallback list of the system config at an L1 block.
+type ElectionFallbackList struct {
+	L1Block      BlockID
+	Instructions []hexutil.Uint64
+}
+
+// ElectionTickets is the ticket count of each operator of the lookahead that an election was computed with.
+type ElectionTickets struct {
+	Epoch   uint64
+	L2Block BlockID
+	Tickets map[common.Address]uint64
+}
+
 type APIGetLookaheadResponse struct {
 	Data []*Validator `json:"data"`
 }
@@ -0,0 +1,62 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const preconfirmationSize = 8 + 8 + 32
+
+// Preconfirmation is a commitment by the elected sequencer of a slot,
+// to include the transaction in the L2 block with the given number and timestamp.
+type Preconfirmation struct {
+	Time        uint64      `json:"time"`
+	BlockNumber uint64      `json:"blockNumber"`
+	TxHash      common.Hash `json:"txHash"`
+}
+
+func (p *Preconfirmation) MarshalBinary() ([]byte, error) {
+	out := make([]byte, preconfirmationSize)
+	binary.BigEndian.PutUint64(out[0:8], p.Time)
+	binary.BigEndian.PutUint64(out[8:16], p.BlockNumber)
+	copy(out[16:48], p.TxHash[:])
+	return out, nil
+}
+
+func (p *Preconfirmation) UnmarshalBinary(data []byte) error {
+	if len(data) != preconfirmationSize {
+		return fmt.Errorf("invalid preconfirmation length: expected %d bytes, got %d", preconfirmationSize, len(data))
+	}
+	p.Time = binary.BigEndian.Uint64(data[0:8])
+	p.BlockNumber = binary.BigEndian.Uint64(data[8:16])
+	copy(p.TxHash[:], data[16:48])
+	return nil
+}
+
+func (p *Preconfirmation) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.TxHash, p.BlockNumber, p.Time)
+}
+
+// PayloadPreconfirmations returns a preconfirmation for every user transaction of the payload.
+// Deposit transactions are derived from L1, and are not committed to by the sequencer.
+func PayloadPreconfirmations(payload *ExecutionPayload) ([]*Preconfirmation, error) {
+	var preconfs []*Preconfirmation
+	for i, data := range payload.Transactions {
+		if len(data) > 0 && data[0] == types.DepositTxType {
+			continue
+		}
+		var tx types.Transaction
+		if err := tx.UnmarshalBinary(data); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction %d: %w", i, err)
+		}
+		preconfs = append(preconfs, &Preconfirmation{
+			Time:        uint64(payload.Timestamp),
+			BlockNumber: uint64(payload.BlockNumber),
+			TxHash:      tx.Hash(),
+		})
+	}
+	return preconfs, nil
+}
@@ -0,0 +1,39 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreconfirmationRoundTrip(t *testing.T) {
+	p := &Preconfirmation{Time: 1000, BlockNumber: 42, TxHash: common.Hash{0xaa, 31: 0xbb}}
+	data, err := p.MarshalBinary()
+	require.NoError(t, err)
+	require.Len(t, data, preconfirmationSize)
+
+	var decoded Preconfirmation
+	require.NoError(t, decoded.UnmarshalBinary(data))
+	require.Equal(t, *p, decoded)
+
+	require.ErrorContains(t, decoded.UnmarshalBinary(data[:47]), "invalid preconfirmation length")
+}
+
+func TestPayloadPreconfirmations(t *testing.T) {
+	deposit, err := types.NewTx(&types.DepositTx{}).MarshalBinary()
+	require.NoError(t, err)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	txData, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	payload := &ExecutionPayload{Timestamp: 1000, BlockNumber: 42, Transactions: []Data{deposit, txData}}
+	preconfs, err := PayloadPreconfirmations(payload)
+	require.NoError(t, err)
+	require.Equal(t, []*Preconfirmation{{Time: 1000, BlockNumber: 42, TxHash: tx.Hash()}}, preconfs)
+
+	payload.Transactions = append(payload.Transactions, Data{0x02, 0x01})
+	_, err = PayloadPreconfirmations(payload)
+	require.ErrorContains(t, err, "failed to decode transaction 2")
+}
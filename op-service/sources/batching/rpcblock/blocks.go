@@ -1,6 +1,8 @@
 package rpcblock
 
 import (
+	"fmt"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -15,6 +17,25 @@ func (b Block) ArgValue() any {
 	return b.value
 }
 
+// Hash returns the hash of the referenced block, if the block is referenced by hash.
+func (b Block) Hash() (common.Hash, bool) {
+	if v, ok := b.value.(rpc.BlockNumberOrHash); ok {
+		return v.Hash()
+	}
+	return common.Hash{}, false
+}
+
+func (b Block) String() string {
+	switch v := b.value.(type) {
+	case rpc.BlockNumberOrHash:
+		return v.String()
+	case rpc.BlockNumber:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 var (
 	Pending   = Block{"pending"}
 	Latest    = Block{"latest"}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/sources/batching/rpcblock"
 	"github.com/ethereum-optimism/optimism/op-service/sources/caching"
 )
 
@@ -247,9 +248,10 @@ func (s *EthClient) ChainID(ctx context.Context) (*big.Int, error) {
 	return (*big.Int)(&id), nil
 }
 
-func (s *EthClient) Call(ctx context.Context, callMsg map[string]interface{}, blockNumber string) (string, error) {
+// Call executes the call message with eth_call at the given block. Blocks referenced by hash are passed as EIP-1898 objects.
+func (s *EthClient) Call(ctx context.Context, callMsg map[string]interface{}, block rpcblock.Block) (string, error) {
 	var result string
-	err := s.client.CallContext(ctx, &result, "eth_call", callMsg, blockNumber)
+	err := s.client.CallContext(ctx, &result, "eth_call", callMsg, block.ArgValue())
 
 	if err != nil {
 		return "", err
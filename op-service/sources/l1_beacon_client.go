@@ -13,6 +13,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 
 	"github.com/ethereum-optimism/optimism/op-service/client"
@@ -26,6 +27,7 @@ const (
 	sidecarsMethodPrefix  = "eth/v1/beacon/blob_sidecars/"
 	lookaheadMethodPrefix = "eth/v1/validator/duties/proposer/"
 	headerMethodPrefix    = "eth/v1/beacon/headers/"
+	stateMethodPrefix     = "eth/v2/debug/beacon/states/"
 )
 
 type L1BeaconClientConfig struct {
@@ -39,9 +41,10 @@ type L1BeaconClient struct {
 	pool *ClientPool[BlobSideCarsFetcher]
 	cfg  L1BeaconClientConfig
 
-	initLock     sync.Mutex
-	timeToSlotFn TimeToSlotFn
-	slotToTimeFn SlotToTimeFn
+	initLock       sync.Mutex
+	timeToSlotFn   TimeToSlotFn
+	slotToTimeFn   SlotToTimeFn
+	secondsPerSlot uint64
 }
 
 // BeaconClient is a thin wrapper over the Beacon APIs.
@@ -64,28 +67,40 @@ type BlobSideCarsFetcher interface {
 
 // BeaconHTTPClient implements BeaconClient. It provides golang types over the basic Beacon API.
 type BeaconHTTPClient struct {
-	cl client.HTTP
+	cl        client.HTTP
+	lookahead *ProposerDutiesLookahead
 }
 
 func NewBeaconHTTPClient(cl client.HTTP) *BeaconHTTPClient {
-	return &BeaconHTTPClient{cl}
+	c := &BeaconHTTPClient{cl: cl}
+	c.lookahead = NewProposerDutiesLookahead(c)
+	return c
 }
 
-func (cl *BeaconHTTPClient) apiReq(ctx context.Context, dest any, reqPath string, reqQuery url.Values) error {
+// get requests the given path, accepting the given content type, and returns the response if the request succeeded.
+func (cl *BeaconHTTPClient) get(ctx context.Context, reqPath string, reqQuery url.Values, accept string) (*http.Response, error) {
 	headers := http.Header{}
-	headers.Add("Accept", "application/json")
+	headers.Add("Accept", accept)
 	resp, err := cl.cl.Get(ctx, reqPath, reqQuery, headers)
 	if err != nil {
-		return fmt.Errorf("http Get failed: %w", err)
+		return nil, fmt.Errorf("http Get failed: %w", err)
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		errMsg, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
-		return fmt.Errorf("failed request with status %d: %s: %w", resp.StatusCode, string(errMsg), ethereum.NotFound)
+		return nil, fmt.Errorf("failed request with status %d: %s: %w", resp.StatusCode, string(errMsg), ethereum.NotFound)
 	} else if resp.StatusCode != http.StatusOK {
 		errMsg, _ := io.ReadAll(resp.Body)
 		_ = resp.Body.Close()
-		return fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(errMsg))
+		return nil, fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(errMsg))
+	}
+	return resp, nil
+}
+
+func (cl *BeaconHTTPClient) apiReq(ctx context.Context, dest any, reqPath string, reqQuery url.Values) error {
+	resp, err := cl.get(ctx, reqPath, reqQuery, "application/json")
+	if err != nil {
+		return err
 	}
 	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
 		_ = resp.Body.Close()
@@ -154,17 +169,39 @@ func (cl *BeaconHTTPClient) BeaconBlobSideCars(ctx context.Context, fetchAllSide
 	return resp, nil
 }
 
-func (cl *BeaconHTTPClient) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
-	reqPath := path.Join(lookaheadMethodPrefix, strconv.FormatUint(epoch, 10))
-
-	var reqQuery url.Values
-	var resp eth.APIGetLookaheadResponse
+// BeaconBlockHeader returns the header of the beacon block with the given root.
+func (cl *BeaconHTTPClient) BeaconBlockHeader(ctx context.Context, root common.Hash) (eth.BeaconBlockHeader, error) {
+	var resp eth.APIBeaconBlockHeaderResponse
+	if err := cl.apiReq(ctx, &resp, headerMethodPrefix+root.Hex(), nil); err != nil {
+		return eth.BeaconBlockHeader{}, err
+	}
+	if resp.Data.Root != eth.Bytes32(root) {
+		return eth.BeaconBlockHeader{}, fmt.Errorf("requested header of beacon block %s, got %s", root, resp.Data.Root)
+	}
+	return resp.Data.Header.Message, nil
+}
 
-	if err := cl.apiReq(ctx, &resp, reqPath, reqQuery); err != nil {
-		return eth.APIGetLookaheadResponse{}, err
+// BeaconStateSSZ returns the SSZ encoded beacon state with the given state root.
+// Beacon nodes only keep recent states, older states have to be served by an archive node.
+func (cl *BeaconHTTPClient) BeaconStateSSZ(ctx context.Context, stateRoot common.Hash) ([]byte, error) {
+	resp, err := cl.get(ctx, stateMethodPrefix+stateRoot.Hex(), nil, "application/octet-stream")
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("failed to read beacon state: %w", err)
+	}
+	if err := resp.Body.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close response body: %w", err)
 	}
+	return data, nil
+}
 
-	return resp, nil
+// GetLookahead returns the proposer duties of the epoch. See ProposerDutiesLookahead.
+func (cl *BeaconHTTPClient) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	return cl.lookahead.GetLookahead(ctx, epoch)
 }
 
 type ClientPool[T any] struct {
@@ -305,8 +342,13 @@ func (cl *L1BeaconClient) GetEpochNumber(ctx context.Context, timestamp uint64)
 }
 
 func (cl *L1BeaconClient) GetSlotNumber(ctx context.Context, timestamp uint64) (uint64, error) {
-	slot, err := cl.timeToSlotFn(timestamp)
+	var err error
+	cl.timeToSlotFn, err = cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return 0, err
+	}
 
+	slot, err := cl.timeToSlotFn(timestamp)
 	if err != nil {
 		return 0, err
 	}
@@ -328,6 +370,26 @@ func (cl *L1BeaconClient) GetTimeFromSlot(ctx context.Context, slot uint64) (uin
 	return time, nil
 }
 
+// GetSecondsPerSlot returns the duration of an L1 slot, as configured in the spec of the beacon node.
+func (cl *L1BeaconClient) GetSecondsPerSlot(ctx context.Context) (uint64, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.secondsPerSlot != 0 {
+		return cl.secondsPerSlot, nil
+	}
+
+	config, err := cl.cl.ConfigSpec(ctx)
+	if err != nil {
+		return 0, err
+	}
+	secondsPerSlot := uint64(config.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return 0, fmt.Errorf("got bad value for seconds per slot: %v", config.Data.SecondsPerSlot)
+	}
+	cl.secondsPerSlot = secondsPerSlot
+	return cl.secondsPerSlot, nil
+}
+
 func (cl *L1BeaconClient) fetchSidecars(ctx context.Context, slot uint64, hashes []eth.IndexedBlobHash) (eth.APIGetBlobSidecarsResponse, error) {
 	var errs []error
 	for i := 0; i < cl.pool.Len(); i++ {
@@ -16,6 +16,8 @@ import (
 	client_mocks "github.com/ethereum-optimism/optimism/op-service/client/mocks"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/sources/mocks"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/stretchr/testify/require"
 )
@@ -228,3 +230,40 @@ func TestClientPoolSeveral(t *testing.T) {
 		p.MoveToNext()
 	}
 }
+
+func TestBeaconHTTPClientBeaconState(t *testing.T) {
+	c := client_mocks.NewHTTP(t)
+	b := NewBeaconHTTPClient(c)
+	ctx := context.Background()
+
+	root := common.Hash{0xaa}
+	header := eth.BeaconBlockHeader{Slot: 7, ProposerIndex: 3, StateRoot: eth.Bytes32{0xbb}}
+	respBytes, err := json.Marshal(eth.APIBeaconBlockHeaderResponse{Data: eth.BeaconBlockHeaderData{
+		Root:   eth.Bytes32(root),
+		Header: eth.SignedBeaconBlockHeader{Message: header},
+	}})
+	require.NoError(t, err)
+	headers := http.Header{}
+	headers.Add("Accept", "application/json")
+	c.EXPECT().Get(ctx, headerMethodPrefix+root.Hex(), url.Values(nil), headers).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(respBytes))}, nil)
+	result, err := b.BeaconBlockHeader(ctx, root)
+	require.NoError(t, err)
+	require.Equal(t, header, result)
+
+	// The header must be of the requested block
+	c.EXPECT().Get(ctx, headerMethodPrefix+common.Hash{0xcc}.Hex(), url.Values(nil), headers).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(respBytes))}, nil)
+	_, err = b.BeaconBlockHeader(ctx, common.Hash{0xcc})
+	require.ErrorContains(t, err, "requested header of beacon block")
+
+	state := []byte{1, 2, 3, 4}
+	sszHeaders := http.Header{}
+	sszHeaders.Add("Accept", "application/octet-stream")
+	c.EXPECT().Get(ctx, stateMethodPrefix+common.Hash(header.StateRoot).Hex(), url.Values(nil), sszHeaders).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(state))}, nil)
+	data, err := b.BeaconStateSSZ(ctx, common.Hash(header.StateRoot))
+	require.NoError(t, err)
+	require.Equal(t, state, data)
+
+	c.EXPECT().Get(ctx, stateMethodPrefix+common.Hash{0xdd}.Hex(), url.Values(nil), sszHeaders).Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil)
+	_, err = b.BeaconStateSSZ(ctx, common.Hash{0xdd})
+	require.ErrorIs(t, err, ethereum.NotFound)
+}
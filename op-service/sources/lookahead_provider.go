@@ -0,0 +1,239 @@
+package sources
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var ErrLookaheadMismatch = errors.New("lookahead providers disagree")
+
+// LookaheadProvider provides the proposer schedule of an epoch of the beacon chain,
+// which the sequencer election is computed from.
+type LookaheadProvider interface {
+	GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error)
+}
+
+// ProposerDutiesLookahead computes the lookahead from the standard proposer duties endpoint of a beacon node.
+type ProposerDutiesLookahead struct {
+	cl *BeaconHTTPClient
+
+	initLock      sync.Mutex
+	slotsPerEpoch uint64
+}
+
+var _ LookaheadProvider = (*ProposerDutiesLookahead)(nil)
+
+func NewProposerDutiesLookahead(cl *BeaconHTTPClient) *ProposerDutiesLookahead {
+	return &ProposerDutiesLookahead{cl: cl}
+}
+
+func (p *ProposerDutiesLookahead) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	slotsPerEpoch, err := p.getSlotsPerEpoch(ctx)
+	if err != nil {
+		return eth.APIGetLookaheadResponse{}, err
+	}
+	reqPath := path.Join(lookaheadMethodPrefix, strconv.FormatUint(epoch, 10))
+
+	var reqQuery url.Values
+	var resp eth.APIGetLookaheadResponse
+	if err := p.cl.apiReq(ctx, &resp, reqPath, reqQuery); err != nil {
+		return eth.APIGetLookaheadResponse{}, err
+	}
+	if err := checkProposerDuties(epoch, slotsPerEpoch, resp.Data); err != nil {
+		return eth.APIGetLookaheadResponse{}, fmt.Errorf("invalid proposer duties of epoch %d: %w", epoch, err)
+	}
+	return resp, nil
+}
+
+// getSlotsPerEpoch returns the number of slots per epoch, as configured in the spec of the beacon node.
+func (p *ProposerDutiesLookahead) getSlotsPerEpoch(ctx context.Context) (uint64, error) {
+	p.initLock.Lock()
+	defer p.initLock.Unlock()
+	if p.slotsPerEpoch != 0 {
+		return p.slotsPerEpoch, nil
+	}
+
+	config, err := p.cl.ConfigSpec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get beacon config spec: %w", err)
+	}
+	if config.Data.SlotsPerEpoch == 0 {
+		return 0, fmt.Errorf("got bad value for slots per epoch: %v", config.Data.SlotsPerEpoch)
+	}
+	p.slotsPerEpoch = uint64(config.Data.SlotsPerEpoch)
+	return p.slotsPerEpoch, nil
+}
+
+// checkProposerDuties sorts the duties by slot, and checks there is exactly one proposer for every slot of the epoch.
+func checkProposerDuties(epoch uint64, slotsPerEpoch uint64, duties []*eth.Validator) error {
+	if len(duties) == 0 {
+		return errors.New("no proposer duties")
+	}
+	if uint64(len(duties)) != slotsPerEpoch {
+		return fmt.Errorf("got %d proposer duties, expected %d slots per epoch", len(duties), slotsPerEpoch)
+	}
+	slices.SortFunc(duties, func(a, b *eth.Validator) int {
+		return cmp.Compare(a.Slot, b.Slot)
+	})
+	firstSlot := epoch * slotsPerEpoch
+	for i, duty := range duties {
+		if expected := firstSlot + uint64(i); uint64(duty.Slot) != expected {
+			return fmt.Errorf("expected duty for slot %d, got slot %d", expected, duty.Slot)
+		}
+	}
+	return nil
+}
+
+// StaticProposer is a proposer of a static lookahead schedule.
+type StaticProposer struct {
+	Pubkey         eth.Bytes48      `json:"pubkey"`
+	ValidatorIndex eth.Uint64String `json:"validator_index"`
+}
+
+// StaticSchedule is the file format of a static lookahead.
+// The proposers are assigned to consecutive slots, starting at slot 0.
+// Once all proposers have been assigned, the schedule repeats, so a short schedule serves a long-running devnet.
+type StaticSchedule struct {
+	SlotsPerEpoch eth.Uint64String `json:"slots_per_epoch"`
+	Proposers     []StaticProposer `json:"proposers"`
+}
+
+func (s *StaticSchedule) Check() error {
+	if s.SlotsPerEpoch == 0 {
+		return errors.New("slots per epoch must be set")
+	}
+	if len(s.Proposers) == 0 {
+		return errors.New("schedule has no proposers")
+	}
+	if uint64(len(s.Proposers))%uint64(s.SlotsPerEpoch) != 0 {
+		return fmt.Errorf("number of proposers (%d) is not a multiple of the slots per epoch (%d)", len(s.Proposers), s.SlotsPerEpoch)
+	}
+	return nil
+}
+
+// StaticLookahead serves the lookahead from a static schedule, instead of the beacon chain. For devnets only.
+type StaticLookahead struct {
+	schedule StaticSchedule
+}
+
+var _ LookaheadProvider = (*StaticLookahead)(nil)
+
+func NewStaticLookahead(schedule StaticSchedule) (*StaticLookahead, error) {
+	if err := schedule.Check(); err != nil {
+		return nil, fmt.Errorf("invalid static lookahead schedule: %w", err)
+	}
+	return &StaticLookahead{schedule: schedule}, nil
+}
+
+// NewStaticLookaheadFromFile loads a static lookahead from a JSON encoded StaticSchedule.
+func NewStaticLookaheadFromFile(file string) (*StaticLookahead, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read static lookahead file: %w", err)
+	}
+	var schedule StaticSchedule
+	if err := json.Unmarshal(data, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to decode static lookahead file: %w", err)
+	}
+	return NewStaticLookahead(schedule)
+}
+
+func (s *StaticLookahead) GetLookahead(_ context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	slotsPerEpoch := uint64(s.schedule.SlotsPerEpoch)
+	resp := eth.APIGetLookaheadResponse{Data: make([]*eth.Validator, 0, slotsPerEpoch)}
+	for slot := epoch * slotsPerEpoch; slot < (epoch+1)*slotsPerEpoch; slot++ {
+		proposer := s.schedule.Proposers[slot%uint64(len(s.schedule.Proposers))]
+		resp.Data = append(resp.Data, &eth.Validator{
+			Pubkey:         proposer.Pubkey,
+			ValidatorIndex: proposer.ValidatorIndex,
+			Slot:           eth.Uint64String(slot),
+		})
+	}
+	return resp, nil
+}
+
+// VerifyingLookahead serves the lookahead of a primary provider, after cross-checking it against a reference provider.
+// Lookaheads that differ fail with ErrLookaheadMismatch, so no election is computed from a schedule
+// that other nodes may not agree on.
+type VerifyingLookahead struct {
+	log       log.Logger
+	primary   LookaheadProvider
+	reference LookaheadProvider
+}
+
+var _ LookaheadProvider = (*VerifyingLookahead)(nil)
+
+func NewVerifyingLookahead(log log.Logger, primary LookaheadProvider, reference LookaheadProvider) *VerifyingLookahead {
+	return &VerifyingLookahead{
+		log:       log,
+		primary:   primary,
+		reference: reference,
+	}
+}
+
+func (v *VerifyingLookahead) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	resp, err := v.primary.GetLookahead(ctx, epoch)
+	if err != nil {
+		return eth.APIGetLookaheadResponse{}, err
+	}
+	ref, err := v.reference.GetLookahead(ctx, epoch)
+	if err != nil {
+		return eth.APIGetLookaheadResponse{}, fmt.Errorf("failed to get reference lookahead of epoch %d: %w", epoch, err)
+	}
+	if err := compareLookaheads(resp.Data, ref.Data); err != nil {
+		v.log.Error("Lookahead does not match the reference lookahead", "epoch", epoch, "err", err)
+		return eth.APIGetLookaheadResponse{}, fmt.Errorf("%w: epoch %d: %w", ErrLookaheadMismatch, epoch, err)
+	}
+	return resp, nil
+}
+
+// compareLookaheads checks both lookaheads assign the same proposers to the same slots.
+func compareLookaheads(a []*eth.Validator, b []*eth.Validator) error {
+	if len(a) != len(b) {
+		return fmt.Errorf("got %d slots, reference has %d slots", len(a), len(b))
+	}
+	proposers := make(map[eth.Uint64String]*eth.Validator, len(b))
+	for _, validator := range b {
+		proposers[validator.Slot] = validator
+	}
+	for _, validator := range a {
+		ref, ok := proposers[validator.Slot]
+		if !ok {
+			return fmt.Errorf("slot %d is missing in the reference", validator.Slot)
+		}
+		if ref.Pubkey != validator.Pubkey || ref.ValidatorIndex != validator.ValidatorIndex {
+			return fmt.Errorf("slot %d: got proposer %d (%s), reference has %d (%s)",
+				validator.Slot, validator.ValidatorIndex, validator.Pubkey, ref.ValidatorIndex, ref.Pubkey)
+		}
+	}
+	return nil
+}
+
+// lookaheadBeaconClient overrides the lookahead of a BeaconClient.
+type lookaheadBeaconClient struct {
+	BeaconClient
+	lookahead LookaheadProvider
+}
+
+// NewBeaconClientWithLookahead returns a BeaconClient that serves the lookahead from the given provider,
+// and all other Beacon APIs from cl.
+func NewBeaconClientWithLookahead(cl BeaconClient, lookahead LookaheadProvider) BeaconClient {
+	return &lookaheadBeaconClient{BeaconClient: cl, lookahead: lookahead}
+}
+
+func (c *lookaheadBeaconClient) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	return c.lookahead.GetLookahead(ctx, epoch)
+}
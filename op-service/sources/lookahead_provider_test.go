@@ -0,0 +1,203 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	client_mocks "github.com/ethereum-optimism/optimism/op-service/client/mocks"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+)
+
+func testDuties(epoch uint64, slotsPerEpoch uint64) []*eth.Validator {
+	var duties []*eth.Validator
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		slot := epoch*slotsPerEpoch + i
+		duties = append(duties, &eth.Validator{
+			Pubkey:         eth.Bytes48{byte(slot)},
+			ValidatorIndex: eth.Uint64String(100 + slot),
+			Slot:           eth.Uint64String(slot),
+		})
+	}
+	return duties
+}
+
+func TestProposerDutiesLookahead(t *testing.T) {
+	ctx := context.Background()
+	epoch := uint64(3)
+	reqPath := path.Join(lookaheadMethodPrefix, strconv.FormatUint(epoch, 10))
+
+	expectDuties := func(c *client_mocks.HTTP, duties []*eth.Validator) {
+		respBytes, err := json.Marshal(eth.APIGetLookaheadResponse{Data: duties})
+		require.NoError(t, err)
+		c.EXPECT().Get(ctx, reqPath, mock.Anything, mock.Anything).
+			Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(respBytes))}, nil).Once()
+	}
+	expectSpec := func(c *client_mocks.HTTP, slotsPerEpoch uint64) {
+		respBytes, err := json.Marshal(eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 12, SlotsPerEpoch: eth.Uint64String(slotsPerEpoch)}})
+		require.NoError(t, err)
+		c.EXPECT().Get(ctx, specMethod, mock.Anything, mock.Anything).
+			Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(respBytes))}, nil).Once()
+	}
+
+	t.Run("sorted by slot", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		p := NewProposerDutiesLookahead(NewBeaconHTTPClient(c))
+		expectSpec(c, 4)
+		duties := testDuties(epoch, 4)
+		expectDuties(c, []*eth.Validator{duties[2], duties[0], duties[3], duties[1]})
+
+		resp, err := p.GetLookahead(ctx, epoch)
+		require.NoError(t, err)
+		require.Equal(t, duties, resp.Data)
+	})
+
+	t.Run("missing slot", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		p := NewProposerDutiesLookahead(NewBeaconHTTPClient(c))
+		expectSpec(c, 4)
+		duties := testDuties(epoch, 4)
+		expectDuties(c, []*eth.Validator{duties[0], duties[1], duties[3], duties[3]})
+
+		_, err := p.GetLookahead(ctx, epoch)
+		require.ErrorContains(t, err, "expected duty for slot 14")
+	})
+
+	t.Run("other epoch", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		p := NewProposerDutiesLookahead(NewBeaconHTTPClient(c))
+		expectSpec(c, 4)
+		expectDuties(c, testDuties(epoch+1, 4))
+
+		_, err := p.GetLookahead(ctx, epoch)
+		require.ErrorContains(t, err, "expected duty for slot 12")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		p := NewProposerDutiesLookahead(NewBeaconHTTPClient(c))
+		expectSpec(c, 4)
+		expectDuties(c, testDuties(epoch, 4)[:2])
+
+		_, err := p.GetLookahead(ctx, epoch)
+		require.ErrorContains(t, err, "got 2 proposer duties, expected 4 slots per epoch")
+	})
+
+	t.Run("spec is read once", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		p := NewProposerDutiesLookahead(NewBeaconHTTPClient(c))
+		expectSpec(c, 4)
+		expectDuties(c, testDuties(epoch, 4))
+		expectDuties(c, testDuties(epoch, 4))
+
+		_, err := p.GetLookahead(ctx, epoch)
+		require.NoError(t, err)
+		_, err = p.GetLookahead(ctx, epoch)
+		require.NoError(t, err)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		c := client_mocks.NewHTTP(t)
+		p := NewProposerDutiesLookahead(NewBeaconHTTPClient(c))
+		expectSpec(c, 4)
+		expectDuties(c, nil)
+
+		_, err := p.GetLookahead(ctx, epoch)
+		require.ErrorContains(t, err, "no proposer duties")
+	})
+}
+
+func TestStaticLookahead(t *testing.T) {
+	schedule := StaticSchedule{
+		SlotsPerEpoch: 2,
+		Proposers: []StaticProposer{
+			{Pubkey: eth.Bytes48{0xaa}, ValidatorIndex: 1},
+			{Pubkey: eth.Bytes48{0xbb}, ValidatorIndex: 2},
+			{Pubkey: eth.Bytes48{0xcc}, ValidatorIndex: 3},
+			{Pubkey: eth.Bytes48{0xdd}, ValidatorIndex: 4},
+		},
+	}
+	data, err := json.Marshal(schedule)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "lookahead.json")
+	require.NoError(t, os.WriteFile(file, data, 0o644))
+
+	s, err := NewStaticLookaheadFromFile(file)
+	require.NoError(t, err)
+
+	resp, err := s.GetLookahead(context.Background(), 1)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Validator{
+		{Pubkey: eth.Bytes48{0xcc}, ValidatorIndex: 3, Slot: 2},
+		{Pubkey: eth.Bytes48{0xdd}, ValidatorIndex: 4, Slot: 3},
+	}, resp.Data)
+
+	// The schedule repeats after the last proposer
+	resp, err = s.GetLookahead(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, []*eth.Validator{
+		{Pubkey: eth.Bytes48{0xaa}, ValidatorIndex: 1, Slot: 8},
+		{Pubkey: eth.Bytes48{0xbb}, ValidatorIndex: 2, Slot: 9},
+	}, resp.Data)
+
+	schedule.Proposers = schedule.Proposers[:3]
+	_, err = NewStaticLookahead(schedule)
+	require.ErrorContains(t, err, "not a multiple of the slots per epoch")
+
+	_, err = NewStaticLookahead(StaticSchedule{Proposers: schedule.Proposers})
+	require.ErrorContains(t, err, "slots per epoch must be set")
+}
+
+type fixedLookahead struct {
+	data []*eth.Validator
+}
+
+func (f *fixedLookahead) GetLookahead(ctx context.Context, epoch uint64) (eth.APIGetLookaheadResponse, error) {
+	return eth.APIGetLookaheadResponse{Data: f.data}, nil
+}
+
+func TestVerifyingLookahead(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelCrit)
+	duties := testDuties(5, 4)
+	primary := &fixedLookahead{data: duties}
+
+	t.Run("match", func(t *testing.T) {
+		// The reference may order the duties differently
+		reference := &fixedLookahead{data: []*eth.Validator{duties[3], duties[2], duties[1], duties[0]}}
+		resp, err := NewVerifyingLookahead(logger, primary, reference).GetLookahead(context.Background(), 5)
+		require.NoError(t, err)
+		require.Equal(t, duties, resp.Data)
+	})
+
+	t.Run("different proposer", func(t *testing.T) {
+		other := *duties[2]
+		other.Pubkey = eth.Bytes48{0xff}
+		reference := &fixedLookahead{data: []*eth.Validator{duties[0], duties[1], &other, duties[3]}}
+		_, err := NewVerifyingLookahead(logger, primary, reference).GetLookahead(context.Background(), 5)
+		require.ErrorIs(t, err, ErrLookaheadMismatch)
+	})
+
+	t.Run("different slots", func(t *testing.T) {
+		reference := &fixedLookahead{data: testDuties(6, 4)}
+		_, err := NewVerifyingLookahead(logger, primary, reference).GetLookahead(context.Background(), 5)
+		require.ErrorIs(t, err, ErrLookaheadMismatch)
+	})
+
+	t.Run("different length", func(t *testing.T) {
+		reference := &fixedLookahead{data: duties[:3]}
+		_, err := NewVerifyingLookahead(logger, primary, reference).GetLookahead(context.Background(), 5)
+		require.ErrorIs(t, err, ErrLookaheadMismatch)
+	})
+}
@@ -2,8 +2,10 @@ package sources
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
@@ -50,6 +52,56 @@ func (r *RollupClient) GetElectionWinners(ctx context.Context, epoch uint64) ([]
 	return output, err
 }
 
+// GetElectionWinner returns the election winner of the slot that the given L2 timestamp falls in.
+func (r *RollupClient) GetElectionWinner(ctx context.Context, timestamp uint64) (eth.ElectionWinner, error) {
+	var output eth.ElectionWinner
+	err := r.rpc.CallContext(ctx, &output, "based_getElectionWinner", hexutil.Uint64(timestamp))
+	return output, err
+}
+
+// GetEpochSchedule returns the elections of the current and the next epoch.
+func (r *RollupClient) GetEpochSchedule(ctx context.Context) (*eth.EpochSchedule, error) {
+	var output *eth.EpochSchedule
+	err := r.rpc.CallContext(ctx, &output, "based_getEpochSchedule")
+	return output, err
+}
+
+// GetFallbackList returns the election fallback list of the system config at the given L1 block.
+func (r *RollupClient) GetFallbackList(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error) {
+	var output *eth.ElectionFallbackList
+	err := r.rpc.CallContext(ctx, &output, "based_getFallbackList", hexutil.Uint64(l1BlockNum))
+	return output, err
+}
+
+// GetTicketCounts returns the ticket count of each operator that the last election was computed with.
+func (r *RollupClient) GetTicketCounts(ctx context.Context) (*eth.ElectionTickets, error) {
+	var output *eth.ElectionTickets
+	err := r.rpc.CallContext(ctx, &output, "based_getTicketCounts")
+	return output, err
+}
+
+// SubscribeElections streams the elections computed by the rollup node to the channel.
+// The rollup node only serves subscriptions over websocket connections.
+func (r *RollupClient) SubscribeElections(ctx context.Context, ch chan<- *eth.EpochElection) (ethereum.Subscription, error) {
+	sub, ok := r.rpc.(client.Subscriber)
+	if !ok {
+		return nil, errors.New("rollup RPC client does not support subscriptions")
+	}
+	return sub.Subscribe(ctx, "based", ch, "elections")
+}
+
+func (r *RollupClient) GetSequencerLiveness(ctx context.Context, operator common.Address) (*eth.SequencerLiveness, error) {
+	var output *eth.SequencerLiveness
+	err := r.rpc.CallContext(ctx, &output, "optimism_getSequencerLiveness", operator)
+	return output, err
+}
+
+func (r *RollupClient) GetMissedSlotProof(ctx context.Context, l1BlockNum uint64) (*eth.MissedSlotProof, error) {
+	var output *eth.MissedSlotProof
+	err := r.rpc.CallContext(ctx, &output, "optimism_getMissedSlotProof", hexutil.Uint64(l1BlockNum))
+	return output, err
+}
+
 func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	var output string
 	err := r.rpc.CallContext(ctx, &output, "optimism_version")
@@ -79,6 +79,34 @@ func (cl *SupervisorClient) CheckBlock(ctx context.Context,
 	return result, nil
 }
 
+func (cl *SupervisorClient) ExecutingMessages(ctx context.Context,
+	chainID types.ChainID, blockHash common.Hash, blockNumber uint64) ([]types.ExecutingMessageLineage, error) {
+	var result []types.ExecutingMessageLineage
+	err := cl.client.CallContext(
+		ctx,
+		&result,
+		"supervisor_executingMessages",
+		(*hexutil.U256)(&chainID), blockHash, hexutil.Uint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get executing messages of Block %s:%d (chain %s): %w", blockHash, blockNumber, chainID, err)
+	}
+	return result, nil
+}
+
+func (cl *SupervisorClient) BlockDependency(ctx context.Context,
+	chainID types.ChainID, blockHash common.Hash, blockNumber uint64) (types.BlockDependency, error) {
+	var result types.BlockDependency
+	err := cl.client.CallContext(
+		ctx,
+		&result,
+		"supervisor_blockDependency",
+		(*hexutil.U256)(&chainID), blockHash, hexutil.Uint64(blockNumber))
+	if err != nil {
+		return types.BlockDependency{}, fmt.Errorf("failed to get dependency of Block %s:%d (chain %s): %w", blockHash, blockNumber, chainID, err)
+	}
+	return result, nil
+}
+
 func (cl *SupervisorClient) Close() {
 	cl.client.Close()
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 
@@ -142,6 +143,15 @@ func (m *MockEthClient) ExpectBlockByNumber(number *big.Int, block *types.Block,
 	m.Mock.On("BlockByNumber", number).Once().Return(block, err)
 }
 
+func (m *MockEthClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	out := m.Mock.Called(msg, blockNumber)
+	return out.Get(0).([]byte), out.Error(1)
+}
+
+func (m *MockEthClient) ExpectCallContract(msg ethereum.CallMsg, blockNumber *big.Int, result []byte, err error) {
+	m.Mock.On("CallContract", msg, blockNumber).Once().Return(result, err)
+}
+
 func (m *MockEthClient) ExpectClose() {
 	m.Mock.On("Close").Once()
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/mock"
 )
@@ -40,6 +41,47 @@ func (m *MockRollupClient) ExpectGetElectionWinners(electionWinners eth.Election
 	m.Mock.On("ElectionWinners").Once().Return(electionWinners, err)
 }
 
+func (m *MockRollupClient) GetElectionWinner(ctx context.Context, timestamp uint64) (eth.ElectionWinner, error) {
+	out := m.Mock.Called(timestamp)
+	return out.Get(0).(eth.ElectionWinner), out.Error(1)
+}
+
+func (m *MockRollupClient) ExpectGetElectionWinner(timestamp uint64, winner eth.ElectionWinner, err error) {
+	m.Mock.On("GetElectionWinner", timestamp).Once().Return(winner, err)
+}
+
+func (m *MockRollupClient) GetEpochSchedule(ctx context.Context) (*eth.EpochSchedule, error) {
+	out := m.Mock.Called()
+	return out.Get(0).(*eth.EpochSchedule), out.Error(1)
+}
+
+func (m *MockRollupClient) ExpectGetEpochSchedule(schedule *eth.EpochSchedule, err error) {
+	m.Mock.On("GetEpochSchedule").Once().Return(schedule, err)
+}
+
+func (m *MockRollupClient) GetFallbackList(ctx context.Context, l1BlockNum uint64) (*eth.ElectionFallbackList, error) {
+	out := m.Mock.Called(l1BlockNum)
+	return out.Get(0).(*eth.ElectionFallbackList), out.Error(1)
+}
+
+func (m *MockRollupClient) ExpectGetFallbackList(l1BlockNum uint64, list *eth.ElectionFallbackList, err error) {
+	m.Mock.On("GetFallbackList", l1BlockNum).Once().Return(list, err)
+}
+
+func (m *MockRollupClient) GetTicketCounts(ctx context.Context) (*eth.ElectionTickets, error) {
+	out := m.Mock.Called()
+	return out.Get(0).(*eth.ElectionTickets), out.Error(1)
+}
+
+func (m *MockRollupClient) ExpectGetTicketCounts(tickets *eth.ElectionTickets, err error) {
+	m.Mock.On("GetTicketCounts").Once().Return(tickets, err)
+}
+
+func (m *MockRollupClient) SubscribeElections(ctx context.Context, ch chan<- *eth.EpochElection) (ethereum.Subscription, error) {
+	out := m.Mock.Called(ch)
+	return out.Get(0).(ethereum.Subscription), out.Error(1)
+}
+
 func (m *MockRollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	out := m.Mock.Called()
 	return out.Get(0).(*rollup.Config), out.Error(1)
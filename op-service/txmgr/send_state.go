@@ -14,6 +14,10 @@ var (
 	// Returned by CriticalError when the system is unable to get the tx into the mempool in the
 	// allotted time
 	ErrMempoolDeadlineExpired = errors.New("failed to get tx into the mempool")
+
+	// Returned by a backend when the tx can never be included anymore, e.g. a batch submission
+	// whose target L1 block has passed. The tx is aborted, to release its nonce.
+	ErrTxExpired = errors.New("tx can no longer be included")
 )
 
 // SendState tracks information about the publication state of a given txn. In
@@ -37,6 +41,9 @@ type SendState struct {
 	// Whether any attempt to send the tx resulted in ErrAlreadyReserved
 	alreadyReserved bool
 
+	// Whether any attempt to send the tx resulted in ErrTxExpired
+	expired bool
+
 	// Whether we should bump fees before trying to publish the tx again
 	bumpFees bool
 
@@ -77,6 +84,8 @@ func (s *SendState) ProcessSendError(err error) {
 		s.nonceTooLowCount++
 	case errStringMatch(err, txpool.ErrAlreadyReserved):
 		s.alreadyReserved = true
+	case errors.Is(err, ErrTxExpired):
+		s.expired = true
 	}
 }
 
@@ -133,6 +142,9 @@ func (s *SendState) CriticalError() error {
 	case s.alreadyReserved:
 		// incompatible tx type in mempool
 		return txpool.ErrAlreadyReserved
+	case s.expired:
+		// the tx can not be included anymore, give up on its nonce
+		return ErrTxExpired
 	}
 	return nil
 }
@@ -2,6 +2,7 @@ package txmgr
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -200,3 +201,15 @@ func TestSendStateNoTimeoutAbortIfPublishedTx(t *testing.T) {
 	sendState.ProcessSendError(nil)
 	require.Nil(t, sendState.CriticalError(), "Should not abort if published transaction successfully")
 }
+
+// TestSendStateAbortAfterTxExpired asserts that we abort once the backend reports
+// that the tx can no longer be included, unless a tx was mined.
+func TestSendStateAbortAfterTxExpired(t *testing.T) {
+	sendState := newSendState()
+	sendState.ProcessSendError(nil)
+	sendState.ProcessSendError(fmt.Errorf("wrapped: %w", ErrTxExpired))
+	require.ErrorIs(t, sendState.CriticalError(), ErrTxExpired)
+
+	sendState.TxMined(testHash)
+	require.Nil(t, sendState.CriticalError())
+}
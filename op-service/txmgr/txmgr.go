@@ -48,6 +48,9 @@ var (
 
 	ErrBlobFeeLimit = errors.New("blob fee limit reached")
 	ErrClosed       = errors.New("transaction manager is closed")
+	// ErrGasEstimation is returned when the gas estimation of a candidate fails,
+	// i.e. the simulation of the transaction reverted or could not be run.
+	ErrGasEstimation = errors.New("failed to estimate gas")
 )
 
 type SendResponse struct {
@@ -361,7 +364,7 @@ func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*
 			Value:     candidate.Value,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", errutil.TryAddRevertReason(err))
+			return nil, fmt.Errorf("%w: %w", ErrGasEstimation, errutil.TryAddRevertReason(err))
 		}
 		gasLimit = gas
 	}
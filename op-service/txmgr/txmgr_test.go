@@ -672,6 +672,7 @@ func TestTxMgr_EstimateGasFails(t *testing.T) {
 	h.gasPricer.err = errors.New("execution error")
 	_, err = h.mgr.craftTx(context.Background(), candidate)
 	require.ErrorContains(t, err, "failed to estimate gas")
+	require.ErrorIs(t, err, ErrGasEstimation)
 
 	// Ensure successful craft uses the correct nonce
 	h.gasPricer.err = nil
@@ -48,6 +48,7 @@ func run(ctx context.Context, args []string, fn supervisor.MainFn) error {
 			Name:        "doc",
 			Subcommands: doc.NewSubcommands(metrics.NewMetrics("default")),
 		},
+		SnapshotCommand,
 	}
 	return app.RunContext(ctx, args)
 }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/ctxinterrupt"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-supervisor/flags"
+	"github.com/ethereum-optimism/optimism/op-supervisor/metrics"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend"
+)
+
+var (
+	SnapshotDirFlag = &cli.PathFlag{
+		Name:     "snapshot-dir",
+		Usage:    "Directory the snapshot is exported to or imported from",
+		EnvVars:  opservice.PrefixEnvVar(flags.EnvVarPrefix, "SNAPSHOT_DIR"),
+		Required: true,
+	}
+)
+
+func ExportSnapshot(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	datadir := ctx.Path(flags.DataDirFlag.Name)
+	if datadir == "" {
+		return fmt.Errorf("missing %v", flags.DataDirFlag.Name)
+	}
+	_, err := backend.ExportSnapshot(logger, metrics.NoopMetrics, datadir, ctx.Path(SnapshotDirFlag.Name))
+	return err
+}
+
+func ImportSnapshot(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.AppOut(ctx), oplog.ReadCLIConfig(ctx))
+	datadir := ctx.Path(flags.DataDirFlag.Name)
+	if datadir == "" {
+		return fmt.Errorf("missing %v", flags.DataDirFlag.Name)
+	}
+	l2RPCs := ctx.StringSlice(flags.L2RPCsFlag.Name)
+	if len(l2RPCs) == 0 {
+		return fmt.Errorf("missing %v", flags.L2RPCsFlag.Name)
+	}
+	rCtx := ctxinterrupt.WithCancelOnInterrupt(ctx.Context)
+	return backend.ImportSnapshot(rCtx, logger, metrics.NoopMetrics, ctx.Path(SnapshotDirFlag.Name), datadir, l2RPCs)
+}
+
+func snapshotFlags(cliFlags ...cli.Flag) []cli.Flag {
+	cliFlags = append(cliFlags, SnapshotDirFlag, flags.DataDirFlag)
+	cliFlags = append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+	return cliFlags
+}
+
+var SnapshotCommand = &cli.Command{
+	Name:  "snapshot",
+	Usage: "Export and import snapshots of the supervisor data directory",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "export",
+			Usage: "Export a snapshot of the data directory at the cross-finalized blocks",
+			Description: "Exports the log databases and heads of all chains in the data directory, " +
+				"cut at the latest cross-finalized block of each chain, along with a manifest of the file hashes.",
+			Action: ExportSnapshot,
+			Flags:  snapshotFlags(),
+		},
+		{
+			Name:  "import",
+			Usage: "Import a snapshot into an empty data directory",
+			Description: "Copies the snapshot into the data directory, and verifies the files against the manifest " +
+				"and the hash of every block of the snapshot against the L2 RPCs. The logs of the blocks are trusted to the source " +
+				"of the snapshot, they are not checked against the receipts. The supervisor resumes indexing from the snapshot blocks.",
+			Action: ImportSnapshot,
+			Flags:  snapshotFlags(flags.L2RPCsFlag),
+		},
+	},
+}
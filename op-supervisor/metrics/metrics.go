@@ -21,6 +21,8 @@ type Metricer interface {
 	RecordDBEntryCount(chainID types.ChainID, count int64)
 	RecordDBSearchEntriesRead(chainID types.ChainID, count int64)
 
+	RecordReorg(chainID types.ChainID, depth uint64)
+
 	Document() []opmetrics.DocumentedMetric
 }
 
@@ -38,6 +40,9 @@ type Metrics struct {
 	DBEntryCountVec        *prometheus.GaugeVec
 	DBSearchEntriesReadVec *prometheus.HistogramVec
 
+	ReorgsVec     *prometheus.CounterVec
+	ReorgDepthVec *prometheus.HistogramVec
+
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
 }
@@ -118,6 +123,22 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"chain",
 		}),
+
+		ReorgsVec: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "reorgs_total",
+			Help:      "Number of reorgs detected by chain ID",
+		}, []string{
+			"chain",
+		}),
+		ReorgDepthVec: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "reorg_depth",
+			Help:      "Number of blocks rewound per reorg by chain ID",
+			Buckets:   []float64{1, 2, 5, 10, 20, 50, 100, 500},
+		}, []string{
+			"chain",
+		}),
 	}
 }
 
@@ -167,6 +188,12 @@ func (m *Metrics) RecordDBSearchEntriesRead(chainID types.ChainID, count int64)
 	m.DBSearchEntriesReadVec.WithLabelValues(chainIDLabel(chainID)).Observe(float64(count))
 }
 
+func (m *Metrics) RecordReorg(chainID types.ChainID, depth uint64) {
+	chain := chainIDLabel(chainID)
+	m.ReorgsVec.WithLabelValues(chain).Inc()
+	m.ReorgDepthVec.WithLabelValues(chain).Observe(float64(depth))
+}
+
 func chainIDLabel(chainID types.ChainID) string {
 	return chainID.String()
 }
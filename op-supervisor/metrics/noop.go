@@ -21,3 +21,5 @@ func (m *noopMetrics) CacheGet(_ types.ChainID, _ string, _ bool)        {}
 
 func (m *noopMetrics) RecordDBEntryCount(_ types.ChainID, _ int64)        {}
 func (m *noopMetrics) RecordDBSearchEntriesRead(_ types.ChainID, _ int64) {}
+
+func (m *noopMetrics) RecordReorg(_ types.ChainID, _ uint64) {}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"path/filepath"
 	"sync/atomic"
 	"time"
 
@@ -13,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/dial"
 	"github.com/ethereum-optimism/optimism/op-supervisor/config"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/source"
@@ -47,7 +47,7 @@ func NewSupervisorBackend(ctx context.Context, logger log.Logger, m Metrics, cfg
 	}
 
 	// create the head tracker
-	headTracker, err := heads.NewHeadTracker(filepath.Join(cfg.Datadir, "heads.json"))
+	headTracker, err := heads.NewHeadTracker(headsPath(cfg.Datadir))
 	if err != nil {
 		return nil, fmt.Errorf("failed to load existing heads: %w", err)
 	}
@@ -185,28 +185,29 @@ func (su *SupervisorBackend) AddL2RPC(ctx context.Context, rpc string) error {
 }
 
 func (su *SupervisorBackend) CheckMessage(identifier types.Identifier, payloadHash common.Hash) (types.SafetyLevel, error) {
-	chainID := identifier.ChainID
-	blockNum := identifier.BlockNumber
-	logIdx := identifier.LogIndex
-	ok, i, err := su.db.Check(chainID, blockNum, uint32(logIdx), backendTypes.TruncateHash(payloadHash))
+	logHash := backendTypes.PayloadHashToLogHash(payloadHash, identifier.Origin)
+	return su.logSafety(identifier.ChainID, identifier.BlockNumber, uint32(identifier.LogIndex), logHash)
+}
+
+// logSafety returns the safety level of the log with the given hash.
+// Logs of chains and blocks that have not been indexed yet are Unknown.
+func (su *SupervisorBackend) logSafety(chainID types.ChainID, blockNum uint64, logIdx uint32, logHash backendTypes.TruncatedHash) (types.SafetyLevel, error) {
+	_, _, err := su.db.FindSealedBlock(chainID, blockNum)
+	if errors.Is(err, logs.ErrFuture) || errors.Is(err, db.ErrUnknownChain) {
+		return types.Unknown, nil
+	}
+	// ErrNotFound is not an error here: the log is checked for against the indexed logs below
+	if err != nil && !errors.Is(err, logs.ErrNotFound) {
+		return types.Invalid, fmt.Errorf("failed to find block %v: %w", blockNum, err)
+	}
+	ok, i, err := su.db.Check(chainID, blockNum, logIdx, logHash)
 	if err != nil {
 		return types.Invalid, fmt.Errorf("failed to check log: %w", err)
 	}
 	if !ok {
 		return types.Invalid, nil
 	}
-	safest := types.CrossUnsafe
-	// at this point we have the log entry, and we can check if it is safe by various criteria
-	for _, checker := range []db.SafetyChecker{
-		db.NewSafetyChecker(types.Unsafe, su.db),
-		db.NewSafetyChecker(types.Safe, su.db),
-		db.NewSafetyChecker(types.Finalized, su.db),
-	} {
-		if i <= checker.CrossHeadForChain(chainID) {
-			safest = checker.SafetyLevel()
-		}
-	}
-	return safest, nil
+	return su.safestAt(chainID, i), nil
 }
 
 func (su *SupervisorBackend) CheckMessages(
@@ -229,28 +230,114 @@ func (su *SupervisorBackend) CheckMessages(
 
 // CheckBlock checks if the block is safe according to the safety level
 // The block is considered safe if all logs in the block are safe
-// this is decided by finding the last log in the block and
+// this is decided by finding the block hash entry that seals the block, after all its logs,
+// and comparing the block hash against it.
+// A block beyond the latest indexed block is Unknown, and a block with a different hash is Invalid.
 func (su *SupervisorBackend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.SafetyLevel, error) {
-	// TODO(#11612): this function ignores blockHash and assumes that the block in the db is the one we are looking for
-	// In order to check block hash, the database must *always* insert a block hash checkpoint, which is not currently done
-	safest := types.CrossUnsafe
-	// find the last log index in the block
-	i, err := su.db.LastLogInBlock(types.ChainID(*chainID), uint64(blockNumber))
-	// TODO(#11836) checking for EOF as a non-error case is a bit of a code smell
-	// and could potentially be incorrect if the given block number is intentionally far in the future
-	if err != nil && !errors.Is(err, io.EOF) {
+	chain := types.ChainID(*chainID)
+	sealHash, i, err := su.db.FindSealedBlock(chain, uint64(blockNumber))
+	if errors.Is(err, logs.ErrFuture) || errors.Is(err, logs.ErrNotFound) {
+		return types.Unknown, nil
+	} else if err != nil {
 		su.logger.Error("failed to scan block", "err", err)
 		return types.Invalid, fmt.Errorf("failed to scan block: %w", err)
 	}
-	// at this point we have the extent of the block, and we can check if it is safe by various criteria
+	if sealHash != backendTypes.TruncateHash(blockHash) {
+		return types.Invalid, nil
+	}
+	return su.safestAt(chain, i), nil
+}
+
+// ExecutingMessages returns the executing messages of the block, resolved to their initiating messages.
+func (su *SupervisorBackend) ExecutingMessages(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) ([]types.ExecutingMessageLineage, error) {
+	chain := types.ChainID(*chainID)
+	if err := su.checkIndexedBlock(chain, blockHash, uint64(blockNumber)); err != nil {
+		return nil, err
+	}
+	msgs, err := su.db.ExecutingMessagesInBlock(chain, uint64(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read executing messages: %w", err)
+	}
+	out := make([]types.ExecutingMessageLineage, 0, len(msgs))
+	for _, msg := range msgs {
+		lineage, err := su.lineage(msg)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, lineage)
+	}
+	return out, nil
+}
+
+// BlockDependency returns the safety of the block, and if the block has not reached cross-safe,
+// the executing message that keeps the cross-safe head of the chain from advancing to the block.
+func (su *SupervisorBackend) BlockDependency(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.BlockDependency, error) {
+	safety, err := su.CheckBlock(chainID, blockHash, blockNumber)
+	if err != nil {
+		return types.BlockDependency{}, err
+	}
+	dep := types.BlockDependency{Safety: safety}
+	if safety != types.CrossUnsafe {
+		// the block is either cross-safe already, or not known to be part of the chain
+		return dep, nil
+	}
+	msg, found, err := su.db.FirstBlockingMessage(types.ChainID(*chainID), uint64(blockNumber), db.NewSafetyChecker(types.Safe, su.db))
+	if err != nil {
+		return types.BlockDependency{}, fmt.Errorf("failed to find blocking message: %w", err)
+	}
+	if found {
+		lineage, err := su.lineage(msg)
+		if err != nil {
+			return types.BlockDependency{}, err
+		}
+		dep.Blocking = &lineage
+	}
+	return dep, nil
+}
+
+// checkIndexedBlock returns an error if the block is not indexed with the given hash.
+func (su *SupervisorBackend) checkIndexedBlock(chain types.ChainID, blockHash common.Hash, blockNum uint64) error {
+	sealHash, _, err := su.db.FindSealedBlock(chain, blockNum)
+	if err != nil {
+		return fmt.Errorf("block %v of chain %v is not indexed: %w", blockNum, chain, err)
+	}
+	if sealHash != backendTypes.TruncateHash(blockHash) {
+		return fmt.Errorf("block %v of chain %v is indexed with hash %v, not %v", blockNum, chain, sealHash, blockHash)
+	}
+	return nil
+}
+
+func (su *SupervisorBackend) lineage(msg db.ExecutingMessageEntry) (types.ExecutingMessageLineage, error) {
+	initChain := types.ChainIDFromUInt64(uint64(msg.Msg.Chain))
+	safety, err := su.logSafety(initChain, msg.Msg.BlockNum, msg.Msg.LogIdx, msg.Msg.Hash)
+	if err != nil {
+		return types.ExecutingMessageLineage{}, fmt.Errorf("failed to check initiating message of log %v in block %v: %w", msg.LogIdx, msg.BlockNum, err)
+	}
+	return types.ExecutingMessageLineage{
+		BlockNumber: hexutil.Uint64(msg.BlockNum),
+		LogIndex:    hexutil.Uint64(msg.LogIdx),
+		Initiating: types.InitiatingMessageRef{
+			ChainID:     hexutil.U256(initChain),
+			BlockNumber: hexutil.Uint64(msg.Msg.BlockNum),
+			LogIndex:    hexutil.Uint64(msg.Msg.LogIdx),
+			Timestamp:   hexutil.Uint64(msg.Msg.Timestamp),
+			LogHash:     msg.Msg.Hash[:],
+		},
+		Safety: safety,
+	}, nil
+}
+
+// safestAt returns the highest safety level of which the cross-head of the chain is at or beyond the entry index.
+func (su *SupervisorBackend) safestAt(chainID types.ChainID, i entrydb.EntryIdx) types.SafetyLevel {
+	safest := types.CrossUnsafe
 	for _, checker := range []db.SafetyChecker{
 		db.NewSafetyChecker(types.Unsafe, su.db),
 		db.NewSafetyChecker(types.Safe, su.db),
 		db.NewSafetyChecker(types.Finalized, su.db),
 	} {
-		if i <= checker.CrossHeadForChain(types.ChainID(*chainID)) {
+		if i <= checker.CrossHeadForChain(chainID) {
 			safest = checker.SafetyLevel()
 		}
 	}
-	return safest, nil
+	return safest
 }
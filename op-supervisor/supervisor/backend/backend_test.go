@@ -0,0 +1,165 @@
+package backend
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func TestCheckBlockAndMessage(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	chainID := types.ChainIDFromUInt64(900)
+
+	logDB, err := logs.NewFromFile(logger, &stubLogsMetrics{}, filepath.Join(dir, "log.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logDB.Close() })
+	headTracker, err := heads.NewHeadTracker(filepath.Join(dir, "heads.json"))
+	require.NoError(t, err)
+
+	origin := common.Address{0xaa}
+	payloadHash := common.Hash{0xbb}
+	block1 := eth.BlockID{Hash: common.Hash{0x01}, Number: 1}
+	block2 := eth.BlockID{Hash: common.Hash{0x02}, Number: 2}
+	require.NoError(t, logDB.AddLog(backendTypes.PayloadHashToLogHash(payloadHash, origin), block1, 1000, 0, nil))
+	require.NoError(t, logDB.SealBlock(block1, 1000))
+	require.NoError(t, logDB.SealBlock(block2, 1002))
+
+	su := &SupervisorBackend{
+		logger: logger,
+		db:     db.NewChainsDB(map[types.ChainID]db.LogStorage{chainID: logDB}, headTracker, logger),
+	}
+	checkBlock := func(block eth.BlockID) types.SafetyLevel {
+		chain := hexutil.U256(chainID)
+		lvl, err := su.CheckBlock(&chain, block.Hash, hexutil.Uint64(block.Number))
+		require.NoError(t, err)
+		return lvl
+	}
+	checkMessage := func(blockNum uint64, payloadHash common.Hash) types.SafetyLevel {
+		lvl, err := su.CheckMessage(types.Identifier{
+			Origin:      origin,
+			BlockNumber: blockNum,
+			ChainID:     chainID,
+		}, payloadHash)
+		require.NoError(t, err)
+		return lvl
+	}
+
+	require.Equal(t, types.CrossUnsafe, checkBlock(block1))
+	require.Equal(t, types.CrossUnsafe, checkBlock(block2))
+	require.Equal(t, types.Invalid, checkBlock(eth.BlockID{Hash: common.Hash{0xff}, Number: 2}), "reorged-out block")
+	require.Equal(t, types.Unknown, checkBlock(eth.BlockID{Hash: common.Hash{0x03}, Number: 3}), "beyond the indexed head")
+	require.Equal(t, types.Unknown, checkBlock(eth.BlockID{Hash: common.Hash{0x00}, Number: 0}), "before the first indexed block")
+
+	require.Equal(t, types.CrossUnsafe, checkMessage(1, payloadHash))
+	require.Equal(t, types.Invalid, checkMessage(1, common.Hash{0xcc}))
+	require.Equal(t, types.Invalid, checkMessage(2, payloadHash))
+	require.Equal(t, types.Unknown, checkMessage(3, payloadHash))
+
+	_, sealIdx, err := logDB.FindSealedBlock(block1.Number)
+	require.NoError(t, err)
+	require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		h.Put(chainID, heads.ChainHeads{CrossUnsafe: sealIdx, CrossSafe: sealIdx})
+		return nil
+	})))
+	require.Equal(t, types.CrossSafe, checkBlock(block1))
+	require.Equal(t, types.CrossSafe, checkMessage(1, payloadHash))
+	require.Equal(t, types.CrossUnsafe, checkBlock(block2))
+}
+
+func TestExecutingMessagesAndBlockDependency(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	dir := t.TempDir()
+	chainID := types.ChainIDFromUInt64(900)
+
+	logDB, err := logs.NewFromFile(logger, &stubLogsMetrics{}, filepath.Join(dir, "log.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logDB.Close() })
+	headTracker, err := heads.NewHeadTracker(filepath.Join(dir, "heads.json"))
+	require.NoError(t, err)
+
+	origin := common.Address{0xaa}
+	initHash := backendTypes.PayloadHashToLogHash(common.Hash{0xbb}, origin)
+	block1 := eth.BlockID{Hash: common.Hash{0x01}, Number: 1}
+	block2 := eth.BlockID{Hash: common.Hash{0x02}, Number: 2}
+	execLocal := &backendTypes.ExecutingMessage{Chain: 900, BlockNum: 1, LogIdx: 0, Timestamp: 1000, Hash: initHash}
+	execRemote := &backendTypes.ExecutingMessage{Chain: 901, BlockNum: 5, LogIdx: 3, Timestamp: 990, Hash: backendTypes.TruncatedHash{0xcc}}
+	require.NoError(t, logDB.AddLog(initHash, block1, 1000, 0, nil))
+	require.NoError(t, logDB.SealBlock(block1, 1000))
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x10}, block2, 1002, 0, execLocal))
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x11}, block2, 1002, 1, nil))
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x12}, block2, 1002, 2, execRemote))
+	require.NoError(t, logDB.SealBlock(block2, 1002))
+
+	_, sealIdx, err := logDB.FindSealedBlock(block1.Number)
+	require.NoError(t, err)
+	require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		h.Put(chainID, heads.ChainHeads{LocalSafe: sealIdx, CrossUnsafe: sealIdx, CrossSafe: sealIdx})
+		return nil
+	})))
+
+	su := &SupervisorBackend{
+		logger: logger,
+		db:     db.NewChainsDB(map[types.ChainID]db.LogStorage{chainID: logDB}, headTracker, logger),
+	}
+	chain := hexutil.U256(chainID)
+
+	msgs, err := su.ExecutingMessages(&chain, block1.Hash, hexutil.Uint64(block1.Number))
+	require.NoError(t, err)
+	require.Empty(t, msgs)
+
+	msgs, err = su.ExecutingMessages(&chain, block2.Hash, hexutil.Uint64(block2.Number))
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+	require.Equal(t, types.ExecutingMessageLineage{
+		BlockNumber: 2,
+		LogIndex:    0,
+		Initiating: types.InitiatingMessageRef{
+			ChainID:     chain,
+			BlockNumber: 1,
+			LogIndex:    0,
+			Timestamp:   1000,
+			LogHash:     initHash[:],
+		},
+		Safety: types.CrossSafe,
+	}, msgs[0])
+	require.Equal(t, hexutil.Uint64(2), msgs[1].LogIndex)
+	require.Equal(t, hexutil.U256(types.ChainIDFromUInt64(901)), msgs[1].Initiating.ChainID)
+	require.Equal(t, types.Unknown, msgs[1].Safety, "initiating chain is not indexed")
+
+	_, err = su.ExecutingMessages(&chain, common.Hash{0xff}, hexutil.Uint64(block2.Number))
+	require.Error(t, err, "reorged-out block")
+	_, err = su.ExecutingMessages(&chain, common.Hash{0x03}, 3)
+	require.ErrorIs(t, err, logs.ErrFuture)
+
+	dep, err := su.BlockDependency(&chain, block1.Hash, hexutil.Uint64(block1.Number))
+	require.NoError(t, err)
+	require.Equal(t, types.BlockDependency{Safety: types.CrossSafe}, dep)
+
+	dep, err = su.BlockDependency(&chain, block2.Hash, hexutil.Uint64(block2.Number))
+	require.NoError(t, err)
+	require.Equal(t, types.CrossUnsafe, dep.Safety)
+	require.NotNil(t, dep.Blocking)
+	require.Equal(t, msgs[1], *dep.Blocking)
+
+	dep, err = su.BlockDependency(&chain, common.Hash{0xff}, hexutil.Uint64(block2.Number))
+	require.NoError(t, err)
+	require.Equal(t, types.BlockDependency{Safety: types.Invalid}, dep)
+}
+
+type stubLogsMetrics struct{}
+
+func (s *stubLogsMetrics) RecordDBEntryCount(count int64)        {}
+func (s *stubLogsMetrics) RecordDBSearchEntriesRead(count int64) {}
@@ -12,6 +12,8 @@ type Metrics interface {
 
 	RecordDBEntryCount(chainID types.ChainID, count int64)
 	RecordDBSearchEntriesRead(chainID types.ChainID, count int64)
+
+	RecordReorg(chainID types.ChainID, depth uint64)
 }
 
 // chainMetrics is an adapter between the metrics API expected by clients that assume there's only a single chain
@@ -44,5 +46,9 @@ func (c *chainMetrics) RecordDBSearchEntriesRead(count int64) {
 	c.delegate.RecordDBSearchEntriesRead(c.chainID, count)
 }
 
+func (c *chainMetrics) RecordReorg(depth uint64) {
+	c.delegate.RecordReorg(c.chainID, depth)
+}
+
 var _ caching.Metrics = (*chainMetrics)(nil)
 var _ logs.Metrics = (*chainMetrics)(nil)
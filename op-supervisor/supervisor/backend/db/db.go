@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -23,6 +24,8 @@ var (
 type LogStorage interface {
 	io.Closer
 	AddLog(logHash backendTypes.TruncatedHash, block eth.BlockID, timestamp uint64, logIdx uint32, execMsg *backendTypes.ExecutingMessage) error
+	SealBlock(block eth.BlockID, timestamp uint64) error
+	FindSealedBlock(blockNum uint64) (backendTypes.TruncatedHash, entrydb.EntryIdx, error)
 	Rewind(newHeadBlockNum uint64) error
 	LatestBlockNum() uint64
 	ClosestBlockInfo(blockNum uint64) (uint64, backendTypes.TruncatedHash, error)
@@ -44,6 +47,9 @@ type ChainsDB struct {
 	heads            HeadsStorage
 	maintenanceReady chan struct{}
 	logger           log.Logger
+
+	// crossHeadsLock prevents the cross-heads from being updated while a chain is rewound
+	crossHeadsLock sync.Mutex
 }
 
 func NewChainsDB(logDBs map[types.ChainID]LogStorage, heads HeadsStorage, l log.Logger) *ChainsDB {
@@ -123,6 +129,8 @@ func (db *ChainsDB) RequestMaintenance() {
 // updateAllHeads updates the cross-heads of all safety levels
 // it is called by the maintenance loop
 func (db *ChainsDB) updateAllHeads() error {
+	db.crossHeadsLock.Lock()
+	defer db.crossHeadsLock.Unlock()
 	// create three safety checkers, one for each safety level
 	unsafeChecker := NewSafetyChecker(Unsafe, db)
 	safeChecker := NewSafetyChecker(Safe, db)
@@ -250,6 +258,90 @@ func (db *ChainsDB) LastLogInBlock(chain types.ChainID, blockNum uint64) (entryd
 	return ret, nil
 }
 
+// ExecutingMessageEntry is an executing message, with the position of its log in the chain that executes it.
+type ExecutingMessageEntry struct {
+	BlockNum uint64
+	LogIdx   uint32
+	Index    entrydb.EntryIdx
+	Msg      backendTypes.ExecutingMessage
+}
+
+// ExecutingMessagesInBlock returns the executing messages of the given block of the chain, in log order.
+func (db *ChainsDB) ExecutingMessagesInBlock(chain types.ChainID, blockNum uint64) ([]ExecutingMessageEntry, error) {
+	logDB, ok := db.logDBs[chain]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownChain, chain)
+	}
+	iter, err := logDB.ClosestBlockIterator(blockNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block iterator for chain %v: %w", chain, err)
+	}
+	var out []ExecutingMessageEntry
+	for {
+		bn, logIdx, _, err := iter.NextLog()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read next log entry for chain %v: %w", chain, err)
+		}
+		if bn > blockNum {
+			break
+		}
+		if bn < blockNum {
+			continue
+		}
+		exec, err := iter.ExecMessage()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read executing message of log %v in block %v: %w", logIdx, bn, err)
+		}
+		if exec != (backendTypes.ExecutingMessage{}) {
+			out = append(out, ExecutingMessageEntry{BlockNum: bn, LogIdx: logIdx, Index: iter.Index(), Msg: exec})
+		}
+	}
+	return out, nil
+}
+
+// FirstBlockingMessage returns the first executing message of the chain after its cross-head of the checker,
+// up to the end of the given block, that does not pass the check of the checker.
+// This is the executing message that keeps the cross-head from advancing to the block.
+// It returns false if all executing messages up to the end of the block pass the check.
+func (db *ChainsDB) FirstBlockingMessage(chain types.ChainID, blockNum uint64, checker SafetyChecker) (ExecutingMessageEntry, bool, error) {
+	logDB, ok := db.logDBs[chain]
+	if !ok {
+		return ExecutingMessageEntry{}, false, fmt.Errorf("%w: %v", ErrUnknownChain, chain)
+	}
+	xHead := checker.CrossHeadForChain(chain)
+	iter, err := logDB.LastCheckpointBehind(xHead)
+	if err != nil {
+		return ExecutingMessageEntry{}, false, fmt.Errorf("failed to get iterator behind cross-head of chain %v: %w", chain, err)
+	}
+	for {
+		bn, logIdx, _, err := iter.NextLog()
+		if errors.Is(err, io.EOF) {
+			return ExecutingMessageEntry{}, false, nil
+		} else if err != nil {
+			return ExecutingMessageEntry{}, false, fmt.Errorf("failed to read next log entry for chain %v: %w", chain, err)
+		}
+		if bn > blockNum {
+			return ExecutingMessageEntry{}, false, nil
+		}
+		if iter.Index() <= xHead {
+			continue
+		}
+		exec, err := iter.ExecMessage()
+		if err != nil {
+			return ExecutingMessageEntry{}, false, fmt.Errorf("failed to read executing message of log %v in block %v: %w", logIdx, bn, err)
+		}
+		if exec == (backendTypes.ExecutingMessage{}) {
+			continue
+		}
+		initChain := types.ChainIDFromUInt64(uint64(exec.Chain))
+		if _, ok := db.logDBs[initChain]; !ok || !checker.Check(initChain, exec.BlockNum, exec.LogIdx, exec.Hash) {
+			return ExecutingMessageEntry{BlockNum: bn, LogIdx: logIdx, Index: iter.Index(), Msg: exec}, true, nil
+		}
+	}
+}
+
 // LatestBlockNum returns the latest block number that has been recorded to the logs db
 // for the given chain. It does not contain safety guarantees.
 func (db *ChainsDB) LatestBlockNum(chain types.ChainID) uint64 {
@@ -268,12 +360,134 @@ func (db *ChainsDB) AddLog(chain types.ChainID, logHash backendTypes.TruncatedHa
 	return logDB.AddLog(logHash, block, timestamp, logIdx, execMsg)
 }
 
+func (db *ChainsDB) SealBlock(chain types.ChainID, block eth.BlockID, timestamp uint64) error {
+	logDB, ok := db.logDBs[chain]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrUnknownChain, chain)
+	}
+	return logDB.SealBlock(block, timestamp)
+}
+
+// FindSealedBlock returns the truncated hash the given block was sealed with in the logs db of the chain,
+// and the entry index of the seal. It returns logs.ErrFuture if the block is beyond the latest sealed block.
+func (db *ChainsDB) FindSealedBlock(chain types.ChainID, blockNum uint64) (backendTypes.TruncatedHash, entrydb.EntryIdx, error) {
+	logDB, ok := db.logDBs[chain]
+	if !ok {
+		return backendTypes.TruncatedHash{}, 0, fmt.Errorf("%w: %v", ErrUnknownChain, chain)
+	}
+	return logDB.FindSealedBlock(blockNum)
+}
+
+// Rewind removes all blocks after headBlockNum from the logs db of the given chain.
+// Heads that point beyond the rewound data are moved back: the heads of the chain itself to the end of
+// headBlockNum, and the heads of the other chains to before their first executing message of a removed block.
+// Maintenance is then requested, to recompute the cross-heads with UpdateCrossHeads.
 func (db *ChainsDB) Rewind(chain types.ChainID, headBlockNum uint64) error {
 	logDB, ok := db.logDBs[chain]
 	if !ok {
 		return fmt.Errorf("%w: %v", ErrUnknownChain, chain)
 	}
-	return logDB.Rewind(headBlockNum)
+	db.crossHeadsLock.Lock()
+	defer db.crossHeadsLock.Unlock()
+	removed := logDB.LatestBlockNum() > headBlockNum
+	if err := logDB.Rewind(headBlockNum); err != nil {
+		return err
+	}
+	if !removed {
+		return nil
+	}
+	if err := db.rewindHeads(chain, headBlockNum); err != nil {
+		return fmt.Errorf("failed to rewind heads after rewinding chain %v to block %v: %w", chain, headBlockNum, err)
+	}
+	db.RequestMaintenance()
+	return nil
+}
+
+// rewindHeads moves back the heads of all chains, so that they do not include any entries
+// that are, or depend on, blocks of the given chain after headBlockNum.
+func (db *ChainsDB) rewindHeads(chain types.ChainID, headBlockNum uint64) error {
+	current := db.heads.Current()
+	limits := make(map[types.ChainID]entrydb.EntryIdx)
+	_, sealIdx, err := db.logDBs[chain].FindSealedBlock(headBlockNum)
+	if errors.Is(err, logs.ErrNotFound) {
+		// rewound to before the first block of the chain, nothing is left
+		limits[chain] = 0
+	} else if err == nil {
+		limits[chain] = sealIdx
+	} else if !errors.Is(err, logs.ErrFuture) {
+		return fmt.Errorf("failed to find new head block %v: %w", headBlockNum, err)
+	}
+	// Cross-finalized entries only execute messages of cross-finalized blocks. Unless the rewind removes
+	// cross-finalized blocks, the executing messages of removed blocks are after the cross-finalized heads,
+	// and the log dbs are only scanned from there, not from their start while holding the cross-heads lock.
+	limit, limited := limits[chain]
+	keepsFinalized := !limited || limit >= current.Get(chain).CrossFinalized
+	for id, logDB := range db.logDBs {
+		if id == chain {
+			continue
+		}
+		h := current.Get(id)
+		maxHead := max(h.Unsafe, h.CrossUnsafe, h.LocalSafe, h.CrossSafe, h.LocalFinalized, h.CrossFinalized)
+		if maxHead == 0 {
+			continue
+		}
+		var from entrydb.EntryIdx
+		if keepsFinalized {
+			from = min(h.CrossUnsafe, h.CrossSafe, h.CrossFinalized)
+		}
+		idx, found, err := firstExecutingMessageOf(logDB, chain, headBlockNum, from, maxHead)
+		if err != nil {
+			return fmt.Errorf("failed to find executing messages of chain %v in chain %v: %w", chain, id, err)
+		}
+		if !found {
+			continue
+		}
+		if idx == 0 {
+			// the first entry of the chain executes a removed message, nothing is left
+			limits[id] = 0
+		} else {
+			limits[id] = idx - 1
+		}
+	}
+	return db.heads.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		for id, limit := range limits {
+			ch := h.Get(id)
+			ch.Unsafe = min(ch.Unsafe, limit)
+			ch.CrossUnsafe = min(ch.CrossUnsafe, limit)
+			ch.LocalSafe = min(ch.LocalSafe, limit)
+			ch.CrossSafe = min(ch.CrossSafe, limit)
+			ch.LocalFinalized = min(ch.LocalFinalized, limit)
+			ch.CrossFinalized = min(ch.CrossFinalized, limit)
+			if ch != h.Get(id) {
+				db.logger.Warn("Rewinding heads", "chain", id, "rewoundChain", chain, "block", headBlockNum, "limit", limit)
+			}
+			h.Put(id, ch)
+		}
+		return nil
+	}))
+}
+
+// firstExecutingMessageOf scans the logs db from the checkpoint behind the from entry up to the until entry,
+// and returns the entry index of the first executing message of a block of the given chain after blockNum.
+func firstExecutingMessageOf(logDB LogStorage, chain types.ChainID, blockNum uint64, from entrydb.EntryIdx, until entrydb.EntryIdx) (entrydb.EntryIdx, bool, error) {
+	i, err := logDB.LastCheckpointBehind(from)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to start iterating: %w", err)
+	}
+	for {
+		exec, err := logDB.NextExecutingMessage(i)
+		if errors.Is(err, io.EOF) {
+			return 0, false, nil
+		} else if err != nil {
+			return 0, false, fmt.Errorf("failed to read next executing message: %w", err)
+		}
+		if i.Index() > until {
+			return 0, false, nil
+		}
+		if types.ChainIDFromUInt64(uint64(exec.Chain)) == chain && exec.BlockNum > blockNum {
+			return i.Index(), true, nil
+		}
+	}
 }
 
 func (db *ChainsDB) Close() error {
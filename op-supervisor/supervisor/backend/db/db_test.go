@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	"io"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -36,6 +37,26 @@ func TestChainsDB_AddLog(t *testing.T) {
 	})
 }
 
+func TestChainsDB_SealBlock(t *testing.T) {
+	t.Run("UnknownChain", func(t *testing.T) {
+		db := NewChainsDB(nil, &stubHeadStorage{}, testlog.Logger(t, log.LevelDebug))
+		err := db.SealBlock(types.ChainIDFromUInt64(2), eth.BlockID{}, 1234)
+		require.ErrorIs(t, err, ErrUnknownChain)
+	})
+
+	t.Run("KnownChain", func(t *testing.T) {
+		chainID := types.ChainIDFromUInt64(1)
+		logDB := &stubLogDB{}
+		db := NewChainsDB(map[types.ChainID]LogStorage{
+			chainID: logDB,
+		}, &stubHeadStorage{},
+			testlog.Logger(t, log.LevelDebug))
+		err := db.SealBlock(chainID, eth.BlockID{}, 1234)
+		require.NoError(t, err, err)
+		require.Equal(t, 1, logDB.sealBlockCalls)
+	})
+}
+
 func TestChainsDB_Rewind(t *testing.T) {
 	t.Run("UnknownChain", func(t *testing.T) {
 		db := NewChainsDB(nil, &stubHeadStorage{}, testlog.Logger(t, log.LevelDebug))
@@ -56,6 +77,112 @@ func TestChainsDB_Rewind(t *testing.T) {
 	})
 }
 
+func TestChainsDB_RewindHeads(t *testing.T) {
+	chainA := types.ChainIDFromUInt64(1)
+	chainB := types.ChainIDFromUInt64(2)
+	chainC := types.ChainIDFromUInt64(3)
+	headTracker, err := heads.NewHeadTracker(filepath.Join(t.TempDir(), "heads.json"))
+	require.NoError(t, err)
+	require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		h.Put(chainA, heads.ChainHeads{CrossUnsafe: 80, CrossSafe: 25})
+		h.Put(chainB, heads.ChainHeads{CrossUnsafe: 20, CrossSafe: 5, CrossFinalized: 3})
+		h.Put(chainC, heads.ChainHeads{CrossUnsafe: 20})
+		return nil
+	})))
+
+	// chain A rewinds from block 50 to block 40, which is sealed at entry 30
+	logDBA := &stubLogDB{headBlockNum: 50, sealedIdx: 30}
+	// chain B executes a message of chain A block 35 at entry 10, and of chain A block 45 at entry 11
+	logDBB := &stubLogDB{
+		lastCheckpointBehind: &stubIterator{index: 9},
+		executingMessages: []*backendTypes.ExecutingMessage{
+			{Chain: 1, BlockNum: 35},
+			{Chain: 1, BlockNum: 45},
+		},
+	}
+	// chain C only executes messages of chain B
+	logDBC := &stubLogDB{
+		lastCheckpointBehind: &stubIterator{index: 9},
+		executingMessages: []*backendTypes.ExecutingMessage{
+			{Chain: 2, BlockNum: 45},
+			{Chain: 2, BlockNum: 46},
+		},
+		errOverload: io.EOF,
+		errAfter:    2,
+	}
+	db := NewChainsDB(map[types.ChainID]LogStorage{
+		chainA: logDBA,
+		chainB: logDBB,
+		chainC: logDBC,
+	}, headTracker, testlog.Logger(t, log.LevelDebug))
+
+	require.NoError(t, db.Rewind(chainA, 40))
+	require.EqualValues(t, 40, logDBA.headBlockNum)
+	current := headTracker.Current()
+	require.Equal(t, heads.ChainHeads{CrossUnsafe: 30, CrossSafe: 25}, current.Get(chainA))
+	require.Equal(t, heads.ChainHeads{CrossUnsafe: 10, CrossSafe: 5, CrossFinalized: 3}, current.Get(chainB))
+	require.Equal(t, heads.ChainHeads{CrossUnsafe: 20}, current.Get(chainC))
+	// The log dbs are scanned from their lowest cross head
+	require.Equal(t, entrydb.EntryIdx(3), logDBB.checkpointBehindIdx)
+	require.Equal(t, entrydb.EntryIdx(0), logDBC.checkpointBehindIdx)
+}
+
+func TestChainsDB_RewindHeadsRemovingFinalized(t *testing.T) {
+	chainA := types.ChainIDFromUInt64(1)
+	chainB := types.ChainIDFromUInt64(2)
+	headTracker, err := heads.NewHeadTracker(filepath.Join(t.TempDir(), "heads.json"))
+	require.NoError(t, err)
+	require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		h.Put(chainA, heads.ChainHeads{CrossUnsafe: 80, CrossSafe: 60, CrossFinalized: 50})
+		h.Put(chainB, heads.ChainHeads{CrossUnsafe: 20, CrossSafe: 15, CrossFinalized: 12})
+		return nil
+	})))
+
+	// chain A rewinds to block 40, sealed at entry 30, before its cross-finalized head
+	logDBA := &stubLogDB{headBlockNum: 50, sealedIdx: 30}
+	logDBB := &stubLogDB{
+		lastCheckpointBehind: &stubIterator{index: 0},
+		executingMessages:    []*backendTypes.ExecutingMessage{{Chain: 1, BlockNum: 45}},
+	}
+	db := NewChainsDB(map[types.ChainID]LogStorage{
+		chainA: logDBA,
+		chainB: logDBB,
+	}, headTracker, testlog.Logger(t, log.LevelDebug))
+
+	require.NoError(t, db.Rewind(chainA, 40))
+	// Finalized entries may execute messages of the removed blocks, chain B is scanned from its start
+	require.Equal(t, entrydb.EntryIdx(0), logDBB.checkpointBehindIdx)
+	require.Equal(t, heads.ChainHeads{}, headTracker.Current().Get(chainB))
+}
+
+func TestChainsDB_RewindHeadsExecutingFirstEntry(t *testing.T) {
+	chainA := types.ChainIDFromUInt64(1)
+	chainB := types.ChainIDFromUInt64(2)
+	headTracker, err := heads.NewHeadTracker(filepath.Join(t.TempDir(), "heads.json"))
+	require.NoError(t, err)
+	require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		h.Put(chainA, heads.ChainHeads{CrossUnsafe: 80, CrossSafe: 25})
+		h.Put(chainB, heads.ChainHeads{Unsafe: 20, CrossUnsafe: 20, LocalSafe: 15, CrossSafe: 15})
+		return nil
+	})))
+
+	// chain A rewinds from block 50 to block 40, which is sealed at entry 30
+	logDBA := &stubLogDB{headBlockNum: 50, sealedIdx: 30}
+	// chain B executes a message of chain A block 45 at its first entry
+	logDBB := &stubLogDB{
+		lastCheckpointBehind: &stubIterator{index: -1},
+		executingMessages:    []*backendTypes.ExecutingMessage{{Chain: 1, BlockNum: 45}},
+	}
+	db := NewChainsDB(map[types.ChainID]LogStorage{
+		chainA: logDBA,
+		chainB: logDBB,
+	}, headTracker, testlog.Logger(t, log.LevelDebug))
+
+	require.NoError(t, db.Rewind(chainA, 40))
+	// The heads of chain B are rewound to its start, not before it
+	require.Equal(t, heads.ChainHeads{}, headTracker.Current().Get(chainB))
+}
+
 func TestChainsDB_LastLogInBlock(t *testing.T) {
 	// using a chainID of 1 for simplicity
 	chainID := types.ChainIDFromUInt64(1)
@@ -410,18 +537,23 @@ func (s *stubIterator) ExecMessage() (backendTypes.ExecutingMessage, error) {
 
 type stubLogDB struct {
 	addLogCalls          int
+	sealBlockCalls       int
+	sealedIdx            entrydb.EntryIdx
+	sealedErr            error
 	headBlockNum         uint64
 	emIndex              int
 	executingMessages    []*backendTypes.ExecutingMessage
 	nextLogs             []nextLogResponse
 	lastCheckpointBehind *stubIterator
+	checkpointBehindIdx  entrydb.EntryIdx
 	errOverload          error
 	errAfter             int
 	containsResponse     containsResponse
 }
 
 // stubbed LastCheckpointBehind returns a stubbed iterator which was passed in to the struct
-func (s *stubLogDB) LastCheckpointBehind(entrydb.EntryIdx) (logs.Iterator, error) {
+func (s *stubLogDB) LastCheckpointBehind(idx entrydb.EntryIdx) (logs.Iterator, error) {
+	s.checkpointBehindIdx = idx
 	return s.lastCheckpointBehind, nil
 }
 
@@ -455,6 +587,15 @@ func (s *stubLogDB) AddLog(logHash backendTypes.TruncatedHash, block eth.BlockID
 	return nil
 }
 
+func (s *stubLogDB) SealBlock(block eth.BlockID, timestamp uint64) error {
+	s.sealBlockCalls++
+	return nil
+}
+
+func (s *stubLogDB) FindSealedBlock(blockNum uint64) (backendTypes.TruncatedHash, entrydb.EntryIdx, error) {
+	return backendTypes.TruncatedHash{}, s.sealedIdx, s.sealedErr
+}
+
 type containsResponse struct {
 	contains bool
 	index    entrydb.EntryIdx
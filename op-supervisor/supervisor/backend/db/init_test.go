@@ -82,6 +82,14 @@ func (s *stubLogStore) AddLog(logHash types.TruncatedHash, block eth.BlockID, ti
 	panic("not supported")
 }
 
+func (s *stubLogStore) SealBlock(block eth.BlockID, timestamp uint64) error {
+	panic("not supported")
+}
+
+func (s *stubLogStore) FindSealedBlock(blockNum uint64) (types.TruncatedHash, entrydb.EntryIdx, error) {
+	panic("not supported")
+}
+
 func (s *stubLogStore) LatestBlockNum() uint64 {
 	panic("not supported")
 }
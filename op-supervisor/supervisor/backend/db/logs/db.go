@@ -26,12 +26,15 @@ const (
 	typeInitiatingEvent
 	typeExecutingLink
 	typeExecutingCheck
+	typeBlockHash
 )
 
 var (
 	ErrLogOutOfOrder  = errors.New("log out of order")
 	ErrDataCorruption = errors.New("data corruption")
 	ErrNotFound       = errors.New("not found")
+	// ErrFuture is returned when looking up a block that is beyond the latest sealed block of the database.
+	ErrFuture = errors.New("future block")
 )
 
 type Metrics interface {
@@ -42,6 +45,8 @@ type Metrics interface {
 type logContext struct {
 	blockNum uint64
 	logIdx   uint32
+	// sealed is true if the block hash of blockNum was recorded, after which no more logs can be added to the block.
+	sealed bool
 }
 
 type EntryStore interface {
@@ -67,6 +72,7 @@ type EntryStore interface {
 // type 2 "diff" values are offsets from type 0 values (always within 256 entries range)
 // type 3 always after type 2
 // type 4 always after type 3
+// type 5 after all type 2, 3 and 4 entries of the block, once for every block
 //
 // Types (<type> = 1 byte):
 // type 0: "search checkpoint" <type><uint64 block number: 8 bytes><uint32 event index offset: 4 bytes><uint64 timestamp: 8 bytes> = 20 bytes
@@ -74,6 +80,7 @@ type EntryStore interface {
 // type 2: "initiating event" <type><blocknum diff: 1 byte><event flags: 1 byte><event-hash: 20 bytes> = 23 bytes
 // type 3: "executing link" <type><chain: 4 bytes><blocknum: 8 bytes><event index: 3 bytes><uint64 timestamp: 8 bytes> = 24 bytes
 // type 4: "executing check" <type><event-hash: 20 bytes> = 21 bytes
+// type 5: "block hash" <type><blocknum diff: 1 byte><blockhash truncated: 20 bytes> = 22 bytes
 // other types: future compat. E.g. for linking to L1, registering block-headers as a kind of initiating-event, tracking safe-head progression, etc.
 //
 // Right-pad each entry that is not 24 bytes.
@@ -132,7 +139,7 @@ func (db *DB) init() error {
 	}
 	// Read all entries until the end of the file
 	for {
-		_, _, _, err := i.NextLog()
+		_, err := i.next()
 		if errors.Is(err, io.EOF) {
 			break
 		} else if err != nil {
@@ -150,8 +157,8 @@ func (db *DB) trimInvalidTrailingEntries() error {
 		if err != nil {
 			return fmt.Errorf("failed to read %v to check for trailing entries: %w", i, err)
 		}
-		if entry[0] == typeExecutingCheck {
-			// executing check is a valid final entry
+		if entry[0] == typeExecutingCheck || entry[0] == typeBlockHash {
+			// executing check and block hash are valid final entries
 			break
 		}
 		if entry[0] == typeInitiatingEvent {
@@ -181,6 +188,94 @@ func (db *DB) LatestBlockNum() uint64 {
 	return db.lastEntryContext.blockNum
 }
 
+// latestSealedBlockNum returns the number of the latest block that may have been sealed,
+// and false if no block can have been sealed yet.
+func (db *DB) latestSealedBlockNum() (uint64, bool) {
+	if db.lastEntryIdx() < 0 {
+		return 0, false
+	}
+	if db.lastEntryContext.sealed {
+		return db.lastEntryContext.blockNum, true
+	}
+	if db.lastEntryContext.blockNum == 0 {
+		return 0, false
+	}
+	return db.lastEntryContext.blockNum - 1, true
+}
+
+// FindSealedBlock returns the truncated hash the block at blockNum was sealed with,
+// and the entry index of the block hash entry.
+// Returns ErrFuture if the block is beyond the latest sealed block,
+// and ErrNotFound if the block was not sealed in the database.
+func (db *DB) FindSealedBlock(blockNum uint64) (types.TruncatedHash, entrydb.EntryIdx, error) {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	latest, ok := db.latestSealedBlockNum()
+	if !ok || blockNum > latest {
+		return types.TruncatedHash{}, 0, fmt.Errorf("%w: block %v is beyond the latest sealed block", ErrFuture, blockNum)
+	}
+	checkpointIdx, err := db.searchCheckpoint(blockNum, 0)
+	if errors.Is(err, io.EOF) {
+		// Did not find a checkpoint to start reading from so the block cannot be present.
+		return types.TruncatedHash{}, 0, ErrNotFound
+	} else if err != nil {
+		return types.TruncatedHash{}, 0, err
+	}
+	i, err := db.newIterator(checkpointIdx)
+	if err != nil {
+		return types.TruncatedHash{}, 0, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer func() {
+		db.m.RecordDBSearchEntriesRead(i.entriesRead)
+	}()
+	for {
+		entryType, err := i.next()
+		if errors.Is(err, io.EOF) {
+			return types.TruncatedHash{}, 0, ErrNotFound
+		} else if err != nil {
+			return types.TruncatedHash{}, 0, fmt.Errorf("failed to read next entry: %w", err)
+		}
+		if i.current.blockNum > blockNum {
+			// Progressed past the requested block without finding its block hash.
+			return types.TruncatedHash{}, 0, ErrNotFound
+		}
+		if entryType == typeBlockHash && i.current.blockNum == blockNum {
+			return i.lastBlockHash, i.Index(), nil
+		}
+	}
+}
+
+// ForEachSealedBlock calls fn with the number and the truncated hash of every sealed block in the database, in order.
+// Iteration stops at the first error returned by fn.
+func (db *DB) ForEachSealedBlock(fn func(blockNum uint64, hash types.TruncatedHash) error) error {
+	db.rwLock.RLock()
+	defer db.rwLock.RUnlock()
+	if db.lastEntryIdx() < 0 {
+		return nil
+	}
+	i, err := db.newIterator(0)
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer func() {
+		db.m.RecordDBSearchEntriesRead(i.entriesRead)
+	}()
+	for {
+		entryType, err := i.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to read next entry: %w", err)
+		}
+		if entryType != typeBlockHash {
+			continue
+		}
+		if err := fn(i.current.blockNum, i.lastBlockHash); err != nil {
+			return err
+		}
+	}
+}
+
 // ClosestBlockInfo returns the block number and hash of the highest recorded block at or before blockNum.
 // Since block data is only recorded in search checkpoints, this may return an earlier block even if log data is
 // recorded for the requested block.
@@ -401,6 +496,9 @@ func (db *DB) AddLog(logHash types.TruncatedHash, block eth.BlockID, timestamp u
 	if db.lastEntryContext.blockNum > block.Number {
 		return fmt.Errorf("%w: adding block %v, head block: %v", ErrLogOutOfOrder, block.Number, db.lastEntryContext.blockNum)
 	}
+	if db.lastEntryContext.blockNum == block.Number && db.lastEntryContext.sealed {
+		return fmt.Errorf("%w: adding log %v to sealed block %v", ErrLogOutOfOrder, logIdx, block.Number)
+	}
 	if db.lastEntryContext.blockNum == block.Number && db.lastEntryContext.logIdx+1 != logIdx {
 		return fmt.Errorf("%w: adding log %v in block %v, but currently at log %v", ErrLogOutOfOrder, logIdx, block.Number, db.lastEntryContext.logIdx)
 	}
@@ -449,6 +547,47 @@ func (db *DB) AddLog(logHash types.TruncatedHash, block eth.BlockID, timestamp u
 	return nil
 }
 
+// SealBlock records the hash of the block, after all the logs of the block were added.
+// Every block has to be sealed, including blocks without any logs, so the database can verify block hashes.
+func (db *DB) SealBlock(block eth.BlockID, timestamp uint64) error {
+	db.rwLock.Lock()
+	defer db.rwLock.Unlock()
+	empty := db.lastEntryIdx() < 0
+	if !empty && db.lastEntryContext.blockNum > block.Number {
+		return fmt.Errorf("%w: sealing block %v, head block: %v", ErrLogOutOfOrder, block.Number, db.lastEntryContext.blockNum)
+	}
+	if !empty && db.lastEntryContext.sealed && db.lastEntryContext.blockNum == block.Number {
+		return fmt.Errorf("%w: block %v is already sealed", ErrLogOutOfOrder, block.Number)
+	}
+	if !empty && !db.lastEntryContext.sealed && db.lastEntryContext.blockNum != block.Number {
+		return fmt.Errorf("%w: sealing block %v before block %v", ErrLogOutOfOrder, block.Number, db.lastEntryContext.blockNum)
+	}
+	var entriesToAdd []entrydb.Entry
+	newContext := db.lastEntryContext
+	if (db.lastEntryIdx()+1)%searchCheckpointFrequency == 0 {
+		// The checkpoint is positioned after the last log of the block, so log searches start before it.
+		logIdx := uint32(0)
+		if !empty && db.lastEntryContext.blockNum == block.Number {
+			logIdx = db.lastEntryContext.logIdx + 1
+		}
+		entriesToAdd = append(entriesToAdd,
+			newSearchCheckpoint(block.Number, logIdx, timestamp).encode(),
+			newCanonicalHash(types.TruncateHash(block.Hash)).encode())
+		newContext = logContext{blockNum: block.Number, logIdx: logIdx}
+	}
+	seal, err := newBlockHash(newContext, block.Number, types.TruncateHash(block.Hash))
+	if err != nil {
+		return fmt.Errorf("failed to create block hash: %w", err)
+	}
+	entriesToAdd = append(entriesToAdd, seal.encode())
+	if err := db.store.Append(entriesToAdd...); err != nil {
+		return fmt.Errorf("failed to append entries: %w", err)
+	}
+	db.lastEntryContext = seal.postContext(newContext)
+	db.updateEntryCountMetric()
+	return nil
+}
+
 // Rewind the database to remove any blocks after headBlockNum
 // The block at headBlockNum itself is not removed.
 func (db *DB) Rewind(headBlockNum uint64) error {
@@ -476,14 +615,14 @@ func (db *DB) Rewind(headBlockNum uint64) error {
 		// So move our delete marker back to include it as a starting point
 		idx--
 		for {
-			blockNum, _, _, err := i.NextLog()
+			_, err := i.next()
 			if errors.Is(err, io.EOF) {
 				// Reached end of file, we need to keep everything
 				return nil
 			} else if err != nil {
 				return fmt.Errorf("failed to find rewind point: %w", err)
 			}
-			if blockNum > headBlockNum {
+			if i.current.blockNum > headBlockNum {
 				// Found the first entry we don't need, so stop searching and delete everything after idx
 				break
 			}
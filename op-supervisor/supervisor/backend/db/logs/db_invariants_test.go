@@ -47,6 +47,7 @@ func checkDBInvariants(t *testing.T, dbPath string, m *stubMetrics) {
 		invariantExecLinkOnlyAfterInitiatingEventWithFlagSet,
 		invariantExecCheckAfterExecLink,
 		invariantExecCheckOnlyAfterExecLink,
+		invariantNoLogsAfterBlockHashInSameBlock,
 		invariantValidLastEntry,
 	}
 	for i, entry := range entries {
@@ -238,12 +239,25 @@ func invariantExecCheckOnlyAfterExecLink(entryIdx int, entry entrydb.Entry, entr
 	return nil
 }
 
-// invariantValidLastEntry checks that the last entry is either a executing check or initiating event with no exec message
+func invariantNoLogsAfterBlockHashInSameBlock(entryIdx int, entry entrydb.Entry, entries []entrydb.Entry, m *stubMetrics) error {
+	if entry[0] != typeInitiatingEvent {
+		return nil
+	}
+	if entryIdx == 0 || entries[entryIdx-1][0] != typeBlockHash {
+		return nil
+	}
+	if blockDiff := entry[1]; blockDiff == 0 {
+		return fmt.Errorf("initiating event at %v adds a log to the block sealed by the block hash at %v", entryIdx, entryIdx-1)
+	}
+	return nil
+}
+
+// invariantValidLastEntry checks that the last entry is either a executing check, block hash or initiating event with no exec message
 func invariantValidLastEntry(entryIdx int, entry entrydb.Entry, entries []entrydb.Entry, m *stubMetrics) error {
 	if entryIdx+1 < len(entries) {
 		return nil
 	}
-	if entry[0] == typeExecutingCheck {
+	if entry[0] == typeExecutingCheck || entry[0] == typeBlockHash {
 		return nil
 	}
 	if entry[0] != typeInitiatingEvent {
@@ -2,6 +2,7 @@ package logs
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
@@ -877,6 +878,221 @@ func TestRewind(t *testing.T) {
 	})
 }
 
+func TestSealBlock(t *testing.T) {
+	t.Run("EmptyBlock", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(16), Number: 16}, 5002))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.EqualValues(t, 4, m.entryCount)
+				require.EqualValues(t, 16, db.LatestBlockNum())
+				requireSealedBlock(t, db, 15, createHash(15))
+				requireSealedBlock(t, db, 16, createHash(16))
+			})
+	})
+
+	t.Run("AfterLogs", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(15), Number: 15}, 5000, 0, nil))
+				require.NoError(t, db.AddLog(createTruncatedHash(2), eth.BlockID{Hash: createHash(15), Number: 15}, 5000, 1, nil))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(16), Number: 16}, 5002))
+				require.NoError(t, db.AddLog(createTruncatedHash(3), eth.BlockID{Hash: createHash(17), Number: 17}, 5004, 0, nil))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(17), Number: 17}, 5004))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.EqualValues(t, 8, m.entryCount)
+				requireContains(t, db, 15, 0, createHash(1))
+				requireContains(t, db, 15, 1, createHash(2))
+				requireContains(t, db, 17, 0, createHash(3))
+				requireSealedBlock(t, db, 15, createHash(15))
+				requireSealedBlock(t, db, 16, createHash(16))
+				requireSealedBlock(t, db, 17, createHash(17))
+			})
+	})
+
+	t.Run("AtSearchCheckpoint", func(t *testing.T) {
+		block1 := eth.BlockID{Hash: createHash(11), Number: 11}
+		block2 := eth.BlockID{Hash: createHash(12), Number: 12}
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				// Fill the first 256 entries, so that the block hash of block1 needs a checkpoint before it
+				for i := 0; i < searchCheckpointFrequency-2; i++ {
+					require.NoError(t, db.AddLog(createTruncatedHash(i), block1, 3000, uint32(i), nil))
+				}
+				require.NoError(t, db.SealBlock(block1, 3000))
+				require.NoError(t, db.AddLog(createTruncatedHash(1), block2, 3002, 0, nil))
+				require.NoError(t, db.SealBlock(block2, 3002))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.EqualValues(t, searchCheckpointFrequency+5, m.entryCount)
+				requireContains(t, db, 11, searchCheckpointFrequency-3, createHash(searchCheckpointFrequency-3))
+				requireContains(t, db, 12, 0, createHash(1))
+				requireSealedBlock(t, db, 11, createHash(11))
+				requireSealedBlock(t, db, 12, createHash(12))
+			})
+	})
+
+	t.Run("RejectLogsInSealedBlock", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(15), Number: 15}, 5000, 0, nil))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				err := db.AddLog(createTruncatedHash(2), eth.BlockID{Hash: createHash(15), Number: 15}, 5000, 1, nil)
+				require.ErrorIs(t, err, ErrLogOutOfOrder)
+				err = db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000)
+				require.ErrorIs(t, err, ErrLogOutOfOrder)
+				err = db.SealBlock(eth.BlockID{Hash: createHash(14), Number: 14}, 4998)
+				require.ErrorIs(t, err, ErrLogOutOfOrder)
+			})
+	})
+
+	t.Run("RejectSkippingUnsealedBlock", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(15), Number: 15}, 5000, 0, nil))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				err := db.SealBlock(eth.BlockID{Hash: createHash(16), Number: 16}, 5002)
+				require.ErrorIs(t, err, ErrLogOutOfOrder)
+			})
+	})
+}
+
+func TestFindSealedBlock(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		runDBTest(t, func(t *testing.T, db *DB, m *stubMetrics) {},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				_, _, err := db.FindSealedBlock(0)
+				require.ErrorIs(t, err, ErrFuture)
+			})
+	})
+
+	t.Run("Unsealed", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000))
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(16), Number: 16}, 5002, 0, nil))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				requireSealedBlock(t, db, 15, createHash(15))
+				_, _, err := db.FindSealedBlock(16)
+				require.ErrorIs(t, err, ErrFuture, "block with logs but no block hash yet")
+				_, _, err = db.FindSealedBlock(14)
+				require.ErrorIs(t, err, ErrNotFound, "block before the first block")
+			})
+	})
+
+	t.Run("EntryIndex", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(15), Number: 15}, 5000, 0, nil))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(16), Number: 16}, 5002))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				_, idx, err := db.FindSealedBlock(15)
+				require.NoError(t, err)
+				require.EqualValues(t, 3, idx)
+				_, idx, err = db.FindSealedBlock(16)
+				require.NoError(t, err)
+				require.EqualValues(t, 4, idx)
+			})
+	})
+}
+
+func TestForEachSealedBlock(t *testing.T) {
+	collect := func(t *testing.T, db *DB) (nums []uint64, hashes []types.TruncatedHash) {
+		require.NoError(t, db.ForEachSealedBlock(func(blockNum uint64, hash types.TruncatedHash) error {
+			nums = append(nums, blockNum)
+			hashes = append(hashes, hash)
+			return nil
+		}))
+		return nums, hashes
+	}
+
+	t.Run("Empty", func(t *testing.T) {
+		runDBTest(t, func(t *testing.T, db *DB, m *stubMetrics) {},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				nums, _ := collect(t, db)
+				require.Empty(t, nums)
+			})
+	})
+
+	t.Run("AcrossCheckpoints", func(t *testing.T) {
+		const blocks = searchCheckpointFrequency
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				for n := uint64(15); n < 15+blocks; n++ {
+					block := eth.BlockID{Hash: createHash(int(n)), Number: n}
+					require.NoError(t, db.AddLog(createTruncatedHash(int(n)), block, 5000+n, 0, nil))
+					require.NoError(t, db.SealBlock(block, 5000+n))
+				}
+				// Not sealed yet
+				require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(15 + blocks), Number: 15 + blocks}, 5000, 0, nil))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				nums, hashes := collect(t, db)
+				require.Len(t, nums, blocks)
+				for i, n := range nums {
+					require.Equal(t, uint64(15+i), n)
+					require.Equal(t, types.TruncateHash(createHash(int(n))), hashes[i])
+				}
+			})
+	})
+
+	t.Run("StopOnError", func(t *testing.T) {
+		runDBTest(t,
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(15), Number: 15}, 5000))
+				require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(16), Number: 16}, 5002))
+			},
+			func(t *testing.T, db *DB, m *stubMetrics) {
+				stop := errors.New("stop")
+				calls := 0
+				err := db.ForEachSealedBlock(func(uint64, types.TruncatedHash) error {
+					calls++
+					return stop
+				})
+				require.ErrorIs(t, err, stop)
+				require.Equal(t, 1, calls)
+			})
+	})
+}
+
+func TestRewindSealedBlocks(t *testing.T) {
+	runDBTest(t,
+		func(t *testing.T, db *DB, m *stubMetrics) {
+			require.NoError(t, db.AddLog(createTruncatedHash(1), eth.BlockID{Hash: createHash(50), Number: 50}, 500, 0, nil))
+			require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(50), Number: 50}, 500))
+			require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(51), Number: 51}, 502))
+			require.NoError(t, db.AddLog(createTruncatedHash(2), eth.BlockID{Hash: createHash(52), Number: 52}, 504, 0, nil))
+			require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(52), Number: 52}, 504))
+			require.NoError(t, db.Rewind(51))
+		},
+		func(t *testing.T, db *DB, m *stubMetrics) {
+			requireContains(t, db, 50, 0, createHash(1))
+			requireNotContains(t, db, 52, 0, createHash(2))
+			requireSealedBlock(t, db, 50, createHash(50))
+			requireSealedBlock(t, db, 51, createHash(51))
+			_, _, err := db.FindSealedBlock(52)
+			require.ErrorIs(t, err, ErrFuture)
+			require.NoError(t, db.SealBlock(eth.BlockID{Hash: createHash(152), Number: 52}, 504), "Can re-add rewound block")
+			requireSealedBlock(t, db, 52, createHash(152))
+		})
+}
+
+func requireSealedBlock(t *testing.T, db *DB, blockNum uint64, blockHash common.Hash) {
+	hash, _, err := db.FindSealedBlock(blockNum)
+	require.NoError(t, err)
+	require.Equal(t, types.TruncateHash(blockHash), hash)
+}
+
 type stubMetrics struct {
 	entryCount           int64
 	entriesReadForSearch int64
@@ -232,6 +232,58 @@ func (e executingCheck) encode() entrydb.Entry {
 	return entry
 }
 
+type blockHash struct {
+	blockDiff uint8
+	hash      types.TruncatedHash
+}
+
+func newBlockHash(pre logContext, blockNum uint64, hash types.TruncatedHash) (blockHash, error) {
+	blockDiff := blockNum - pre.blockNum
+	if blockDiff > math.MaxUint8 {
+		// TODO(optimism#11091): Need to find a way to support this.
+		return blockHash{}, fmt.Errorf("too many block skipped between %v and %v", pre.blockNum, blockNum)
+	}
+	return blockHash{
+		blockDiff: uint8(blockDiff),
+		hash:      hash,
+	}, nil
+}
+
+func newBlockHashFromEntry(entry entrydb.Entry) (blockHash, error) {
+	if entry[0] != typeBlockHash {
+		return blockHash{}, fmt.Errorf("%w: attempting to decode block hash but was type %v", ErrDataCorruption, entry[0])
+	}
+	var hash types.TruncatedHash
+	copy(hash[:], entry[2:22])
+	return blockHash{
+		blockDiff: entry[1],
+		hash:      hash,
+	}, nil
+}
+
+// encode creates a block hash entry
+// type 5: "block hash" <type><blocknum diff: 1 byte><blockhash truncated: 20 bytes> = 22 bytes
+func (b blockHash) encode() entrydb.Entry {
+	var entry entrydb.Entry
+	entry[0] = typeBlockHash
+	entry[1] = b.blockDiff
+	copy(entry[2:22], b.hash[:])
+	return entry
+}
+
+// postContext returns the logContext of the sealed block.
+func (b blockHash) postContext(pre logContext) logContext {
+	post := logContext{
+		blockNum: pre.blockNum + uint64(b.blockDiff),
+		logIdx:   pre.logIdx,
+		sealed:   true,
+	}
+	if b.blockDiff > 0 {
+		post.logIdx = 0
+	}
+	return post
+}
+
 func newExecutingMessageFromEntries(linkEntry entrydb.Entry, checkEntry entrydb.Entry) (types.ExecutingMessage, error) {
 	link, err := newExecutingLinkFromEntry(linkEntry)
 	if err != nil {
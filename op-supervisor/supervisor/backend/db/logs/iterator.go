@@ -22,53 +22,72 @@ type iterator struct {
 	current    logContext
 	hasExecMsg bool
 
+	lastLogHash   types.TruncatedHash
+	lastBlockHash types.TruncatedHash
+
 	entriesRead int64
 }
 
 // NextLog returns the next log in the iterator.
 // It scans forward until it finds an initiating event, returning the block number, log index, and event hash.
 func (i *iterator) NextLog() (blockNum uint64, logIdx uint32, evtHash types.TruncatedHash, outErr error) {
-	for i.nextEntryIdx <= i.db.lastEntryIdx() {
-		entryIdx := i.nextEntryIdx
-		entry, err := i.db.store.Read(entryIdx)
+	for {
+		entryType, err := i.next()
 		if err != nil {
-			outErr = fmt.Errorf("failed to read entry %v: %w", i, err)
+			outErr = err
 			return
 		}
-		i.nextEntryIdx++
-		i.entriesRead++
-		i.hasExecMsg = false
-		switch entry[0] {
-		case typeSearchCheckpoint:
-			current, err := newSearchCheckpointFromEntry(entry)
-			if err != nil {
-				outErr = fmt.Errorf("failed to parse search checkpoint at idx %v: %w", entryIdx, err)
-				return
-			}
-			i.current.blockNum = current.blockNum
-			i.current.logIdx = current.logIdx
-		case typeInitiatingEvent:
-			evt, err := newInitiatingEventFromEntry(entry)
-			if err != nil {
-				outErr = fmt.Errorf("failed to parse initiating event at idx %v: %w", entryIdx, err)
-				return
-			}
-			i.current = evt.postContext(i.current)
-			blockNum = i.current.blockNum
-			logIdx = i.current.logIdx
-			evtHash = evt.logHash
-			i.hasExecMsg = evt.hasExecMsg
-			return
-		case typeCanonicalHash: // Skip
-		case typeExecutingCheck: // Skip
-		case typeExecutingLink: // Skip
-		default:
-			outErr = fmt.Errorf("unknown entry type at idx %v %v", entryIdx, entry[0])
-			return
+		if entryType == typeInitiatingEvent {
+			return i.current.blockNum, i.current.logIdx, i.lastLogHash, nil
+		}
+	}
+}
+
+// next reads the next entry and applies it to the current log context.
+// It returns the type of the entry that was read, or io.EOF if there are no more entries.
+func (i *iterator) next() (byte, error) {
+	if i.nextEntryIdx > i.db.lastEntryIdx() {
+		return 0, io.EOF
+	}
+	entryIdx := i.nextEntryIdx
+	entry, err := i.db.store.Read(entryIdx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read entry %v: %w", entryIdx, err)
+	}
+	i.nextEntryIdx++
+	i.entriesRead++
+	i.hasExecMsg = false
+	switch entry[0] {
+	case typeSearchCheckpoint:
+		current, err := newSearchCheckpointFromEntry(entry)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse search checkpoint at idx %v: %w", entryIdx, err)
+		}
+		i.current.blockNum = current.blockNum
+		i.current.logIdx = current.logIdx
+		i.current.sealed = false
+	case typeInitiatingEvent:
+		evt, err := newInitiatingEventFromEntry(entry)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse initiating event at idx %v: %w", entryIdx, err)
+		}
+		i.current = evt.postContext(i.current)
+		i.lastLogHash = evt.logHash
+		i.hasExecMsg = evt.hasExecMsg
+	case typeBlockHash:
+		seal, err := newBlockHashFromEntry(entry)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse block hash at idx %v: %w", entryIdx, err)
 		}
+		i.current = seal.postContext(i.current)
+		i.lastBlockHash = seal.hash
+	case typeCanonicalHash: // Skip
+	case typeExecutingCheck: // Skip
+	case typeExecutingLink: // Skip
+	default:
+		return 0, fmt.Errorf("unknown entry type at idx %v %v", entryIdx, entry[0])
 	}
-	outErr = io.EOF
-	return
+	return entry[0], nil
 }
 
 func (i *iterator) Index() entrydb.EntryIdx {
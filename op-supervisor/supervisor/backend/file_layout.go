@@ -2,8 +2,12 @@ package backend
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"sort"
+
+	"github.com/holiman/uint256"
 
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 )
@@ -24,6 +28,40 @@ func prepChainDir(chainID types.ChainID, datadir string) (string, error) {
 	return dir, nil
 }
 
+// chainsInDataDir returns the chains that have a log db in the data directory, ordered by chain ID.
+func chainsInDataDir(datadir string) ([]types.ChainID, error) {
+	entries, err := os.ReadDir(datadir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read data directory %v: %w", datadir, err)
+	}
+	var chains []types.ChainID
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		id, ok := new(big.Int).SetString(entry.Name(), 10)
+		if !ok {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(datadir, entry.Name(), "log.db")); err != nil {
+			continue
+		}
+		chains = append(chains, types.ChainIDFromBig(id))
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		return (*uint256.Int)(&chains[i]).Lt((*uint256.Int)(&chains[j]))
+	})
+	return chains, nil
+}
+
+func headsPath(datadir string) string {
+	return filepath.Join(datadir, "heads.json")
+}
+
+func snapshotManifestPath(dir string) string {
+	return filepath.Join(dir, "manifest.json")
+}
+
 func prepDataDir(datadir string) error {
 	if err := os.MkdirAll(datadir, 0755); err != nil {
 		return fmt.Errorf("failed to create data directory %v: %w", datadir, err)
@@ -55,6 +55,14 @@ func (m *MockBackend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash, b
 	return types.CrossUnsafe, nil
 }
 
+func (m *MockBackend) ExecutingMessages(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) ([]types.ExecutingMessageLineage, error) {
+	return []types.ExecutingMessageLineage{}, nil
+}
+
+func (m *MockBackend) BlockDependency(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.BlockDependency, error) {
+	return types.BlockDependency{Safety: types.CrossUnsafe}, nil
+}
+
 func (m *MockBackend) Close() error {
 	return nil
 }
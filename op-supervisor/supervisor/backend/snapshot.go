@@ -0,0 +1,430 @@
+package backend
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ethereum-optimism/optimism/op-service/dial"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+	"github.com/ethereum-optimism/optimism/op-service/jsonutil"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+const (
+	// verifyProgressInterval is the number of blocks between the progress logs of verifying the blocks of a snapshot.
+	verifyProgressInterval = 100_000
+	// verifyConcurrency is the number of blocks of a snapshot that are fetched concurrently to verify them.
+	verifyConcurrency = 32
+)
+
+// SnapshotManifest describes a snapshot of the supervisor data directory.
+// The snapshot uses the same file layout as the data directory itself,
+// with the manifest stored next to the heads file.
+type SnapshotManifest struct {
+	Chains []SnapshotChain `json:"chains"`
+	// HeadsHash is the Keccak256 hash of the heads file of the snapshot
+	HeadsHash common.Hash `json:"headsHash"`
+}
+
+// SnapshotChain describes the log db of a single chain in a snapshot.
+type SnapshotChain struct {
+	ChainID hexutil.U256 `json:"chainID"`
+	// BlockNumber is the last block in the log db, the latest cross-finalized block of the chain at export time
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	// BlockHash is the truncated hash the block was sealed with
+	BlockHash hexutil.Bytes `json:"blockHash"`
+	// Entries is the number of entries in the log db
+	Entries hexutil.Uint64 `json:"entries"`
+	// LogDBHash is the Keccak256 hash of the log db file
+	LogDBHash common.Hash `json:"logDBHash"`
+}
+
+// l2HeaderSource provides the canonical L2 blocks a snapshot is verified against.
+type l2HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error)
+}
+
+// ExportSnapshot writes a snapshot of the supervisor data directory to outDir.
+// The log db of every chain is cut at the last block that is cross-finalized, and the heads are limited to it.
+// Entries up to the cross-finalized block are never rewound, so the snapshot is consistent
+// even if a supervisor is still writing to the data directory.
+func ExportSnapshot(logger log.Logger, m Metrics, datadir string, outDir string) (*SnapshotManifest, error) {
+	if entries, err := os.ReadDir(outDir); err == nil && len(entries) > 0 {
+		return nil, fmt.Errorf("snapshot directory %v is not empty", outDir)
+	}
+	if err := prepDataDir(outDir); err != nil {
+		return nil, err
+	}
+	headTracker, err := heads.NewHeadTracker(headsPath(datadir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load existing heads: %w", err)
+	}
+	current := headTracker.Current()
+	chains, err := chainsInDataDir(datadir)
+	if err != nil {
+		return nil, err
+	}
+	if len(chains) == 0 {
+		return nil, fmt.Errorf("no chains found in data directory %v", datadir)
+	}
+	manifest := &SnapshotManifest{}
+	snapshotHeads := heads.NewHeads()
+	for _, chainID := range chains {
+		chain, chainHeads, err := exportChain(logger, m, chainID, current.Get(chainID), datadir, outDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to export chain %v: %w", chainID, err)
+		}
+		manifest.Chains = append(manifest.Chains, chain)
+		snapshotHeads.Put(chainID, chainHeads)
+	}
+	if err := jsonutil.WriteJSON(snapshotHeads, ioutil.ToAtomicFile(headsPath(outDir), 0o644)); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot heads: %w", err)
+	}
+	manifest.HeadsHash, _, err = hashFile(headsPath(outDir))
+	if err != nil {
+		return nil, err
+	}
+	if err := jsonutil.WriteJSON(manifest, ioutil.ToAtomicFile(snapshotManifestPath(outDir), 0o644)); err != nil {
+		return nil, fmt.Errorf("failed to write snapshot manifest: %w", err)
+	}
+	logger.Info("Exported supervisor snapshot", "dir", outDir, "chains", len(manifest.Chains))
+	return manifest, nil
+}
+
+func exportChain(logger log.Logger, m Metrics, chainID types.ChainID, chainHeads heads.ChainHeads, datadir string, outDir string) (SnapshotChain, heads.ChainHeads, error) {
+	src, err := prepLogDBPath(chainID, datadir)
+	if err != nil {
+		return SnapshotChain{}, heads.ChainHeads{}, err
+	}
+	dst, err := prepLogDBPath(chainID, outDir)
+	if err != nil {
+		return SnapshotChain{}, heads.ChainHeads{}, err
+	}
+	if _, _, err := copyFile(src, dst); err != nil {
+		return SnapshotChain{}, heads.ChainHeads{}, err
+	}
+	// Cut the copy, never the log db of the data directory itself
+	logDB, err := logs.NewFromFile(logger, newChainMetrics(chainID, m), dst)
+	if err != nil {
+		return SnapshotChain{}, heads.ChainHeads{}, fmt.Errorf("failed to open log db copy: %w", err)
+	}
+	blockNum, blockHash, sealIdx, err := lastSealedBlockAt(logDB, chainHeads.CrossFinalized)
+	if errors.Is(err, logs.ErrNotFound) {
+		_ = logDB.Close()
+		return SnapshotChain{}, heads.ChainHeads{}, errors.New("no cross-finalized block to snapshot")
+	} else if err != nil {
+		_ = logDB.Close()
+		return SnapshotChain{}, heads.ChainHeads{}, err
+	}
+	if err := logDB.Rewind(blockNum); err != nil {
+		_ = logDB.Close()
+		return SnapshotChain{}, heads.ChainHeads{}, fmt.Errorf("failed to rewind log db copy to block %v: %w", blockNum, err)
+	}
+	if err := logDB.Close(); err != nil {
+		return SnapshotChain{}, heads.ChainHeads{}, fmt.Errorf("failed to close log db copy: %w", err)
+	}
+	fileHash, size, err := hashFile(dst)
+	if err != nil {
+		return SnapshotChain{}, heads.ChainHeads{}, err
+	}
+	logger.Info("Exported chain", "chain", chainID, "block", blockNum, "entries", size/entrydb.EntrySize)
+	return SnapshotChain{
+		ChainID:     hexutil.U256(chainID),
+		BlockNumber: hexutil.Uint64(blockNum),
+		BlockHash:   blockHash[:],
+		Entries:     hexutil.Uint64(size / entrydb.EntrySize),
+		LogDBHash:   fileHash,
+	}, heads.ChainHeads{
+		Unsafe:         min(chainHeads.Unsafe, sealIdx),
+		CrossUnsafe:    min(chainHeads.CrossUnsafe, sealIdx),
+		LocalSafe:      min(chainHeads.LocalSafe, sealIdx),
+		CrossSafe:      min(chainHeads.CrossSafe, sealIdx),
+		LocalFinalized: min(chainHeads.LocalFinalized, sealIdx),
+		CrossFinalized: min(chainHeads.CrossFinalized, sealIdx),
+	}, nil
+}
+
+// lastSealedBlockAt returns the number, hash and seal entry index of the last block that was sealed at or before idx.
+// Returns ErrNotFound if no block was sealed at or before idx.
+func lastSealedBlockAt(logDB *logs.DB, idx entrydb.EntryIdx) (uint64, backendTypes.TruncatedHash, entrydb.EntryIdx, error) {
+	var searchErr error
+	// Blocks are sealed in order, so the seal indices of the blocks are increasing
+	n := sort.Search(int(logDB.LatestBlockNum())+1, func(i int) bool {
+		_, sealIdx, err := logDB.FindSealedBlock(uint64(i))
+		if errors.Is(err, logs.ErrFuture) {
+			return true
+		} else if errors.Is(err, logs.ErrNotFound) {
+			// before the first block in the log db
+			return false
+		} else if err != nil {
+			searchErr = err
+			return true
+		}
+		return sealIdx > idx
+	})
+	if searchErr != nil {
+		return 0, backendTypes.TruncatedHash{}, 0, fmt.Errorf("failed to search sealed blocks: %w", searchErr)
+	}
+	if n == 0 {
+		return 0, backendTypes.TruncatedHash{}, 0, logs.ErrNotFound
+	}
+	blockNum := uint64(n - 1)
+	hash, sealIdx, err := logDB.FindSealedBlock(blockNum)
+	if err != nil {
+		return 0, backendTypes.TruncatedHash{}, 0, err
+	}
+	return blockNum, hash, sealIdx, nil
+}
+
+// ImportSnapshot imports a snapshot into an empty data directory,
+// after verifying the snapshot files against the manifest and the snapshot blocks against the given L2 RPCs.
+// Every chain in the snapshot must have an L2 RPC.
+//
+// The hash of every sealed block of the log dbs is checked against the canonical chain of the RPC,
+// but the logs of the blocks are not re-derived from receipts: the manifest only protects the integrity of the files,
+// so the log entries are trusted to be the ones of the source of the snapshot.
+// Indexing resumes from the snapshot blocks when the supervisor is started on the data directory.
+func ImportSnapshot(ctx context.Context, logger log.Logger, m Metrics, snapshotDir string, datadir string, l2RPCs []string) error {
+	sources := make(map[types.ChainID]l2HeaderSource, len(l2RPCs))
+	for _, rpc := range l2RPCs {
+		ethClient, err := dial.DialEthClientWithTimeout(ctx, dial.DefaultDialTimeout, logger, rpc)
+		if err != nil {
+			return fmt.Errorf("failed to connect to rpc %v: %w", rpc, err)
+		}
+		defer ethClient.Close()
+		chainID, err := ethClient.ChainID(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load chain id for rpc %v: %w", rpc, err)
+		}
+		sources[types.ChainIDFromBig(chainID)] = ethClient
+	}
+	return importSnapshot(ctx, logger, m, snapshotDir, datadir, sources)
+}
+
+func importSnapshot(ctx context.Context, logger log.Logger, m Metrics, snapshotDir string, datadir string, sources map[types.ChainID]l2HeaderSource) (outErr error) {
+	manifest, err := jsonutil.LoadJSON[SnapshotManifest](snapshotManifestPath(snapshotDir))
+	if err != nil {
+		return fmt.Errorf("failed to load snapshot manifest: %w", err)
+	}
+	if _, err := os.Stat(headsPath(datadir)); err == nil {
+		return fmt.Errorf("data directory %v already contains supervisor data", datadir)
+	}
+	headsHash, _, err := hashFile(headsPath(snapshotDir))
+	if err != nil {
+		return err
+	}
+	if headsHash != manifest.HeadsHash {
+		return fmt.Errorf("snapshot heads hash %v does not match manifest hash %v", headsHash, manifest.HeadsHash)
+	}
+	for _, chain := range manifest.Chains {
+		if err := verifyCanonical(ctx, chain, sources); err != nil {
+			return err
+		}
+	}
+
+	if err := prepDataDir(datadir); err != nil {
+		return err
+	}
+	var imported []string
+	defer func() {
+		if outErr == nil {
+			return
+		}
+		for _, path := range imported {
+			if err := os.Remove(path); err != nil {
+				logger.Warn("Failed to remove partially imported log db", "path", path, "err", err)
+			}
+		}
+	}()
+	for _, chain := range manifest.Chains {
+		path, err := importChain(ctx, logger, m, chain, snapshotDir, datadir, sources[types.ChainID(chain.ChainID)])
+		if path != "" {
+			imported = append(imported, path)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to import chain %v: %w", types.ChainID(chain.ChainID), err)
+		}
+	}
+	// Write the heads last, so the data directory is only considered in-use once all log dbs are in place
+	if _, _, err := copyFile(headsPath(snapshotDir), headsPath(datadir)); err != nil {
+		return err
+	}
+	for chainID := range sources {
+		if !manifestHasChain(manifest, chainID) {
+			logger.Warn("Chain is not in snapshot, it will be indexed from genesis", "chain", chainID)
+		}
+	}
+	logger.Info("Imported supervisor snapshot", "dir", snapshotDir, "chains", len(manifest.Chains))
+	return nil
+}
+
+// verifyCanonical checks that the last block of the snapshot of the chain is canonical,
+// to fail early before any files are copied. The hashes the blocks are sealed with are not linked to each other,
+// the other blocks of the log db are checked once it is imported, by verifySealedBlocks.
+func verifyCanonical(ctx context.Context, chain SnapshotChain, sources map[types.ChainID]l2HeaderSource) error {
+	chainID := types.ChainID(chain.ChainID)
+	source, ok := sources[chainID]
+	if !ok {
+		return fmt.Errorf("no L2 RPC for chain %v of the snapshot", chainID)
+	}
+	header, err := source.HeaderByNumber(ctx, new(big.Int).SetUint64(uint64(chain.BlockNumber)))
+	if err != nil {
+		return fmt.Errorf("failed to fetch block %v of chain %v: %w", uint64(chain.BlockNumber), chainID, err)
+	}
+	canonical := backendTypes.TruncateHash(header.Hash())
+	if !bytes.Equal(canonical[:], chain.BlockHash) {
+		return fmt.Errorf("snapshot block %v of chain %v with hash %v is not canonical, expected %v",
+			uint64(chain.BlockNumber), chainID, chain.BlockHash, header.Hash())
+	}
+	return nil
+}
+
+// importChain copies the log db of the chain into the data directory and verifies it.
+// The path of the log db is returned if it was written, even if verification failed.
+func importChain(ctx context.Context, logger log.Logger, m Metrics, chain SnapshotChain, snapshotDir string, datadir string, source l2HeaderSource) (string, error) {
+	chainID := types.ChainID(chain.ChainID)
+	src, err := prepLogDBPath(chainID, snapshotDir)
+	if err != nil {
+		return "", err
+	}
+	dst, err := prepLogDBPath(chainID, datadir)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("log db %v already exists", dst)
+	}
+	fileHash, size, err := copyFile(src, dst)
+	if err != nil {
+		return "", err
+	}
+	if fileHash != chain.LogDBHash {
+		return dst, fmt.Errorf("log db hash %v does not match manifest hash %v", fileHash, chain.LogDBHash)
+	}
+	if size != int64(chain.Entries)*entrydb.EntrySize {
+		return dst, fmt.Errorf("log db has %v bytes, manifest has %v entries", size, uint64(chain.Entries))
+	}
+	logDB, err := logs.NewFromFile(logger, newChainMetrics(chainID, m), dst)
+	if err != nil {
+		return dst, fmt.Errorf("failed to open log db: %w", err)
+	}
+	defer logDB.Close()
+	if latest := logDB.LatestBlockNum(); latest != uint64(chain.BlockNumber) {
+		return dst, fmt.Errorf("log db ends at block %v, manifest has block %v", latest, uint64(chain.BlockNumber))
+	}
+	hash, _, err := logDB.FindSealedBlock(uint64(chain.BlockNumber))
+	if err != nil {
+		return dst, fmt.Errorf("failed to find block %v: %w", uint64(chain.BlockNumber), err)
+	}
+	if !bytes.Equal(hash[:], chain.BlockHash) {
+		return dst, fmt.Errorf("log db block %v has hash %v, manifest has %v", uint64(chain.BlockNumber), hash, chain.BlockHash)
+	}
+	if err := verifySealedBlocks(ctx, logger, chainID, logDB, source); err != nil {
+		return dst, err
+	}
+	logger.Info("Imported chain", "chain", chainID, "block", uint64(chain.BlockNumber), "entries", uint64(chain.Entries))
+	return dst, nil
+}
+
+// verifySealedBlocks checks the hash of every sealed block of the log db against the canonical chain.
+// The blocks are fetched by up to verifyConcurrency requests at a time.
+func verifySealedBlocks(ctx context.Context, logger log.Logger, chainID types.ChainID, logDB *logs.DB, source l2HeaderSource) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(verifyConcurrency)
+	var verified atomic.Uint64
+	err := logDB.ForEachSealedBlock(func(blockNum uint64, hash backendTypes.TruncatedHash) error {
+		// stop iterating once a block failed verification
+		if err := groupCtx.Err(); err != nil {
+			return err
+		}
+		group.Go(func() error {
+			header, err := source.HeaderByNumber(groupCtx, new(big.Int).SetUint64(blockNum))
+			if err != nil {
+				return fmt.Errorf("failed to fetch block %v of chain %v: %w", blockNum, chainID, err)
+			}
+			if canonical := backendTypes.TruncateHash(header.Hash()); canonical != hash {
+				return fmt.Errorf("snapshot block %v of chain %v with hash %v is not canonical, expected %v",
+					blockNum, chainID, hash, header.Hash())
+			}
+			if n := verified.Add(1); n%verifyProgressInterval == 0 {
+				logger.Info("Verifying snapshot blocks", "chain", chainID, "block", blockNum, "verified", n)
+			}
+			return nil
+		})
+		return nil
+	})
+	// the error of the failed block takes precedence over the iteration stopping because of it
+	if groupErr := group.Wait(); groupErr != nil {
+		return groupErr
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Verified snapshot blocks", "chain", chainID, "verified", verified.Load())
+	return nil
+}
+
+func manifestHasChain(manifest *SnapshotManifest, chainID types.ChainID) bool {
+	for _, chain := range manifest.Chains {
+		if types.ChainID(chain.ChainID) == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// copyFile atomically copies src to dst, and returns the Keccak256 hash and size of the data.
+func copyFile(src string, dst string) (common.Hash, int64, error) {
+	in, err := os.Open(src)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to open %v: %w", src, err)
+	}
+	defer in.Close()
+	out, err := ioutil.NewAtomicWriter(dst, 0o644)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to create %v: %w", dst, err)
+	}
+	hasher := crypto.NewKeccakState()
+	size, err := io.Copy(io.MultiWriter(out, hasher), in)
+	if err != nil {
+		_ = out.Abort()
+		return common.Hash{}, 0, fmt.Errorf("failed to copy %v to %v: %w", src, dst, err)
+	}
+	if err := out.Close(); err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to write %v: %w", dst, err)
+	}
+	return common.BytesToHash(hasher.Sum(nil)), size, nil
+}
+
+// hashFile returns the Keccak256 hash and size of the file.
+func hashFile(path string) (common.Hash, int64, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to open %v: %w", path, err)
+	}
+	defer in.Close()
+	hasher := crypto.NewKeccakState()
+	size, err := io.Copy(hasher, in)
+	if err != nil {
+		return common.Hash{}, 0, fmt.Errorf("failed to hash %v: %w", path, err)
+	}
+	return common.BytesToHash(hasher.Sum(nil)), size, nil
+}
@@ -0,0 +1,223 @@
+package backend
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/metrics"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/heads"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
+)
+
+func TestSnapshotExportImport(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	chainID := types.ChainIDFromUInt64(900)
+	canonical := &stubHeaderSource{headers: make(map[uint64]*ethtypes.Header)}
+	for i := uint64(1); i <= 4; i++ {
+		canonical.headers[i] = &ethtypes.Header{Number: new(big.Int).SetUint64(i), Time: 1000 + i}
+	}
+	block := func(num uint64) eth.BlockID {
+		return eth.BlockID{Hash: canonical.headers[num].Hash(), Number: num}
+	}
+
+	datadir := t.TempDir()
+	path, err := prepLogDBPath(chainID, datadir)
+	require.NoError(t, err)
+	logDB, err := logs.NewFromFile(logger, &stubLogsMetrics{}, path)
+	require.NoError(t, err)
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x10}, block(1), 1001, 0, nil))
+	require.NoError(t, logDB.SealBlock(block(1), 1001))
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x20}, block(2), 1002, 0, nil))
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x21}, block(2), 1002, 1, nil))
+	require.NoError(t, logDB.SealBlock(block(2), 1002))
+	_, block2Seal, err := logDB.FindSealedBlock(2)
+	require.NoError(t, err)
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x30}, block(3), 1003, 0, nil))
+	require.NoError(t, logDB.SealBlock(block(3), 1003))
+	require.NoError(t, logDB.AddLog(backendTypes.TruncatedHash{0x40}, block(4), 1004, 0, nil))
+	require.NoError(t, logDB.Close())
+
+	headTracker, err := heads.NewHeadTracker(headsPath(datadir))
+	require.NoError(t, err)
+	require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+		// Cross-finalized up to the first log of block 3, which is not fully finalized yet
+		h.Put(chainID, heads.ChainHeads{CrossUnsafe: block2Seal + 3, CrossSafe: block2Seal + 1, CrossFinalized: block2Seal + 1})
+		return nil
+	})))
+
+	snapshotDir := filepath.Join(t.TempDir(), "snapshot")
+	manifest, err := ExportSnapshot(logger, metrics.NoopMetrics, datadir, snapshotDir)
+	require.NoError(t, err)
+	require.Len(t, manifest.Chains, 1)
+	require.Equal(t, uint64(2), uint64(manifest.Chains[0].BlockNumber))
+	truncated := backendTypes.TruncateHash(block(2).Hash)
+	require.Equal(t, truncated[:], []byte(manifest.Chains[0].BlockHash))
+	require.Equal(t, int64(block2Seal)+1, int64(manifest.Chains[0].Entries))
+
+	_, err = ExportSnapshot(logger, metrics.NoopMetrics, datadir, snapshotDir)
+	require.ErrorContains(t, err, "not empty")
+
+	t.Run("Import", func(t *testing.T) {
+		target := t.TempDir()
+		sources := map[types.ChainID]l2HeaderSource{chainID: canonical}
+		require.NoError(t, importSnapshot(context.Background(), logger, metrics.NoopMetrics, snapshotDir, target, sources))
+
+		path, err := prepLogDBPath(chainID, target)
+		require.NoError(t, err)
+		imported, err := logs.NewFromFile(logger, &stubLogsMetrics{}, path)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = imported.Close() })
+		require.Equal(t, uint64(2), imported.LatestBlockNum())
+		hash, _, err := imported.FindSealedBlock(2)
+		require.NoError(t, err)
+		require.Equal(t, truncated, hash)
+
+		importedHeads, err := heads.NewHeadTracker(headsPath(target))
+		require.NoError(t, err)
+		require.Equal(t, heads.ChainHeads{CrossUnsafe: block2Seal, CrossSafe: block2Seal, CrossFinalized: block2Seal},
+			importedHeads.Current().Get(chainID))
+
+		err = importSnapshot(context.Background(), logger, metrics.NoopMetrics, snapshotDir, target, sources)
+		require.ErrorContains(t, err, "already contains supervisor data")
+	})
+
+	t.Run("MissingRPC", func(t *testing.T) {
+		err := importSnapshot(context.Background(), logger, metrics.NoopMetrics, snapshotDir, t.TempDir(), nil)
+		require.ErrorContains(t, err, "no L2 RPC")
+	})
+
+	t.Run("NotCanonical", func(t *testing.T) {
+		reorged := &stubHeaderSource{headers: map[uint64]*ethtypes.Header{
+			2: {Number: big.NewInt(2), Time: 999},
+		}}
+		sources := map[types.ChainID]l2HeaderSource{chainID: reorged}
+		err := importSnapshot(context.Background(), logger, metrics.NoopMetrics, snapshotDir, t.TempDir(), sources)
+		require.ErrorContains(t, err, "is not canonical")
+	})
+
+	t.Run("EarlierBlockNotCanonical", func(t *testing.T) {
+		// The snapshot block is canonical, but the block before it is not
+		reorged := &stubHeaderSource{headers: map[uint64]*ethtypes.Header{
+			1: {Number: big.NewInt(1), Time: 999},
+			2: canonical.headers[2],
+		}}
+		target := t.TempDir()
+		sources := map[types.ChainID]l2HeaderSource{chainID: reorged}
+		err := importSnapshot(context.Background(), logger, metrics.NoopMetrics, snapshotDir, target, sources)
+		require.ErrorContains(t, err, "snapshot block 1")
+		require.ErrorContains(t, err, "is not canonical")
+		path, err := prepLogDBPath(chainID, target)
+		require.NoError(t, err)
+		require.NoFileExists(t, path, "unverified log db is removed")
+		require.NoFileExists(t, headsPath(target))
+	})
+
+	t.Run("CorruptLogDB", func(t *testing.T) {
+		corruptDir := t.TempDir()
+		_, _, err := copyFile(snapshotManifestPath(snapshotDir), snapshotManifestPath(corruptDir))
+		require.NoError(t, err)
+		_, _, err = copyFile(headsPath(snapshotDir), headsPath(corruptDir))
+		require.NoError(t, err)
+		src, err := prepLogDBPath(chainID, snapshotDir)
+		require.NoError(t, err)
+		data, err := os.ReadFile(src)
+		require.NoError(t, err)
+		data[len(data)-1] ^= 0xff
+		dst, err := prepLogDBPath(chainID, corruptDir)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(dst, data, 0o644))
+
+		target := t.TempDir()
+		sources := map[types.ChainID]l2HeaderSource{chainID: canonical}
+		err = importSnapshot(context.Background(), logger, metrics.NoopMetrics, corruptDir, target, sources)
+		require.ErrorContains(t, err, "does not match manifest hash")
+		path, err := prepLogDBPath(chainID, target)
+		require.NoError(t, err)
+		require.NoFileExists(t, path, "partially imported log db is removed")
+		require.NoFileExists(t, headsPath(target))
+	})
+
+	t.Run("NoFinalizedBlock", func(t *testing.T) {
+		require.NoError(t, headTracker.Apply(heads.OperationFn(func(h *heads.Heads) error {
+			h.Put(chainID, heads.ChainHeads{})
+			return nil
+		})))
+		_, err := ExportSnapshot(logger, metrics.NoopMetrics, datadir, t.TempDir())
+		require.ErrorContains(t, err, "no cross-finalized block")
+	})
+}
+
+type stubHeaderSource struct {
+	headers map[uint64]*ethtypes.Header
+}
+
+func (s *stubHeaderSource) HeaderByNumber(_ context.Context, number *big.Int) (*ethtypes.Header, error) {
+	header, ok := s.headers[number.Uint64()]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return header, nil
+}
+
+// concurrentHeaderSource tracks the number of headers that are fetched at the same time.
+type concurrentHeaderSource struct {
+	stubHeaderSource
+	inFlight    atomic.Int64
+	maxInFlight atomic.Int64
+}
+
+func (s *concurrentHeaderSource) HeaderByNumber(ctx context.Context, number *big.Int) (*ethtypes.Header, error) {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		m := s.maxInFlight.Load()
+		if n <= m || s.maxInFlight.CompareAndSwap(m, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return s.stubHeaderSource.HeaderByNumber(ctx, number)
+}
+
+func TestVerifySealedBlocks(t *testing.T) {
+	logger := testlog.Logger(t, log.LevelInfo)
+	chainID := types.ChainIDFromUInt64(900)
+	blocks := uint64(4 * verifyConcurrency)
+	source := &concurrentHeaderSource{stubHeaderSource: stubHeaderSource{headers: make(map[uint64]*ethtypes.Header)}}
+	logDB, err := logs.NewFromFile(logger, &stubLogsMetrics{}, filepath.Join(t.TempDir(), "log.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = logDB.Close() })
+	for i := uint64(1); i <= blocks; i++ {
+		header := &ethtypes.Header{Number: new(big.Int).SetUint64(i), Time: 1000 + i}
+		source.headers[i] = header
+		require.NoError(t, logDB.SealBlock(eth.BlockID{Hash: header.Hash(), Number: i}, header.Time))
+	}
+
+	t.Run("Canonical", func(t *testing.T) {
+		require.NoError(t, verifySealedBlocks(context.Background(), logger, chainID, logDB, source))
+		require.Greater(t, source.maxInFlight.Load(), int64(1), "blocks are fetched concurrently")
+		require.LessOrEqual(t, source.maxInFlight.Load(), int64(verifyConcurrency))
+	})
+
+	t.Run("NotCanonical", func(t *testing.T) {
+		source.headers[blocks/2] = &ethtypes.Header{Number: new(big.Int).SetUint64(blocks / 2), Time: 999}
+		err := verifySealedBlocks(context.Background(), logger, chainID, logDB, source)
+		require.ErrorContains(t, err, fmt.Sprintf("snapshot block %v", blocks/2))
+		require.ErrorContains(t, err, "is not canonical")
+	})
+}
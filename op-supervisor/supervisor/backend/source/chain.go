@@ -21,6 +21,7 @@ const rpcKind = sources.RPCKindStandard
 
 type Metrics interface {
 	caching.Metrics
+	ReorgMetrics
 }
 
 type Storage interface {
@@ -49,7 +50,7 @@ func NewChainMonitor(ctx context.Context, logger log.Logger, m Metrics, chainID
 
 	processLogs := newLogProcessor(chainID, store)
 	fetchReceipts := newLogFetcher(cl, processLogs)
-	unsafeBlockProcessor := NewChainProcessor(logger, cl, chainID, startingHead, fetchReceipts, store)
+	unsafeBlockProcessor := NewChainProcessor(logger, cl, chainID, startingHead, fetchReceipts, store, m)
 
 	unsafeProcessors := []HeadProcessor{unsafeBlockProcessor}
 	callback := newHeadUpdateProcessor(logger, unsafeProcessors, nil, nil)
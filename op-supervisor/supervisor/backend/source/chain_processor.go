@@ -2,8 +2,13 @@ package source
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -16,8 +21,15 @@ type BlockProcessor interface {
 	ProcessBlock(ctx context.Context, block eth.L1BlockRef) error
 }
 
+// DatabaseRewinder rewinds the database of a chain, and provides the block hashes
+// that are used to find the common ancestor to rewind to when the chain reorgs.
 type DatabaseRewinder interface {
 	Rewind(chain types.ChainID, headBlockNum uint64) error
+	FindSealedBlock(chain types.ChainID, blockNum uint64) (backendTypes.TruncatedHash, entrydb.EntryIdx, error)
+}
+
+type ReorgMetrics interface {
+	RecordReorg(depth uint64)
 }
 
 type BlockProcessorFn func(ctx context.Context, block eth.L1BlockRef) error
@@ -28,6 +40,8 @@ func (fn BlockProcessorFn) ProcessBlock(ctx context.Context, block eth.L1BlockRe
 
 // ChainProcessor is a HeadProcessor that fills in any skipped blocks between head update events.
 // It ensures that, absent reorgs, every block in the chain is processed even if some head advancements are skipped.
+// When the parent hash of a block does not match the hash the previous block was recorded with, the chain reorged:
+// the database is rewound to the common ancestor, and processing continues from there.
 type ChainProcessor struct {
 	log       log.Logger
 	client    BlockByNumberSource
@@ -35,9 +49,10 @@ type ChainProcessor struct {
 	lastBlock eth.L1BlockRef
 	processor BlockProcessor
 	rewinder  DatabaseRewinder
+	m         ReorgMetrics
 }
 
-func NewChainProcessor(log log.Logger, client BlockByNumberSource, chain types.ChainID, startingHead eth.L1BlockRef, processor BlockProcessor, rewinder DatabaseRewinder) *ChainProcessor {
+func NewChainProcessor(log log.Logger, client BlockByNumberSource, chain types.ChainID, startingHead eth.L1BlockRef, processor BlockProcessor, rewinder DatabaseRewinder, m ReorgMetrics) *ChainProcessor {
 	return &ChainProcessor{
 		log:       log,
 		client:    client,
@@ -45,14 +60,25 @@ func NewChainProcessor(log log.Logger, client BlockByNumberSource, chain types.C
 		lastBlock: startingHead,
 		processor: processor,
 		rewinder:  rewinder,
+		m:         m,
 	}
 }
 
 func (s *ChainProcessor) OnNewHead(ctx context.Context, head eth.L1BlockRef) {
 	s.log.Debug("Processing chain", "chain", s.chain, "head", head)
 	if head.Number <= s.lastBlock.Number {
-		s.log.Info("head is not newer than last processed block", "head", head, "lastBlock", s.lastBlock)
-		return
+		if canonical, err := s.isCanonical(head.ID()); err != nil {
+			s.log.Error("Failed to check head against processed blocks", "head", head, "err", err)
+			return
+		} else if canonical {
+			s.log.Info("head is not newer than last processed block", "head", head, "lastBlock", s.lastBlock)
+			return
+		}
+		// The chain reorged to a head that is not newer than the last processed block
+		if err := s.rewindToCommonAncestor(ctx, head); err != nil {
+			s.log.Error("Failed to rewind to common ancestor", "head", head, "err", err)
+			return
+		}
 	}
 	for s.lastBlock.Number+1 < head.Number {
 		s.log.Debug("Filling in skipped block", "chain", s.chain, "lastBlock", s.lastBlock, "head", head)
@@ -71,6 +97,17 @@ func (s *ChainProcessor) OnNewHead(ctx context.Context, head eth.L1BlockRef) {
 }
 
 func (s *ChainProcessor) processBlock(ctx context.Context, block eth.L1BlockRef) bool {
+	if block.Number > 0 {
+		if parent, err := s.isCanonical(block.ParentID()); err != nil {
+			s.log.Error("Failed to check parent of block", "block", block, "err", err)
+			return false
+		} else if !parent {
+			if err := s.rewindToCommonAncestor(ctx, block); err != nil {
+				s.log.Error("Failed to rewind to common ancestor", "block", block, "err", err)
+			}
+			return false // Continue from the common ancestor on next update
+		}
+	}
 	if err := s.processor.ProcessBlock(ctx, block); err != nil {
 		s.log.Error("Failed to process block", "block", block, "err", err)
 		// Try to rewind the database to the previous block to remove any logs from this block that were written
@@ -84,3 +121,49 @@ func (s *ChainProcessor) processBlock(ctx context.Context, block eth.L1BlockRef)
 	s.lastBlock = block
 	return true
 }
+
+// isCanonical returns false if the database recorded a different hash for the block,
+// and true if it recorded the same hash or the block is not in the database.
+func (s *ChainProcessor) isCanonical(block eth.BlockID) (bool, error) {
+	hash, _, err := s.rewinder.FindSealedBlock(s.chain, block.Number)
+	if errors.Is(err, logs.ErrNotFound) || errors.Is(err, logs.ErrFuture) {
+		return true, nil
+	} else if err != nil {
+		return false, err
+	}
+	return hash == backendTypes.TruncateHash(block.Hash), nil
+}
+
+// rewindToCommonAncestor rewinds the database to the latest block before the given block that
+// was recorded with the same hash as the current chain has, and continues processing from there.
+func (s *ChainProcessor) rewindToCommonAncestor(ctx context.Context, block eth.L1BlockRef) error {
+	num := min(block.Number, s.lastBlock.Number+1)
+	var ancestor eth.L1BlockRef
+	for {
+		if num == 0 {
+			return fmt.Errorf("no common ancestor found for block %v", block)
+		}
+		num--
+		ref, err := s.client.L1BlockRefByNumber(ctx, num)
+		if err != nil {
+			return fmt.Errorf("failed to fetch block %v: %w", num, err)
+		}
+		canonical, err := s.isCanonical(ref.ID())
+		if err != nil {
+			return fmt.Errorf("failed to check block %v: %w", num, err)
+		}
+		if canonical {
+			ancestor = ref
+			break
+		}
+	}
+	depth := s.lastBlock.Number - ancestor.Number
+	s.log.Warn("Detected reorg, rewinding to common ancestor", "block", block, "lastBlock", s.lastBlock,
+		"commonAncestor", ancestor, "depth", depth)
+	if err := s.rewinder.Rewind(s.chain, ancestor.Number); err != nil {
+		return fmt.Errorf("failed to rewind to common ancestor %v: %w", ancestor, err)
+	}
+	s.m.RecordReorg(depth)
+	s.lastBlock = ancestor
+	return nil
+}
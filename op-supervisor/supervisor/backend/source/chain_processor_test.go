@@ -8,6 +8,9 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/testlog"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/entrydb"
+	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/db/logs"
+	backendTypes "github.com/ethereum-optimism/optimism/op-supervisor/supervisor/backend/types"
 	"github.com/ethereum-optimism/optimism/op-supervisor/supervisor/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -22,7 +25,7 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		logger := testlog.Logger(t, log.LvlInfo)
 		client := &stubBlockByNumberSource{}
 		processor := &stubBlockProcessor{}
-		stage := NewChainProcessor(logger, client, processorChainID, eth.L1BlockRef{Number: 100}, processor, &stubRewinder{})
+		stage := NewChainProcessor(logger, client, processorChainID, eth.L1BlockRef{Number: 100}, processor, &stubRewinder{}, &stubReorgMetrics{})
 		stage.OnNewHead(ctx, eth.L1BlockRef{Number: 100})
 		stage.OnNewHead(ctx, eth.L1BlockRef{Number: 99})
 
@@ -39,7 +42,7 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		block2 := eth.L1BlockRef{Number: 102}
 		block3 := eth.L1BlockRef{Number: 103}
 		processor := &stubBlockProcessor{}
-		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, &stubRewinder{})
+		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, &stubRewinder{}, &stubReorgMetrics{})
 		stage.OnNewHead(ctx, block1)
 		require.Equal(t, []eth.L1BlockRef{block1}, processor.processed)
 		stage.OnNewHead(ctx, block2)
@@ -57,7 +60,7 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		block0 := eth.L1BlockRef{Number: 100}
 		block1 := eth.L1BlockRef{Number: 101}
 		processor := &stubBlockProcessor{}
-		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, &stubRewinder{})
+		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, &stubRewinder{}, &stubReorgMetrics{})
 		stage.OnNewHead(ctx, block1)
 		require.NotEmpty(t, processor.processed)
 		require.Equal(t, []eth.L1BlockRef{block1}, processor.processed)
@@ -76,7 +79,7 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		block0 := eth.L1BlockRef{Number: 100}
 		block3 := eth.L1BlockRef{Number: 103}
 		processor := &stubBlockProcessor{}
-		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, &stubRewinder{})
+		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, &stubRewinder{}, &stubReorgMetrics{})
 
 		stage.OnNewHead(ctx, block3)
 		require.Equal(t, []eth.L1BlockRef{makeBlockRef(101), makeBlockRef(102), block3}, processor.processed)
@@ -92,7 +95,7 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		block3 := eth.L1BlockRef{Number: 103}
 		processor := &stubBlockProcessor{}
 		rewinder := &stubRewinder{}
-		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, rewinder)
+		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, rewinder, &stubReorgMetrics{})
 
 		stage.OnNewHead(ctx, block3)
 		require.Empty(t, processor.processed, "should not update any blocks because backfill failed")
@@ -111,7 +114,7 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		block3 := eth.L1BlockRef{Number: 103}
 		processor := &stubBlockProcessor{err: errors.New("boom")}
 		rewinder := &stubRewinder{}
-		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, rewinder)
+		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, rewinder, &stubReorgMetrics{})
 
 		stage.OnNewHead(ctx, block3)
 		require.Equal(t, []eth.L1BlockRef{makeBlockRef(101)}, processor.processed, "Attempted to process block 101")
@@ -131,13 +134,57 @@ func TestUnsafeBlocksStage(t *testing.T) {
 		block1 := eth.L1BlockRef{Number: 101}
 		processor := &stubBlockProcessor{err: errors.New("boom")}
 		rewinder := &stubRewinder{}
-		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, rewinder)
+		stage := NewChainProcessor(logger, client, processorChainID, block0, processor, rewinder, &stubReorgMetrics{})
 
 		// No skipped blocks
 		stage.OnNewHead(ctx, block1)
 		require.Equal(t, []eth.L1BlockRef{block1}, processor.processed, "Attempted to process block 101")
 		require.Equal(t, block0.Number, rewinder.rewoundTo, "should rewind to block before error")
 	})
+
+	t.Run("RewindOnParentHashMismatch", func(t *testing.T) {
+		ctx := context.Background()
+		logger := testlog.Logger(t, log.LvlInfo)
+		client := &stubBlockByNumberSource{}
+		processor := &stubBlockProcessor{}
+		rewinder := &stubRewinder{sealed: map[uint64]common.Hash{
+			100: makeBlockRef(100).Hash,
+			101: {0xee}, // reorged out
+		}}
+		m := &stubReorgMetrics{}
+		staleBlock1 := eth.L1BlockRef{Number: 101, Hash: common.Hash{0xee}, ParentHash: makeBlockRef(100).Hash}
+		stage := NewChainProcessor(logger, client, processorChainID, staleBlock1, processor, rewinder, m)
+
+		stage.OnNewHead(ctx, makeBlockRef(102))
+		require.Empty(t, processor.processed, "should not process block of the new chain before rewinding")
+		require.True(t, rewinder.rewindCalled)
+		require.EqualValues(t, 100, rewinder.rewoundTo, "should rewind to common ancestor")
+		require.Equal(t, []uint64{1}, m.depths)
+
+		stage.OnNewHead(ctx, makeBlockRef(102))
+		require.Equal(t, []eth.L1BlockRef{makeBlockRef(101), makeBlockRef(102)}, processor.processed)
+	})
+
+	t.Run("RewindOnReorgToOlderHead", func(t *testing.T) {
+		ctx := context.Background()
+		logger := testlog.Logger(t, log.LvlInfo)
+		client := &stubBlockByNumberSource{}
+		processor := &stubBlockProcessor{}
+		rewinder := &stubRewinder{sealed: map[uint64]common.Hash{
+			100: makeBlockRef(100).Hash,
+			101: {0xee},
+			102: {0xef},
+		}}
+		m := &stubReorgMetrics{}
+		staleBlock2 := eth.L1BlockRef{Number: 102, Hash: common.Hash{0xef}, ParentHash: common.Hash{0xee}}
+		stage := NewChainProcessor(logger, client, processorChainID, staleBlock2, processor, rewinder, m)
+
+		head := eth.L1BlockRef{Number: 101, Hash: common.Hash{0xdd}, ParentHash: makeBlockRef(100).Hash}
+		stage.OnNewHead(ctx, head)
+		require.EqualValues(t, 100, rewinder.rewoundTo, "should rewind to common ancestor")
+		require.Equal(t, []uint64{2}, m.depths)
+		require.Equal(t, []eth.L1BlockRef{head}, processor.processed)
+	})
 }
 
 type stubBlockByNumberSource struct {
@@ -175,6 +222,15 @@ func makeBlockRef(number uint64) eth.L1BlockRef {
 type stubRewinder struct {
 	rewoundTo    uint64
 	rewindCalled bool
+	sealed       map[uint64]common.Hash
+}
+
+func (s *stubRewinder) FindSealedBlock(chainID types.ChainID, blockNum uint64) (backendTypes.TruncatedHash, entrydb.EntryIdx, error) {
+	hash, ok := s.sealed[blockNum]
+	if !ok {
+		return backendTypes.TruncatedHash{}, 0, logs.ErrNotFound
+	}
+	return backendTypes.TruncateHash(hash), entrydb.EntryIdx(blockNum), nil
 }
 
 func (s *stubRewinder) Rewind(chainID types.ChainID, headBlockNum uint64) error {
@@ -183,5 +239,18 @@ func (s *stubRewinder) Rewind(chainID types.ChainID, headBlockNum uint64) error
 	}
 	s.rewoundTo = headBlockNum
 	s.rewindCalled = true
+	for num := range s.sealed {
+		if num > headBlockNum {
+			delete(s.sealed, num)
+		}
+	}
 	return nil
 }
+
+type stubReorgMetrics struct {
+	depths []uint64
+}
+
+func (s *stubReorgMetrics) RecordReorg(depth uint64) {
+	s.depths = append(s.depths, depth)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type TruncatedHash [20]byte
@@ -18,6 +19,18 @@ func (h TruncatedHash) String() string {
 	return hex.EncodeToString(h[:])
 }
 
+// PayloadHashToLogHash converts the payload hash to the log hash
+// it is the concatenation of the log's address and the hash of the log's payload,
+// which is then hashed again. This is the hash that is stored in the log storage.
+// The logHash can then be used to traverse from the executing message
+// to the log the referenced initiating message.
+func PayloadHashToLogHash(payloadHash common.Hash, addr common.Address) TruncatedHash {
+	msg := make([]byte, 0, 2*common.HashLength)
+	msg = append(msg, addr.Bytes()...)
+	msg = append(msg, payloadHash.Bytes()...)
+	return TruncateHash(crypto.Keccak256Hash(msg))
+}
+
 type ExecutingMessage struct {
 	Chain     uint32
 	BlockNum  uint64
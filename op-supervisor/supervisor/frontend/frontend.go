@@ -19,6 +19,8 @@ type QueryBackend interface {
 	CheckMessage(identifier types.Identifier, payloadHash common.Hash) (types.SafetyLevel, error)
 	CheckMessages(messages []types.Message, minSafety types.SafetyLevel) error
 	CheckBlock(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.SafetyLevel, error)
+	ExecutingMessages(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) ([]types.ExecutingMessageLineage, error)
+	BlockDependency(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.BlockDependency, error)
 }
 
 type Backend interface {
@@ -49,6 +51,18 @@ func (q *QueryFrontend) CheckBlock(chainID *hexutil.U256, blockHash common.Hash,
 	return q.Supervisor.CheckBlock(chainID, blockHash, blockNumber)
 }
 
+// ExecutingMessages lists the executing messages of an L2 block,
+// with the initiating message each of them refers to and its safety-level.
+func (q *QueryFrontend) ExecutingMessages(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) ([]types.ExecutingMessageLineage, error) {
+	return q.Supervisor.ExecutingMessages(chainID, blockHash, blockNumber)
+}
+
+// BlockDependency returns the safety-level of an L2 block, and if it has not reached cross-safe,
+// the executing message that it is waiting for.
+func (q *QueryFrontend) BlockDependency(chainID *hexutil.U256, blockHash common.Hash, blockNumber hexutil.Uint64) (types.BlockDependency, error) {
+	return q.Supervisor.BlockDependency(chainID, blockHash, blockNumber)
+}
+
 type AdminFrontend struct {
 	Supervisor Backend
 }
@@ -65,7 +65,7 @@ func (lvl SafetyLevel) String() string {
 
 func (lvl SafetyLevel) Valid() bool {
 	switch lvl {
-	case Finalized, Safe, CrossUnsafe, Unsafe:
+	case CrossFinalized, Finalized, CrossSafe, Safe, CrossUnsafe, Unsafe, Invalid, Unknown:
 		return true
 	default:
 		return false
@@ -94,7 +94,7 @@ func (lvl *SafetyLevel) AtLeastAsSafe(min SafetyLevel) bool {
 	case Invalid:
 		return true
 	case Unsafe:
-		return *lvl != Invalid
+		return *lvl != Invalid && *lvl != Unknown
 	case Safe:
 		return *lvl == Safe || *lvl == Finalized
 	case Finalized:
@@ -112,8 +112,37 @@ const (
 	CrossUnsafe    SafetyLevel = "cross-unsafe"
 	Unsafe         SafetyLevel = "unsafe"
 	Invalid        SafetyLevel = "invalid"
+	// Unknown is the safety level of data that the supervisor has not indexed yet.
+	Unknown SafetyLevel = "unknown"
 )
 
+// InitiatingMessageRef is the initiating message an executing message refers to, as recorded by the supervisor.
+type InitiatingMessageRef struct {
+	ChainID     hexutil.U256   `json:"chainID"`
+	BlockNumber hexutil.Uint64 `json:"blockNumber"`
+	LogIndex    hexutil.Uint64 `json:"logIndex"`
+	Timestamp   hexutil.Uint64 `json:"timestamp"`
+	// LogHash is the truncated hash of the origin and payload hash of the initiating message
+	LogHash hexutil.Bytes `json:"logHash"`
+}
+
+// ExecutingMessageLineage is an executing message in a block, resolved to the initiating message it refers to.
+type ExecutingMessageLineage struct {
+	BlockNumber hexutil.Uint64       `json:"blockNumber"`
+	LogIndex    hexutil.Uint64       `json:"logIndex"`
+	Initiating  InitiatingMessageRef `json:"initiating"`
+	// Safety is the safety level of the initiating message
+	Safety SafetyLevel `json:"safety"`
+}
+
+// BlockDependency describes what a block that has not reached cross-safe is waiting for.
+type BlockDependency struct {
+	Safety SafetyLevel `json:"safety"`
+	// Blocking is the first executing message, in this block or an earlier block of the chain,
+	// of which the initiating message is not safe yet. It is nil if there is none.
+	Blocking *ExecutingMessageLineage `json:"blocking,omitempty"`
+}
+
 type ChainID uint256.Int
 
 func ChainIDFromBig(chainID *big.Int) ChainID {
@@ -31,6 +31,15 @@ var crossL2Inbox []byte
 //go:embed abi/BatchInbox.json
 var batchInbox []byte
 
+//go:embed abi/BasedInbox.json
+var basedInbox []byte
+
+//go:embed abi/BlockDutchAuction.json
+var blockDutchAuction []byte
+
+//go:embed abi/ElectionTickets.json
+var electionTickets []byte
+
 func LoadDisputeGameFactoryABI() *abi.ABI {
 	return loadABI(disputeGameFactory)
 }
@@ -59,6 +68,18 @@ func LoadBatchInboxABI() *abi.ABI {
 	return loadABI(batchInbox)
 }
 
+func LoadBasedInboxABI() *abi.ABI {
+	return loadABI(basedInbox)
+}
+
+func LoadBlockDutchAuctionABI() *abi.ABI {
+	return loadABI(blockDutchAuction)
+}
+
+func LoadElectionTicketsABI() *abi.ABI {
+	return loadABI(electionTickets)
+}
+
 func loadABI(json []byte) *abi.ABI {
 	if parsed, err := abi.JSON(bytes.NewReader(json)); err != nil {
 		panic(err)
@@ -17,6 +17,8 @@ func TestLoadABIs(t *testing.T) {
 		{"PreimageOracle", LoadPreimageOracleABI},
 		{"MIPS", LoadMIPSABI},
 		{"DelayedWETH", LoadDelayedWETHABI},
+		{"BatchInbox", LoadBatchInboxABI},
+		{"BasedInbox", LoadBasedInboxABI},
 	}
 	for _, test := range tests {
 		test := test